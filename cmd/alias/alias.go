@@ -0,0 +1,17 @@
+package alias
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewAliasCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "alias",
+		Short: "Manage index aliases",
+		Long:  "Perform multi-step alias operations such as atomically moving aliases between indices.",
+	}
+
+	cmd.AddCommand(NewSwapCmd())
+
+	return cmd
+}
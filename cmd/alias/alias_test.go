@@ -0,0 +1,22 @@
+package alias
+
+import "testing"
+
+func TestNewAliasCmd(t *testing.T) {
+	cmd := NewAliasCmd()
+
+	if cmd.Use != "alias" {
+		t.Errorf("Expected Use to be 'alias', got %s", cmd.Use)
+	}
+
+	found := false
+	for _, sub := range cmd.Commands() {
+		if sub.Use == "swap OLD_INDEX NEW_INDEX" {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Error("Expected swap subcommand to be added")
+	}
+}
@@ -0,0 +1,78 @@
+package alias
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	pkgalias "github.com/chronicblondiee/searchctl/pkg/alias"
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewSwapCmd() *cobra.Command {
+	var names []string
+
+	cmd := &cobra.Command{
+		Use:   "swap OLD_INDEX NEW_INDEX",
+		Short: "Atomically move aliases from one index to another",
+		Long: `Move aliases from OLD_INDEX to NEW_INDEX in a single atomic _aliases request.
+By default every alias on OLD_INDEX is moved; use --alias to limit the swap to specific aliases.
+Filters, routing, and write index flags are carried over to the new index.`,
+		Example: strings.TrimSpace(`
+  # Blue/green cutover of every alias on the old index
+  searchctl alias swap logs-v1 logs-v2
+
+  # Only move the read alias
+  searchctl alias swap logs-v1 logs-v2 --alias logs-read
+
+  # Preview the actions that would be sent
+  searchctl alias swap logs-v1 logs-v2 --dry-run
+        `),
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			oldIndex, newIndex := args[0], args[1]
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			lookup := "*"
+			if len(names) > 0 {
+				lookup = strings.Join(names, ",")
+			}
+			defs, err := c.GetAlias(lookup)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting aliases: %v\n", err)
+				os.Exit(1)
+			}
+
+			actions, moved, err := pkgalias.SwapActions(defs, oldIndex, newIndex, names)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			if viper.GetBool("dry-run") {
+				actionsJSON, _ := json.MarshalIndent(map[string]interface{}{"actions": actions}, "", "  ")
+				cmd.Printf("Would move aliases %s from %s to %s\nActions:\n%s\n", strings.Join(moved, ","), oldIndex, newIndex, string(actionsJSON))
+				return
+			}
+
+			if err := c.UpdateAliases(actions); err != nil {
+				fmt.Fprintf(os.Stderr, "Error swapping aliases: %v\n", err)
+				os.Exit(1)
+			}
+
+			cmd.Printf("Moved aliases %s from %s to %s\n", strings.Join(moved, ","), oldIndex, newIndex)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&names, "alias", []string{}, "alias names to move (comma-separated, default: all aliases on OLD_INDEX)")
+
+	return cmd
+}
@@ -0,0 +1,244 @@
+package analyze
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/output"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type options struct {
+	index       string
+	analyzer    string
+	tokenizer   string
+	filters     []string
+	charFilters []string
+	field       string
+	normalizer  string
+	explain     bool
+}
+
+func NewAnalyzeCmd() *cobra.Command {
+	var opts options
+
+	cmd := &cobra.Command{
+		Use:   "analyze TEXT...",
+		Short: "Show the tokens an analyzer produces for some text",
+		Long: `Run text through the analyze API and print the resulting tokens with their
+position, offsets and type.
+
+Use --analyzer for a built-in analyzer or, with --index, one defined in that
+index. Alternatively build a chain from --tokenizer, --filter and --char-filter,
+which can be repeated and are applied in order. --field analyzes the text as the
+index would for that field. Tokenizer, filter and char filter values starting
+with '{' are parsed as inline JSON definitions.
+
+--explain prints the tokens after every stage of the chain. Give "-" as the text
+to read it from stdin.`,
+		Example: strings.TrimSpace(`
+# Built-in analyzer
+searchctl analyze --analyzer standard "The Quick Brown Foxes"
+
+# Custom chain
+searchctl analyze --tokenizer whitespace --filter lowercase --filter porter_stem "Running Quickly"
+
+# Analyzer of an index field, stage by stage
+searchctl analyze --index products --field title --explain "Wi-Fi Router AC1200"
+
+# Inline filter definition
+searchctl analyze --tokenizer standard --filter '{"type":"edge_ngram","min_gram":2,"max_gram":5}' "search"`),
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			text, err := readText(args, os.Stdin)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			body, err := buildBody(opts, text)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			result, err := c.AnalyzeText(opts.index, body)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error analyzing text: %v\n", err)
+				os.Exit(1)
+			}
+
+			if err := printResult(cmd.OutOrStdout(), result); err != nil {
+				fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.index, "index", "", "index whose analyzers and mappings are used")
+	cmd.Flags().StringVar(&opts.analyzer, "analyzer", "", "analyzer name")
+	cmd.Flags().StringVar(&opts.tokenizer, "tokenizer", "", "tokenizer name or inline JSON definition")
+	cmd.Flags().StringArrayVar(&opts.filters, "filter", nil, "token filter name or inline JSON definition (repeatable)")
+	cmd.Flags().StringArrayVar(&opts.charFilters, "char-filter", nil, "char filter name or inline JSON definition (repeatable)")
+	cmd.Flags().StringVar(&opts.field, "field", "", "analyze as this field of --index")
+	cmd.Flags().StringVar(&opts.normalizer, "normalizer", "", "normalizer name")
+	cmd.Flags().BoolVar(&opts.explain, "explain", false, "show the tokens after every analysis stage")
+
+	return cmd
+}
+
+// readText joins the arguments, or reads the text from stdin when it is "-"
+func readText(args []string, stdin io.Reader) (string, error) {
+	if len(args) == 1 && args[0] == "-" {
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return "", fmt.Errorf("error reading text: %v", err)
+		}
+		return strings.TrimRight(string(data), "\n"), nil
+	}
+	return strings.Join(args, " "), nil
+}
+
+func buildBody(opts options, text string) (map[string]interface{}, error) {
+	if opts.analyzer != "" && (opts.tokenizer != "" || len(opts.filters) > 0 || len(opts.charFilters) > 0) {
+		return nil, fmt.Errorf("--analyzer cannot be combined with --tokenizer, --filter or --char-filter")
+	}
+	if opts.field != "" && opts.index == "" {
+		return nil, fmt.Errorf("--field requires --index")
+	}
+
+	body := map[string]interface{}{"text": text}
+	if opts.analyzer != "" {
+		body["analyzer"] = opts.analyzer
+	}
+	if opts.normalizer != "" {
+		body["normalizer"] = opts.normalizer
+	}
+	if opts.field != "" {
+		body["field"] = opts.field
+	}
+	if opts.tokenizer != "" {
+		v, err := component(opts.tokenizer)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --tokenizer: %v", err)
+		}
+		body["tokenizer"] = v
+	}
+	if len(opts.filters) > 0 {
+		filters, err := components(opts.filters)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --filter: %v", err)
+		}
+		body["filter"] = filters
+	}
+	if len(opts.charFilters) > 0 {
+		filters, err := components(opts.charFilters)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --char-filter: %v", err)
+		}
+		body["char_filter"] = filters
+	}
+	if opts.explain {
+		body["explain"] = true
+	}
+	return body, nil
+}
+
+// component returns a name as is and parses an inline JSON definition
+func component(value string) (interface{}, error) {
+	if !strings.HasPrefix(strings.TrimSpace(value), "{") {
+		return value, nil
+	}
+	var def map[string]interface{}
+	if err := json.Unmarshal([]byte(value), &def); err != nil {
+		return nil, err
+	}
+	return def, nil
+}
+
+func components(values []string) ([]interface{}, error) {
+	result := make([]interface{}, len(values))
+	for i, v := range values {
+		c, err := component(v)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = c
+	}
+	return result, nil
+}
+
+func printResult(w io.Writer, result *types.AnalyzeResponse) error {
+	outFmt := viper.GetString("output")
+	formatter := output.NewFormatter(outFmt)
+	if outFmt == "json" || outFmt == "yaml" {
+		return formatter.Format(result, w)
+	}
+	if result.Detail == nil {
+		return formatter.Format(tokenRows(result.Tokens), w)
+	}
+
+	for i, s := range stages(result.Detail) {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%s:\n", s.title)
+		if err := formatter.Format(s.rows, w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type stage struct {
+	title string
+	rows  []interface{}
+}
+
+// stages lists the output of every step of an explained analysis in the order it runs
+func stages(d *types.AnalyzeDetail) []stage {
+	var result []stage
+	if d.Analyzer != nil {
+		result = append(result, stage{"analyzer " + d.Analyzer.Name, tokenRows(d.Analyzer.Tokens)})
+	}
+	for _, cf := range d.CharFilters {
+		rows := make([]interface{}, len(cf.FilteredText))
+		for i, text := range cf.FilteredText {
+			rows[i] = map[string]interface{}{"__columns": "TEXT", "TEXT": text}
+		}
+		result = append(result, stage{"char_filter " + cf.Name, rows})
+	}
+	if d.Tokenizer != nil {
+		result = append(result, stage{"tokenizer " + d.Tokenizer.Name, tokenRows(d.Tokenizer.Tokens)})
+	}
+	for _, f := range d.TokenFilters {
+		result = append(result, stage{"filter " + f.Name, tokenRows(f.Tokens)})
+	}
+	return result
+}
+
+func tokenRows(tokens []types.AnalyzeToken) []interface{} {
+	rows := make([]interface{}, len(tokens))
+	for i, t := range tokens {
+		rows[i] = map[string]interface{}{
+			"__columns":    "TOKEN,POSITION,START_OFFSET,END_OFFSET,TYPE",
+			"TOKEN":        t.Token,
+			"POSITION":     t.Position,
+			"START_OFFSET": t.StartOffset,
+			"END_OFFSET":   t.EndOffset,
+			"TYPE":         t.Type,
+		}
+	}
+	return rows
+}
@@ -0,0 +1,89 @@
+package analyze
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/chronicblondiee/searchctl/pkg/types"
+)
+
+func TestNewAnalyzeCmd(t *testing.T) {
+	cmd := NewAnalyzeCmd()
+	if cmd.Use != "analyze TEXT..." {
+		t.Errorf("Expected Use 'analyze TEXT...', got %q", cmd.Use)
+	}
+	for _, name := range []string{"index", "analyzer", "tokenizer", "filter", "char-filter", "field", "normalizer", "explain"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("Expected flag %s", name)
+		}
+	}
+}
+
+func TestBuildBody(t *testing.T) {
+	opts := options{
+		tokenizer: "whitespace",
+		filters:   []string{"lowercase", `{"type":"stop","stopwords":["the"]}`},
+		explain:   true,
+	}
+	body, err := buildBody(opts, "The Fox")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := map[string]interface{}{
+		"text":      "The Fox",
+		"tokenizer": "whitespace",
+		"filter": []interface{}{
+			"lowercase",
+			map[string]interface{}{"type": "stop", "stopwords": []interface{}{"the"}},
+		},
+		"explain": true,
+	}
+	if !reflect.DeepEqual(body, expected) {
+		t.Errorf("Expected %v, got %v", expected, body)
+	}
+
+	if _, err := buildBody(options{analyzer: "standard", filters: []string{"lowercase"}}, "x"); err == nil {
+		t.Error("Expected error combining --analyzer and --filter")
+	}
+	if _, err := buildBody(options{field: "title"}, "x"); err == nil {
+		t.Error("Expected error for --field without --index")
+	}
+	if _, err := buildBody(options{tokenizer: "{bad"}, "x"); err == nil {
+		t.Error("Expected error for invalid inline JSON")
+	}
+}
+
+func TestReadText(t *testing.T) {
+	text, err := readText([]string{"-"}, strings.NewReader("from stdin\n"))
+	if err != nil || text != "from stdin" {
+		t.Errorf("Expected 'from stdin', got %q (%v)", text, err)
+	}
+	text, _ = readText([]string{"two", "words"}, nil)
+	if text != "two words" {
+		t.Errorf("Expected 'two words', got %q", text)
+	}
+}
+
+func TestStages(t *testing.T) {
+	detail := &types.AnalyzeDetail{
+		CustomAnalyzer: true,
+		CharFilters:    []types.AnalyzeCharFilter{{Name: "html_strip", FilteredText: []string{"Fox"}}},
+		Tokenizer:      &types.AnalyzeStage{Name: "standard", Tokens: []types.AnalyzeToken{{Token: "Fox", EndOffset: 3, Type: "<ALPHANUM>"}}},
+		TokenFilters:   []types.AnalyzeStage{{Name: "lowercase", Tokens: []types.AnalyzeToken{{Token: "fox", EndOffset: 3}}}},
+	}
+
+	var titles []string
+	for _, s := range stages(detail) {
+		titles = append(titles, s.title)
+	}
+	expected := []string{"char_filter html_strip", "tokenizer standard", "filter lowercase"}
+	if !reflect.DeepEqual(titles, expected) {
+		t.Errorf("Expected %v, got %v", expected, titles)
+	}
+
+	row := tokenRows(detail.TokenFilters[0].Tokens)[0].(map[string]interface{})
+	if row["TOKEN"] != "fox" || row["END_OFFSET"] != 3 {
+		t.Errorf("Unexpected token row %v", row)
+	}
+}
@@ -80,6 +80,8 @@ func applyConfigurationFromFile(c client.SearchClient, filename string) error {
 		return applyComponentTemplate(c, resource)
 	case "LifecyclePolicy":
 		return applyLifecyclePolicy(c, resource)
+	case "SnapshotPolicy":
+		return applySnapshotPolicy(c, resource)
 	default:
 		return fmt.Errorf("unsupported resource kind: %s", kind)
 	}
@@ -211,6 +213,48 @@ func applyLifecyclePolicy(c client.SearchClient, resource map[string]interface{}
 	return c.CreateLifecyclePolicy(name, spec)
 }
 
+func applySnapshotPolicy(c client.SearchClient, resource map[string]interface{}) error {
+	// Handle both string and interface{} keys in metadata
+	var metadata map[string]interface{}
+	if meta, ok := resource["metadata"].(map[interface{}]interface{}); ok {
+		metadata = make(map[string]interface{})
+		for k, v := range meta {
+			if key, ok := k.(string); ok {
+				metadata[key] = v
+			}
+		}
+	} else if meta, ok := resource["metadata"].(map[string]interface{}); ok {
+		metadata = meta
+	} else {
+		return fmt.Errorf("metadata section missing or invalid")
+	}
+
+	name, ok := metadata["name"].(string)
+	if !ok {
+		return fmt.Errorf("snapshot policy name missing or invalid")
+	}
+
+	// Handle both string and interface{} keys in spec
+	var spec map[string]interface{}
+	if s, ok := resource["spec"].(map[interface{}]interface{}); ok {
+		spec = make(map[string]interface{})
+		for k, v := range s {
+			if key, ok := k.(string); ok {
+				spec[key] = convertInterfaceKeys(v)
+			}
+		}
+	} else if s, ok := resource["spec"].(map[string]interface{}); ok {
+		spec = make(map[string]interface{})
+		for k, v := range s {
+			spec[k] = convertInterfaceKeys(v)
+		}
+	} else {
+		return fmt.Errorf("spec section missing or invalid")
+	}
+
+	return c.CreateSnapshotPolicy(name, spec)
+}
+
 // Convert interface{} keys to string keys recursively
 func convertInterfaceKeys(v interface{}) interface{} {
 	switch val := v.(type) {
@@ -0,0 +1,17 @@
+package cancel
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewCancelCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cancel",
+		Short: "Cancel a running operation",
+		Long:  "Cancel running operations in the search cluster.",
+	}
+
+	cmd.AddCommand(NewCancelTaskCmd())
+
+	return cmd
+}
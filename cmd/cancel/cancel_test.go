@@ -0,0 +1,28 @@
+package cancel
+
+import (
+	"testing"
+)
+
+func TestNewCancelCmd(t *testing.T) {
+	cmd := NewCancelCmd()
+
+	if cmd.Use != "cancel" {
+		t.Errorf("Expected Use 'cancel', got %s", cmd.Use)
+	}
+
+	found := false
+	for _, sub := range cmd.Commands() {
+		if sub.Name() == "task" {
+			found = true
+			for _, name := range []string{"actions", "yes"} {
+				if sub.Flags().Lookup(name) == nil {
+					t.Errorf("Expected flag %s on cancel task", name)
+				}
+			}
+		}
+	}
+	if !found {
+		t.Error("Expected task subcommand")
+	}
+}
@@ -0,0 +1,109 @@
+package cancel
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/output"
+	"github.com/chronicblondiee/searchctl/pkg/prompt"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewCancelTaskCmd() *cobra.Command {
+	var actions string
+
+	cmd := &cobra.Command{
+		Use:     "task [TASK_ID]",
+		Short:   "Cancel a task",
+		Long:    "Cancel a single task by ID, or every cancellable task whose action matches --actions.",
+		Aliases: []string{"tasks"},
+		Example: strings.TrimSpace(`
+# Cancel one task
+searchctl cancel task oTUltX4IQMOUUVeiohTt8A:12345
+
+# Cancel all running reindexes without confirmation
+searchctl cancel task --actions '*reindex*' -y`),
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			taskID := ""
+			if len(args) > 0 {
+				taskID = args[0]
+			}
+			if (taskID == "") == (actions == "") {
+				fmt.Fprintln(os.Stderr, "Error: specify either a TASK_ID or --actions")
+				os.Exit(1)
+			}
+
+			target := "task " + taskID
+			if taskID == "" {
+				target = fmt.Sprintf("tasks matching actions %q", actions)
+			}
+
+			if viper.GetBool("dry-run") {
+				cmd.Printf("Would cancel %s\n", target)
+				return
+			}
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			if taskID == "" {
+				matching, err := c.GetTasks(types.TaskListOptions{Actions: actions})
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error getting tasks: %v\n", err)
+					os.Exit(1)
+				}
+				cancellable := 0
+				for _, t := range matching {
+					if t.Cancellable && !t.Cancelled {
+						cmd.Printf("  %s\t%s\n", t.TaskID(), t.Action)
+						cancellable++
+					}
+				}
+				if cancellable == 0 {
+					cmd.Printf("No cancellable tasks match actions %q\n", actions)
+					return
+				}
+				target = fmt.Sprintf("%d tasks", cancellable)
+			}
+
+			if !prompt.Confirm(cmd, "cancel "+target) {
+				cmd.Println("Operation cancelled")
+				return
+			}
+
+			cancelled, err := c.CancelTasks(taskID, actions)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error cancelling %s: %v\n", target, err)
+				os.Exit(1)
+			}
+
+			data := make([]interface{}, len(cancelled))
+			for i, t := range cancelled {
+				data[i] = map[string]interface{}{
+					"__columns": "TASK,ACTION,NODE",
+					"TASK":      t.TaskID(),
+					"ACTION":    t.Action,
+					"NODE":      t.NodeName,
+				}
+			}
+			formatter := output.NewFormatter(viper.GetString("output"))
+			if err := formatter.Format(data, cmd.OutOrStdout()); err != nil {
+				fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&actions, "actions", "", "cancel all cancellable tasks matching these action patterns (e.g. '*forcemerge*')")
+	cmd.Flags().BoolP("yes", "y", false, "automatically confirm cancellation")
+
+	return cmd
+}
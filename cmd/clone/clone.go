@@ -1,14 +1,18 @@
 package clone
 
-import "github.com/spf13/cobra"
+import (
+	"github.com/chronicblondiee/searchctl/cmd/resize"
+	"github.com/spf13/cobra"
+)
 
 func NewCloneCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "clone",
-		Short: "Export (clone) and import cluster configuration resources",
-		Long:  "Clone cluster configuration (templates, policies, pipelines, settings) to/from the filesystem.",
+		Short: "Export (clone) and import cluster configuration resources, or clone an index",
+		Long:  "Clone cluster configuration (templates, policies, pipelines, settings) to/from the filesystem, or clone an index into a new index.",
 	}
 	cmd.AddCommand(NewExportCmd())
 	cmd.AddCommand(NewImportCmd())
+	cmd.AddCommand(resize.NewCloneIndexCmd())
 	return cmd
 }
@@ -34,7 +34,7 @@ func NewExportCmd() *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVarP(&opts.dir, "dir", "d", "", "output directory")
-	cmd.Flags().StringSliceVar(&opts.types, "types", []string{}, "resource types to export (index-templates,component-templates,lifecycle-policies,ingest-pipelines,cluster-settings)")
+	cmd.Flags().StringSliceVar(&opts.types, "types", []string{}, "resource types to export (index-templates,component-templates,lifecycle-policies,ingest-pipelines,snapshot-policies,cluster-settings)")
 	cmd.Flags().StringSliceVar(&opts.names, "names", []string{}, "optional names/patterns to filter (comma-separated)")
 	cmd.Flags().BoolVar(&opts.includeSystem, "include-system", false, "include system resources (e.g. names starting with .)")
 	cmd.Flags().BoolVar(&opts.all, "all", false, "export all supported resource types")
@@ -94,6 +94,9 @@ func runExport(opts exportOptions) error {
 	if len(opts.types) == 0 || contains(opts.types, "ingest-pipelines") || contains(opts.types, "all") {
 		selected["ingest-pipelines"] = true
 	}
+	if len(opts.types) == 0 || contains(opts.types, "snapshot-policies") || contains(opts.types, "slm") || contains(opts.types, "all") {
+		selected["snapshot-policies"] = true
+	}
 	if len(opts.types) == 0 || contains(opts.types, "cluster-settings") || contains(opts.types, "all") {
 		selected["cluster-settings"] = true
 	}
@@ -243,6 +246,34 @@ func runExport(opts exportOptions) error {
 		}
 	}
 
+	if selected["snapshot-policies"] {
+		for _, p := range patterns {
+			items, err := c.GetSnapshotPolicies(p)
+			if err != nil {
+				if strings.Contains(err.Error(), "no handler found") || strings.Contains(err.Error(), "404") || strings.Contains(strings.ToLower(err.Error()), "not found") {
+					continue
+				}
+				return err
+			}
+			for _, sp := range items {
+				if !opts.includeSystem && strings.HasPrefix(sp.Name, ".") {
+					continue
+				}
+				doc := map[string]interface{}{
+					"kind": "SnapshotPolicy",
+					"metadata": map[string]interface{}{
+						"name": sp.Name,
+					},
+					"spec": sp.Policy,
+				}
+				path := filepath.Join(opts.dir, "snapshot-policies", safeName(sp.Name)+ext())
+				if err := writeDoc(path, doc); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
 	if selected["cluster-settings"] {
 		settings, err := c.GetClusterSettings()
 		if err != nil {
@@ -34,15 +34,15 @@ func NewImportCmd() *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVarP(&opts.dir, "dir", "d", "", "input directory")
-	cmd.Flags().StringSliceVar(&opts.types, "types", []string{}, "resource types to import (component-templates,index-templates,lifecycle-policies,ingest-pipelines,cluster-settings)")
+	cmd.Flags().StringSliceVar(&opts.types, "types", []string{}, "resource types to import (component-templates,index-templates,lifecycle-policies,ingest-pipelines,snapshot-policies,cluster-settings)")
 	cmd.Flags().BoolVar(&opts.continueOnError, "continue-on-error", false, "continue when a file fails")
 	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "show planned operations without applying")
 	return cmd
 }
 
 func runImport(opts importOptions) error {
-	// Import order: component-templates -> index-templates -> lifecycle-policies -> ingest-pipelines -> cluster-settings
-	order := []string{"component-templates", "index-templates", "lifecycle-policies", "ingest-pipelines", "cluster-settings"}
+	// Import order: component-templates -> index-templates -> lifecycle-policies -> ingest-pipelines -> snapshot-policies -> cluster-settings
+	order := []string{"component-templates", "index-templates", "lifecycle-policies", "ingest-pipelines", "snapshot-policies", "cluster-settings"}
 	selected := map[string]bool{}
 	if len(opts.types) == 0 {
 		for _, t := range order {
@@ -135,6 +135,8 @@ func inferKind(folder string, obj map[string]interface{}) string {
 		return "LifecyclePolicy"
 	case "ingest-pipelines":
 		return "IngestPipeline"
+	case "snapshot-policies":
+		return "SnapshotPolicy"
 	case "cluster-settings":
 		return "ClusterSettings"
 	default:
@@ -168,6 +170,8 @@ func applyOne(c client.SearchClient, kind, name string, spec map[string]interfac
 		return c.CreateLifecyclePolicy(name, spec)
 	case "IngestPipeline":
 		return c.CreateIngestPipeline(name, spec)
+	case "SnapshotPolicy":
+		return c.CreateSnapshotPolicy(name, spec)
 	case "ClusterSettings":
 		return c.UpdateClusterSettings(spec)
 	default:
@@ -24,6 +24,8 @@ func NewClusterCmd() *cobra.Command {
 	cmd.AddCommand(cluster.NewStateCmd())
 	cmd.AddCommand(cluster.NewPendingTasksCmd())
 	cmd.AddCommand(cluster.NewAllocationSettingsCmd())
+	cmd.AddCommand(cluster.NewRerouteCmd())
+	cmd.AddCommand(cluster.NewVotingExclusionsCmd())
 
 	return cmd
 }
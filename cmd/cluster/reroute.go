@@ -0,0 +1,366 @@
+package cluster
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/output"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type rerouteOptions struct {
+	explain     bool
+	retryFailed bool
+}
+
+// shardFlags are the flags identifying a shard copy, shared by the reroute actions
+type shardFlags struct {
+	index string
+	shard int
+	node  string
+}
+
+func NewRerouteCmd() *cobra.Command {
+	var opts rerouteOptions
+
+	cmd := &cobra.Command{
+		Use:   "reroute",
+		Short: "Move, cancel or allocate shards",
+		Long: `Change shard allocation with the cluster reroute API.
+
+Each action sends a single reroute command. With the global --dry-run flag the
+command is evaluated by the cluster without applying it, and the resulting
+shard copies are shown. --explain adds the decision of every allocation decider,
+which shows why an action is or is not allowed.
+
+Run 'searchctl cluster reroute --retry-failed' on its own to retry shards whose
+allocation failed too many times, for example after a disk was freed.`,
+		Example: strings.TrimSpace(`
+# Check whether a shard may move, without moving it
+searchctl cluster reroute move --index logs-1 --shard 0 --from node-1 --to node-2 --dry-run --explain
+
+# Retry shards that failed to allocate
+searchctl cluster reroute --retry-failed
+
+# Promote a stale copy after every in-sync copy was lost
+searchctl cluster reroute allocate-stale-primary --index orders --shard 2 --node node-3 --accept-data-loss`),
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if !opts.retryFailed {
+				cmd.Help()
+				return
+			}
+			runReroute(cmd, nil, opts)
+		},
+	}
+
+	cmd.PersistentFlags().BoolVar(&opts.explain, "explain", false, "show the decision of every allocation decider")
+	cmd.PersistentFlags().BoolVar(&opts.retryFailed, "retry-failed", false, "retry shards whose allocation failed too many times")
+
+	cmd.AddCommand(newRerouteMoveCmd(&opts))
+	cmd.AddCommand(newRerouteCancelCmd(&opts))
+	cmd.AddCommand(newRerouteAllocateReplicaCmd(&opts))
+	cmd.AddCommand(newRerouteAllocateStalePrimaryCmd(&opts))
+
+	return cmd
+}
+
+func addShardFlags(cmd *cobra.Command, f *shardFlags, nodeFlag, nodeUsage string) {
+	cmd.Flags().StringVar(&f.index, "index", "", "index name")
+	cmd.Flags().IntVar(&f.shard, "shard", 0, "shard number")
+	cmd.MarkFlagRequired("index")
+	cmd.MarkFlagRequired("shard")
+	if nodeFlag != "" {
+		cmd.Flags().StringVar(&f.node, nodeFlag, "", nodeUsage)
+		cmd.MarkFlagRequired(nodeFlag)
+	}
+}
+
+func newRerouteMoveCmd(opts *rerouteOptions) *cobra.Command {
+	var f shardFlags
+	var to string
+
+	cmd := &cobra.Command{
+		Use:   "move",
+		Short: "Move a started shard copy to another node",
+		Long:  "Move a started shard copy from one node to another. The copy keeps serving requests until relocation completes.",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			runReroute(cmd, []types.RerouteCommand{{"move": {
+				"index":     f.index,
+				"shard":     f.shard,
+				"from_node": f.node,
+				"to_node":   to,
+			}}}, *opts)
+		},
+	}
+
+	addShardFlags(cmd, &f, "from", "node holding the shard copy")
+	cmd.Flags().StringVar(&to, "to", "", "node to move the shard copy to")
+	cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+func newRerouteCancelCmd(opts *rerouteOptions) *cobra.Command {
+	var f shardFlags
+	var allowPrimary bool
+
+	cmd := &cobra.Command{
+		Use:   "cancel",
+		Short: "Cancel the allocation or recovery of a shard copy",
+		Long: `Cancel the allocation of a shard copy on a node, which also cancels a running
+recovery or relocation. Cancelling a primary requires --allow-primary; a replica
+is then promoted if one is in sync.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			params := map[string]interface{}{"index": f.index, "shard": f.shard, "node": f.node}
+			if allowPrimary {
+				params["allow_primary"] = true
+			}
+			runReroute(cmd, []types.RerouteCommand{{"cancel": params}}, *opts)
+		},
+	}
+
+	addShardFlags(cmd, &f, "node", "node holding the shard copy")
+	cmd.Flags().BoolVar(&allowPrimary, "allow-primary", false, "allow cancelling a primary shard")
+
+	return cmd
+}
+
+func newRerouteAllocateReplicaCmd(opts *rerouteOptions) *cobra.Command {
+	var f shardFlags
+
+	cmd := &cobra.Command{
+		Use:   "allocate-replica",
+		Short: "Allocate an unassigned replica to a node",
+		Long:  "Allocate an unassigned replica shard to a node. The allocation deciders still apply.",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			runReroute(cmd, []types.RerouteCommand{{"allocate_replica": {
+				"index": f.index,
+				"shard": f.shard,
+				"node":  f.node,
+			}}}, *opts)
+		},
+	}
+
+	addShardFlags(cmd, &f, "node", "node to allocate the replica to")
+
+	return cmd
+}
+
+func newRerouteAllocateStalePrimaryCmd(opts *rerouteOptions) *cobra.Command {
+	var f shardFlags
+	var acceptDataLoss bool
+
+	cmd := &cobra.Command{
+		Use:   "allocate-stale-primary",
+		Short: "Promote a stale shard copy to primary",
+		Long: `Allocate a primary shard to a node holding a stale copy of it. Use this only
+when no in-sync copy can be recovered: writes the stale copy missed are lost,
+and if an in-sync copy comes back later it is wiped. --accept-data-loss is
+required.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if !acceptDataLoss {
+				fmt.Fprintf(os.Stderr, "Error: allocating a stale primary can lose data; pass --accept-data-loss to confirm\n")
+				os.Exit(1)
+			}
+			runReroute(cmd, []types.RerouteCommand{{"allocate_stale_primary": {
+				"index":            f.index,
+				"shard":            f.shard,
+				"node":             f.node,
+				"accept_data_loss": true,
+			}}}, *opts)
+		},
+	}
+
+	addShardFlags(cmd, &f, "node", "node holding the stale copy")
+	cmd.Flags().BoolVar(&acceptDataLoss, "accept-data-loss", false, "confirm that writes missing from the stale copy are lost")
+
+	return cmd
+}
+
+func runReroute(cmd *cobra.Command, commands []types.RerouteCommand, opts rerouteOptions) {
+	if commands == nil {
+		commands = []types.RerouteCommand{}
+	}
+	dryRun := viper.GetBool("dry-run")
+
+	c, err := client.NewClient()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+		os.Exit(1)
+	}
+
+	resp, err := c.Reroute(commands, types.RerouteOptions{DryRun: dryRun, Explain: opts.explain, RetryFailed: opts.retryFailed})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error rerouting: %v\n", err)
+		os.Exit(1)
+	}
+
+	outFmt := viper.GetString("output")
+	w := cmd.OutOrStdout()
+	if outFmt == "json" || outFmt == "yaml" {
+		if err := output.NewFormatter(outFmt).Format(resp, w); err != nil {
+			fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := printReroute(w, resp, commands, dryRun); err != nil {
+		fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+		os.Exit(1)
+	}
+	switch {
+	case dryRun:
+		cmd.Printf("Dry run: the reroute was evaluated but not applied\n")
+	case opts.retryFailed && len(commands) == 0:
+		cmd.Printf("Retrying allocation of failed shards\n")
+	default:
+		cmd.Printf("Reroute accepted\n")
+	}
+}
+
+// printReroute prints the decider table of every explained command and, for a dry
+// run, the shard copies the cluster would end up with
+func printReroute(w io.Writer, resp *types.RerouteResponse, commands []types.RerouteCommand, dryRun bool) error {
+	formatter := output.NewFormatter(viper.GetString("output"))
+	printed := false
+	for _, e := range resp.Explanations {
+		if printed {
+			fmt.Fprintln(w)
+		}
+		rows := decisionRows(e)
+		fmt.Fprintf(w, "%s: %s\n", explanationTitle(e), overallDecision(rows))
+		if err := formatter.Format(rows, w); err != nil {
+			return err
+		}
+		printed = true
+	}
+
+	if !dryRun {
+		return nil
+	}
+	for _, cmd := range commands {
+		for _, params := range cmd {
+			index, _ := params["index"].(string)
+			shard := fmt.Sprint(params["shard"])
+			rows := shardCopies(resp.State, index, shard)
+			if len(rows) == 0 {
+				continue
+			}
+			if printed {
+				fmt.Fprintln(w)
+			}
+			fmt.Fprintf(w, "Resulting copies of [%s][%s]:\n", index, shard)
+			if err := formatter.Format(rows, w); err != nil {
+				return err
+			}
+			printed = true
+		}
+	}
+	return nil
+}
+
+// explanationTitle describes an explained command, such as "move [logs][0] node-1 -> node-2"
+func explanationTitle(e map[string]interface{}) string {
+	command, _ := e["command"].(string)
+	params, _ := e["parameters"].(map[string]interface{})
+	title := fmt.Sprintf("%s [%v][%v]", command, params["index"], params["shard"])
+	if from, ok := params["from_node"].(string); ok {
+		return fmt.Sprintf("%s %s -> %v", title, from, params["to_node"])
+	}
+	if node, ok := params["node"].(string); ok {
+		return title + " on " + node
+	}
+	return title
+}
+
+func decisionRows(e map[string]interface{}) []interface{} {
+	decisions, _ := e["decisions"].([]interface{})
+	rows := make([]interface{}, 0, len(decisions))
+	for _, d := range decisions {
+		m, ok := d.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		rows = append(rows, map[string]interface{}{
+			"__columns":   "DECIDER,DECISION,EXPLANATION",
+			"DECIDER":     m["decider"],
+			"DECISION":    m["decision"],
+			"EXPLANATION": m["explanation"],
+		})
+	}
+	return rows
+}
+
+// overallDecision is NO when any decider says no, THROTTLE when any throttles and YES
+// otherwise
+func overallDecision(rows []interface{}) string {
+	result := "YES"
+	for _, r := range rows {
+		switch r.(map[string]interface{})["DECISION"] {
+		case "NO":
+			return "NO"
+		case "THROTTLE":
+			result = "THROTTLE"
+		}
+	}
+	return result
+}
+
+// shardCopies returns the copies of a shard from the routing table of a reroute response
+func shardCopies(state map[string]interface{}, index, shard string) []interface{} {
+	routing, _ := state["routing_table"].(map[string]interface{})
+	indices, _ := routing["indices"].(map[string]interface{})
+	idx, _ := indices[index].(map[string]interface{})
+	shards, _ := idx["shards"].(map[string]interface{})
+	copies, _ := shards[shard].([]interface{})
+
+	rows := make([]interface{}, 0, len(copies))
+	for _, c := range copies {
+		m, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		prirep := "r"
+		if m["primary"] == true {
+			prirep = "p"
+		}
+		rows = append(rows, map[string]interface{}{
+			"__columns":       "PRIREP,STATE,NODE,RELOCATING_NODE",
+			"PRIREP":          prirep,
+			"STATE":           m["state"],
+			"NODE":            nodeName(state, m["node"]),
+			"RELOCATING_NODE": nodeName(state, m["relocating_node"]),
+		})
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].(map[string]interface{})["PRIREP"] == "p" && rows[j].(map[string]interface{})["PRIREP"] != "p"
+	})
+	return rows
+}
+
+// nodeName resolves a node id with the nodes section of the state when it is present
+func nodeName(state map[string]interface{}, id interface{}) string {
+	s, ok := id.(string)
+	if !ok {
+		return "-"
+	}
+	nodes, _ := state["nodes"].(map[string]interface{})
+	if n, ok := nodes[s].(map[string]interface{}); ok {
+		if name, ok := n["name"].(string); ok {
+			return name
+		}
+	}
+	return s
+}
@@ -0,0 +1,85 @@
+package cluster
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/chronicblondiee/searchctl/pkg/types"
+)
+
+func TestNewRerouteCmd(t *testing.T) {
+	cmd := NewRerouteCmd()
+	for _, name := range []string{"move", "cancel", "allocate-replica", "allocate-stale-primary"} {
+		sub, _, err := cmd.Find([]string{name})
+		if err != nil || sub.Name() != name {
+			t.Errorf("Expected subcommand %s", name)
+		}
+	}
+	for _, name := range []string{"explain", "retry-failed"} {
+		if cmd.PersistentFlags().Lookup(name) == nil {
+			t.Errorf("Expected flag %s", name)
+		}
+	}
+}
+
+func TestPrintReroute(t *testing.T) {
+	var resp types.RerouteResponse
+	err := json.Unmarshal([]byte(`{
+		"explanations": [{
+			"command": "allocate_replica",
+			"parameters": {"index": "logs", "shard": 1, "node": "node-2"},
+			"decisions": [
+				{"decider": "allocate_replica_allocation_command", "decision": "YES", "explanation": "can allocate"},
+				{"decider": "throttling", "decision": "THROTTLE", "explanation": "too many recoveries"}
+			]
+		}],
+		"state": {
+			"nodes": {"id2": {"name": "node-2"}},
+			"routing_table": {"indices": {"logs": {"shards": {"1": [
+				{"state": "INITIALIZING", "primary": false, "node": "id2"},
+				{"state": "STARTED", "primary": true, "node": "id1"}
+			]}}}}
+		}
+	}`), &resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commands := []types.RerouteCommand{{"allocate_replica": {"index": "logs", "shard": 1, "node": "node-2"}}}
+	var buf bytes.Buffer
+	if err := printReroute(&buf, &resp, commands, true); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"allocate_replica [logs][1] on node-2: THROTTLE",
+		"too many recoveries",
+		"Resulting copies of [logs][1]:",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+
+	rows := shardCopies(resp.State, "logs", "1")
+	first := rows[0].(map[string]interface{})
+	second := rows[1].(map[string]interface{})
+	if first["PRIREP"] != "p" || first["NODE"] != "id1" || second["NODE"] != "node-2" || second["RELOCATING_NODE"] != "-" {
+		t.Errorf("Unexpected shard copies %v", rows)
+	}
+}
+
+func TestOverallDecision(t *testing.T) {
+	rows := decisionRows(map[string]interface{}{"decisions": []interface{}{
+		map[string]interface{}{"decider": "a", "decision": "THROTTLE"},
+		map[string]interface{}{"decider": "b", "decision": "NO"},
+	}})
+	if got := overallDecision(rows); got != "NO" {
+		t.Errorf("Expected NO, got %s", got)
+	}
+	if got := overallDecision(nil); got != "YES" {
+		t.Errorf("Expected YES, got %s", got)
+	}
+}
@@ -0,0 +1,250 @@
+package cluster
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/output"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewVotingExclusionsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "voting-exclusions",
+		Short: "Manage voting configuration exclusions",
+		Long: `Manage voting configuration exclusions for removing master-eligible nodes.
+
+Before shutting down master-eligible nodes for good, add them to the voting
+configuration exclusions so the cluster moves its voting configuration to the
+remaining nodes. Clear the exclusions once the nodes have left the cluster.`,
+		Aliases: []string{"voting-exclusion", "ve"},
+		Example: strings.TrimSpace(`
+# Remove two master-eligible nodes
+searchctl cluster voting-exclusions add es-master-4 es-master-5
+searchctl cluster voting-exclusions list
+
+# After the nodes left the cluster
+searchctl cluster voting-exclusions clear`),
+	}
+
+	cmd.AddCommand(newVotingExclusionsAddCmd())
+	cmd.AddCommand(newVotingExclusionsListCmd())
+	cmd.AddCommand(newVotingExclusionsClearCmd())
+
+	return cmd
+}
+
+func newVotingExclusionsAddCmd() *cobra.Command {
+	var timeout string
+
+	cmd := &cobra.Command{
+		Use:   "add NODE...",
+		Short: "Exclude master-eligible nodes from the voting configuration",
+		Long: `Add nodes by name to the voting configuration exclusions and wait until the
+voting configuration no longer contains them.
+
+A warning is printed when fewer nodes of the voting configuration than its
+quorum would remain, because stopping the excluded nodes too early then leaves
+the cluster without a master.`,
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			state, coord, err := getCoordination(c)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting voting configuration: %v\n", err)
+				os.Exit(1)
+			}
+			for _, w := range exclusionWarnings(state, coord, args) {
+				cmd.Printf("Warning: %s\n", w)
+			}
+
+			if viper.GetBool("dry-run") {
+				cmd.Printf("Would add voting config exclusions for %s\n", strings.Join(args, ", "))
+				return
+			}
+
+			if err := c.AddVotingExclusions(args, timeout); err != nil {
+				fmt.Fprintf(os.Stderr, "Error adding voting config exclusions: %v\n", err)
+				os.Exit(1)
+			}
+			cmd.Printf("Excluded %s from the voting configuration\n", strings.Join(args, ", "))
+		},
+	}
+
+	cmd.Flags().StringVar(&timeout, "timeout", "", "how long to wait for the voting configuration to exclude the nodes, such as 1m")
+
+	return cmd
+}
+
+func newVotingExclusionsListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   "Show the voting configuration and its exclusions",
+		Long:    "Show the committed voting configuration and the voting configuration exclusions from the cluster state metadata.",
+		Aliases: []string{"ls"},
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			state, coord, err := getCoordination(c)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting voting configuration: %v\n", err)
+				os.Exit(1)
+			}
+			if err := printVotingConfig(os.Stdout, state, coord); err != nil {
+				fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	return cmd
+}
+
+func newVotingExclusionsClearCmd() *cobra.Command {
+	var waitForRemoval bool
+
+	cmd := &cobra.Command{
+		Use:   "clear",
+		Short: "Remove all voting configuration exclusions",
+		Long: `Remove all voting configuration exclusions. By default the cluster first waits
+until the excluded nodes have left; --wait-for-removal=false clears them at once,
+which lets excluded nodes that are still running vote again.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if viper.GetBool("dry-run") {
+				cmd.Printf("Would clear voting config exclusions\n")
+				return
+			}
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+			if err := c.ClearVotingExclusions(waitForRemoval); err != nil {
+				fmt.Fprintf(os.Stderr, "Error clearing voting config exclusions: %v\n", err)
+				os.Exit(1)
+			}
+			cmd.Printf("Voting config exclusions cleared\n")
+		},
+	}
+
+	cmd.Flags().BoolVar(&waitForRemoval, "wait-for-removal", true, "wait until the excluded nodes have left the cluster")
+
+	return cmd
+}
+
+func getCoordination(c client.SearchClient) (*types.ClusterState, *types.ClusterCoordination, error) {
+	state, err := c.ClusterCoordinationState()
+	if err != nil {
+		return nil, nil, err
+	}
+	coord, err := state.Coordination()
+	if err != nil {
+		return nil, nil, err
+	}
+	if coord == nil {
+		return nil, nil, fmt.Errorf("cluster state has no cluster_coordination metadata")
+	}
+	return state, coord, nil
+}
+
+// exclusionWarnings checks nodes about to be excluded against the voting quorum
+func exclusionWarnings(state *types.ClusterState, coord *types.ClusterCoordination, names []string) []string {
+	var warnings []string
+	excluded := make([]string, 0, len(coord.VotingConfigExclusions)+len(names))
+	for _, e := range coord.VotingConfigExclusions {
+		excluded = append(excluded, e.NodeID)
+	}
+	for _, name := range names {
+		id, ok := state.NodeID(name)
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("node %s is not part of the cluster", name))
+			continue
+		}
+		excluded = append(excluded, id)
+	}
+
+	if remaining, quorum := state.RemainingVoters(coord, excluded...), coord.Quorum(); remaining < quorum {
+		warnings = append(warnings, fmt.Sprintf("excluding %s leaves %d of %d voting node(s), below the quorum of %d",
+			strings.Join(names, ", "), remaining, len(coord.LastCommittedConfig), quorum))
+	}
+	return warnings
+}
+
+func printVotingConfig(w io.Writer, state *types.ClusterState, coord *types.ClusterCoordination) error {
+	outFmt := viper.GetString("output")
+	if outFmt == "json" || outFmt == "yaml" {
+		return output.NewFormatter(outFmt).Format(coord, w)
+	}
+
+	excluded := make(map[string]bool)
+	var excludedIDs []string
+	for _, e := range coord.VotingConfigExclusions {
+		excluded[e.NodeID] = true
+		excludedIDs = append(excludedIDs, e.NodeID)
+	}
+	remaining := state.RemainingVoters(coord, excludedIDs...)
+	fmt.Fprintf(os.Stderr, "Term %d: %d voting node(s), quorum %d, %d joined and not excluded\n",
+		coord.Term, len(coord.LastCommittedConfig), coord.Quorum(), remaining)
+
+	rows := votingRows(state, coord, excluded)
+	if len(rows) == 0 {
+		return nil
+	}
+	return output.NewFormatter(outFmt).Format(rows, w)
+}
+
+// votingRows lists the nodes of the voting configuration followed by excluded nodes
+// that are no longer part of it
+func votingRows(state *types.ClusterState, coord *types.ClusterCoordination, excluded map[string]bool) []interface{} {
+	var rows []interface{}
+	row := func(id, name string, voting bool) {
+		if name == "" {
+			name = "-"
+		}
+		_, joined := state.Nodes[id]
+		rows = append(rows, map[string]interface{}{
+			"__columns": "NAME,ID,VOTING,EXCLUDED,JOINED",
+			"NAME":      name,
+			"ID":        id,
+			"VOTING":    yesNo(voting),
+			"EXCLUDED":  yesNo(excluded[id]),
+			"JOINED":    yesNo(joined),
+		})
+	}
+
+	voting := make(map[string]bool)
+	for _, id := range coord.LastCommittedConfig {
+		voting[id] = true
+		row(id, state.NodeName(id), true)
+	}
+	for _, e := range coord.VotingConfigExclusions {
+		if !voting[e.NodeID] {
+			row(e.NodeID, e.NodeName, false)
+		}
+	}
+	return rows
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,68 @@
+package cluster
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/chronicblondiee/searchctl/pkg/types"
+)
+
+func votingState(t *testing.T) (*types.ClusterState, *types.ClusterCoordination) {
+	var state types.ClusterState
+	err := json.Unmarshal([]byte(`{
+		"metadata": {"cluster_coordination": {
+			"term": 4,
+			"last_committed_config": ["a", "b", "c"],
+			"last_accepted_config": ["a", "b", "c"],
+			"voting_config_exclusions": [{"node_id": "d", "node_name": "es-4"}]
+		}},
+		"nodes": {"a": {"name": "es-1"}, "b": {"name": "es-2"}, "c": {"name": "es-3"}}
+	}`), &state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coord, err := state.Coordination()
+	if err != nil || coord == nil {
+		t.Fatalf("Expected coordination metadata, got %v", err)
+	}
+	return &state, coord
+}
+
+func TestNewVotingExclusionsCmd(t *testing.T) {
+	cmd := NewVotingExclusionsCmd()
+	for _, name := range []string{"add", "list", "clear"} {
+		sub, _, err := cmd.Find([]string{name})
+		if err != nil || sub.Name() != name {
+			t.Errorf("Expected subcommand %s", name)
+		}
+	}
+}
+
+func TestExclusionWarnings(t *testing.T) {
+	state, coord := votingState(t)
+	if coord.Quorum() != 2 {
+		t.Errorf("Expected quorum 2, got %d", coord.Quorum())
+	}
+
+	if w := exclusionWarnings(state, coord, []string{"es-1"}); len(w) != 0 {
+		t.Errorf("Expected no warnings for one of three voting nodes, got %v", w)
+	}
+
+	w := exclusionWarnings(state, coord, []string{"es-1", "es-2", "es-9"})
+	if len(w) != 2 || !strings.Contains(w[0], "es-9") || !strings.Contains(w[1], "leaves 1 of 3 voting node(s), below the quorum of 2") {
+		t.Errorf("Unexpected warnings %v", w)
+	}
+}
+
+func TestVotingRows(t *testing.T) {
+	state, coord := votingState(t)
+	rows := votingRows(state, coord, map[string]bool{"d": true})
+	if len(rows) != 4 {
+		t.Fatalf("Expected 4 rows, got %d", len(rows))
+	}
+	last := rows[3].(map[string]interface{})
+	if last["NAME"] != "es-4" || last["VOTING"] != "no" || last["EXCLUDED"] != "yes" || last["JOINED"] != "no" {
+		t.Errorf("Unexpected row for the excluded node: %v", last)
+	}
+}
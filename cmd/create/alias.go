@@ -0,0 +1,97 @@
+package create
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewCreateAliasCmd() *cobra.Command {
+	var index string
+	var filter string
+	var routing string
+	var indexRouting string
+	var searchRouting string
+	var isWriteIndex bool
+
+	cmd := &cobra.Command{
+		Use:     "alias ALIAS_NAME",
+		Short:   "Create an index alias",
+		Long:    "Create an alias pointing at one or more indices, with optional filter, routing, and write index settings.",
+		Aliases: []string{"al"},
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			aliasName := args[0]
+
+			if index == "" {
+				fmt.Fprintln(os.Stderr, "Error: --index is required")
+				os.Exit(1)
+			}
+
+			body, err := buildAliasBody(filter, routing, indexRouting, searchRouting, isWriteIndex, cmd.Flags().Changed("is-write-index"))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error building alias: %v\n", err)
+				os.Exit(1)
+			}
+
+			if viper.GetBool("dry-run") {
+				cmd.Printf("Would create alias %s on index: %s\n", aliasName, index)
+				if len(body) > 0 {
+					bodyJSON, _ := json.MarshalIndent(body, "", "  ")
+					cmd.Printf("Definition:\n%s\n", string(bodyJSON))
+				}
+				return
+			}
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			if err := c.CreateAlias(index, aliasName, body); err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating alias: %v\n", err)
+				os.Exit(1)
+			}
+
+			cmd.Printf("Alias %s created successfully on %s\n", aliasName, index)
+		},
+	}
+
+	cmd.Flags().StringVar(&index, "index", "", "index name or pattern the alias points to (required)")
+	cmd.Flags().StringVar(&filter, "filter", "", "filter query as JSON (e.g. '{\"term\":{\"env\":\"prod\"}}')")
+	cmd.Flags().StringVar(&routing, "routing", "", "routing value for both indexing and search")
+	cmd.Flags().StringVar(&indexRouting, "index-routing", "", "routing value for indexing only")
+	cmd.Flags().StringVar(&searchRouting, "search-routing", "", "routing value for search only")
+	cmd.Flags().BoolVar(&isWriteIndex, "is-write-index", false, "mark the index as the write index for the alias")
+
+	return cmd
+}
+
+func buildAliasBody(filter, routing, indexRouting, searchRouting string, isWriteIndex, writeIndexSet bool) (map[string]interface{}, error) {
+	body := map[string]interface{}{}
+	if filter != "" {
+		var f map[string]interface{}
+		if err := json.Unmarshal([]byte(filter), &f); err != nil {
+			return nil, fmt.Errorf("invalid --filter JSON: %w", err)
+		}
+		body["filter"] = f
+	}
+	if routing != "" {
+		body["routing"] = routing
+	}
+	if indexRouting != "" {
+		body["index_routing"] = indexRouting
+	}
+	if searchRouting != "" {
+		body["search_routing"] = searchRouting
+	}
+	if writeIndexSet {
+		body["is_write_index"] = isWriteIndex
+	}
+	return body, nil
+}
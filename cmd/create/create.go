@@ -14,6 +14,11 @@ func NewCreateCmd() *cobra.Command {
 	cmd.AddCommand(NewCreateIndexCmd())
 	cmd.AddCommand(NewCreateDataStreamCmd())
 	cmd.AddCommand(NewCreateIndexTemplateCmd())
+	cmd.AddCommand(NewCreateAliasCmd())
+	cmd.AddCommand(NewCreateSnapshotRepositoryCmd())
+	cmd.AddCommand(NewCreateSnapshotCmd())
+	cmd.AddCommand(NewCreateSnapshotPolicyCmd())
+	cmd.AddCommand(NewCreateIngestPipelineCmd())
 
 	return cmd
 }
@@ -0,0 +1,69 @@
+package create
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewCreateIngestPipelineCmd() *cobra.Command {
+	var filename string
+
+	cmd := &cobra.Command{
+		Use:   "ingest-pipeline NAME",
+		Short: "Create or update an ingest pipeline",
+		Long: `Create or update an ingest pipeline from a file.
+
+The file holds either the raw pipeline body (description, processors,
+on_failure) or a resource with kind IngestPipeline and the body under spec, as
+written by 'searchctl clone export'. Use 'searchctl simulate ingest-pipeline'
+with --pipeline to try the file on sample documents first.`,
+		Aliases: []string{"ingestpipeline", "pipeline", "ip"},
+		Example: strings.TrimSpace(`
+# Create a pipeline parsing access logs
+searchctl create ingest-pipeline access-logs -f access-logs.yaml
+
+# Recreate a pipeline exported from another cluster
+searchctl create ingest-pipeline access-logs -f export/ingest-pipelines/access-logs.yaml`),
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			name := args[0]
+
+			body, err := readTemplateFromFile(filename)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error reading pipeline file: %v\n", err)
+				os.Exit(1)
+			}
+			if spec, ok := body["spec"].(map[string]interface{}); ok && body["kind"] != nil {
+				body = spec
+			}
+
+			if viper.GetBool("dry-run") {
+				cmd.Printf("Would create ingest pipeline: %s\n", name)
+				return
+			}
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			if err := c.CreateIngestPipeline(name, body); err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating ingest pipeline: %v\n", err)
+				os.Exit(1)
+			}
+
+			cmd.Printf("Ingest pipeline %s created successfully\n", name)
+		},
+	}
+
+	cmd.Flags().StringVarP(&filename, "filename", "f", "", "pipeline definition file (YAML or JSON)")
+	cmd.MarkFlagRequired("filename")
+
+	return cmd
+}
@@ -0,0 +1,210 @@
+package create
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewCreateSnapshotRepositoryCmd() *cobra.Command {
+	var repoType string
+	var location string
+	var repoURL string
+	var bucket string
+	var basePath string
+	var s3Client string
+	var settings []string
+	var verify bool
+
+	cmd := &cobra.Command{
+		Use:   "snapshot-repository REPOSITORY",
+		Short: "Register a snapshot repository",
+		Long: `Register a snapshot repository or update its settings.
+
+Type-specific flags cover the common settings: --location for fs (the path must be
+listed in path.repo on every node), --url for url and --bucket/--base-path/--client
+for s3. Any other setting can be passed with --setting KEY=VALUE.`,
+		Aliases: []string{"repository", "repo"},
+		Example: strings.TrimSpace(`
+# Shared filesystem repository
+searchctl create snapshot-repository backups --type fs --location /mnt/backups
+
+# S3 repository with compression
+searchctl create snapshot-repository s3-backups --type s3 --bucket my-bucket --base-path prod --setting compress=true
+
+# Read-only URL repository
+searchctl create snapshot-repository archive --type url --url https://backups.example.com/archive/`),
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			name := args[0]
+
+			body, err := buildRepositoryBody(repoType, location, repoURL, bucket, basePath, s3Client, settings)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error building repository: %v\n", err)
+				os.Exit(1)
+			}
+
+			if viper.GetBool("dry-run") {
+				bodyJSON, _ := json.MarshalIndent(body, "", "  ")
+				cmd.Printf("Would create snapshot repository: %s\nDefinition:\n%s\n", name, string(bodyJSON))
+				return
+			}
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			if err := c.CreateSnapshotRepository(name, body, verify); err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating snapshot repository: %v\n", err)
+				os.Exit(1)
+			}
+
+			cmd.Printf("Snapshot repository %s created successfully\n", name)
+		},
+	}
+
+	cmd.Flags().StringVar(&repoType, "type", "fs", "repository type (fs, url, s3, or any installed repository plugin)")
+	cmd.Flags().StringVar(&location, "location", "", "filesystem path for fs repositories")
+	cmd.Flags().StringVar(&repoURL, "url", "", "URL for url repositories")
+	cmd.Flags().StringVar(&bucket, "bucket", "", "bucket for s3 repositories")
+	cmd.Flags().StringVar(&basePath, "base-path", "", "path inside the bucket for s3 repositories")
+	cmd.Flags().StringVar(&s3Client, "client", "", "named s3 client configured on the nodes")
+	cmd.Flags().StringArrayVar(&settings, "setting", nil, "additional repository setting as KEY=VALUE (repeatable)")
+	cmd.Flags().BoolVar(&verify, "verify", true, "verify that all nodes can access the repository")
+
+	return cmd
+}
+
+func NewCreateSnapshotCmd() *cobra.Command {
+	var indices string
+	var includeGlobalState bool
+	var ignoreUnavailable bool
+	var partial bool
+	var wait bool
+
+	cmd := &cobra.Command{
+		Use:   "snapshot REPOSITORY SNAPSHOT",
+		Short: "Create a snapshot",
+		Long: `Take a snapshot of indices and data streams into a repository.
+
+SNAPSHOT may use date math, e.g. '<nightly-{now/d}>'. Without --wait the snapshot runs
+in the background; follow it with 'searchctl snapshot status REPOSITORY SNAPSHOT'.`,
+		Aliases: []string{"snap"},
+		Example: strings.TrimSpace(`
+# Snapshot everything and wait for it to finish
+searchctl create snapshot backups nightly-1 --wait
+
+# Snapshot some indices without cluster state
+searchctl create snapshot backups logs-2024.01 --indices 'logs-2024.01.*' --include-global-state=false`),
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			repository, name := args[0], args[1]
+
+			body := map[string]interface{}{
+				"include_global_state": includeGlobalState,
+			}
+			if indices != "" {
+				body["indices"] = indices
+			}
+			if ignoreUnavailable {
+				body["ignore_unavailable"] = true
+			}
+			if partial {
+				body["partial"] = true
+			}
+
+			if viper.GetBool("dry-run") {
+				bodyJSON, _ := json.MarshalIndent(body, "", "  ")
+				cmd.Printf("Would create snapshot %s in repository %s\nDefinition:\n%s\n", name, repository, string(bodyJSON))
+				return
+			}
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			snap, err := c.CreateSnapshot(repository, name, body, wait)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating snapshot: %v\n", err)
+				os.Exit(1)
+			}
+
+			if snap == nil {
+				cmd.Printf("Snapshot %s started in repository %s\n", name, repository)
+				cmd.Printf("Follow progress with: searchctl snapshot status %s %s\n", repository, name)
+				return
+			}
+
+			duration := (time.Duration(snap.DurationInMillis) * time.Millisecond).Round(time.Millisecond)
+			cmd.Printf("Snapshot %s finished with state %s in %s (%d/%d shards successful)\n",
+				snap.Snapshot, snap.State, duration, snap.Shards.Successful, snap.Shards.Total)
+			if snap.State != "SUCCESS" {
+				for _, f := range snap.Failures {
+					fmt.Fprintf(os.Stderr, "  %v[%v]: %v\n", f["index"], f["shard_id"], f["reason"])
+				}
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&indices, "indices", "", "comma-separated indices, data streams or patterns to include (default: all)")
+	cmd.Flags().BoolVar(&includeGlobalState, "include-global-state", true, "include cluster state such as templates and persistent settings")
+	cmd.Flags().BoolVar(&ignoreUnavailable, "ignore-unavailable", false, "skip missing or closed indices instead of failing")
+	cmd.Flags().BoolVar(&partial, "partial", false, "allow a partial snapshot when some primaries are unavailable")
+	cmd.Flags().BoolVar(&wait, "wait", false, "wait for the snapshot to complete")
+
+	return cmd
+}
+
+// buildRepositoryBody assembles the repository definition, checking the settings each built-in type requires
+func buildRepositoryBody(repoType, location, repoURL, bucket, basePath, s3Client string, extra []string) (map[string]interface{}, error) {
+	if repoType == "" {
+		return nil, fmt.Errorf("--type is required")
+	}
+
+	settings := map[string]interface{}{}
+	for _, kv := range extra {
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("invalid --setting %q: expected KEY=VALUE", kv)
+		}
+		settings[strings.TrimSpace(parts[0])] = parts[1]
+	}
+	if location != "" {
+		settings["location"] = location
+	}
+	if repoURL != "" {
+		settings["url"] = repoURL
+	}
+	if bucket != "" {
+		settings["bucket"] = bucket
+	}
+	if basePath != "" {
+		settings["base_path"] = basePath
+	}
+	if s3Client != "" {
+		settings["client"] = s3Client
+	}
+
+	required := map[string]string{"fs": "location", "url": "url", "s3": "bucket"}
+	if key, ok := required[repoType]; ok {
+		if _, set := settings[key]; !set {
+			return nil, fmt.Errorf("%s repositories require the %s setting", repoType, key)
+		}
+	}
+
+	return map[string]interface{}{
+		"type":     repoType,
+		"settings": settings,
+	}, nil
+}
@@ -0,0 +1,69 @@
+package create
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewCreateSnapshotPolicyCmd() *cobra.Command {
+	var filename string
+
+	cmd := &cobra.Command{
+		Use:   "snapshot-policy NAME",
+		Short: "Create or update a snapshot policy",
+		Long: `Create or update a snapshot policy from a file.
+
+The file holds either the raw policy body or a resource with kind SnapshotPolicy
+and the body under spec, as written by 'searchctl clone export'. Elasticsearch
+expects an SLM policy (schedule, name, repository, config, retention); OpenSearch
+expects a snapshot management policy (creation, deletion, snapshot_config).`,
+		Aliases: []string{"snapshotpolicy", "slm", "sm"},
+		Example: strings.TrimSpace(`
+# Create a nightly SLM policy on Elasticsearch
+searchctl create snapshot-policy nightly -f nightly-slm.yaml
+
+# Recreate a policy exported from another cluster
+searchctl create snapshot-policy nightly -f export/snapshot-policies/nightly.yaml`),
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			name := args[0]
+
+			body, err := readTemplateFromFile(filename)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error reading policy file: %v\n", err)
+				os.Exit(1)
+			}
+			if spec, ok := body["spec"].(map[string]interface{}); ok && body["kind"] != nil {
+				body = spec
+			}
+
+			if viper.GetBool("dry-run") {
+				cmd.Printf("Would create snapshot policy: %s\n", name)
+				return
+			}
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			if err := c.CreateSnapshotPolicy(name, body); err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating snapshot policy: %v\n", err)
+				os.Exit(1)
+			}
+
+			cmd.Printf("Snapshot policy %s created successfully\n", name)
+		},
+	}
+
+	cmd.Flags().StringVarP(&filename, "filename", "f", "", "policy definition file (YAML or JSON)")
+	cmd.MarkFlagRequired("filename")
+
+	return cmd
+}
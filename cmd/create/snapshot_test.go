@@ -0,0 +1,42 @@
+package create
+
+import (
+	"testing"
+)
+
+func TestBuildRepositoryBody(t *testing.T) {
+	body, err := buildRepositoryBody("fs", "/mnt/backups", "", "", "", "", []string{"compress=true"})
+	if err != nil {
+		t.Fatalf("buildRepositoryBody failed: %v", err)
+	}
+	settings := body["settings"].(map[string]interface{})
+	if body["type"] != "fs" || settings["location"] != "/mnt/backups" || settings["compress"] != "true" {
+		t.Errorf("Unexpected body: %v", body)
+	}
+
+	if _, err := buildRepositoryBody("fs", "", "", "", "", "", nil); err == nil {
+		t.Error("Expected error for fs repository without location")
+	}
+	if _, err := buildRepositoryBody("s3", "", "", "", "", "", nil); err == nil {
+		t.Error("Expected error for s3 repository without bucket")
+	}
+	if _, err := buildRepositoryBody("fs", "/x", "", "", "", "", []string{"novalue"}); err == nil {
+		t.Error("Expected error for malformed --setting")
+	}
+	if _, err := buildRepositoryBody("gcs", "", "", "", "", "", []string{"bucket=b"}); err != nil {
+		t.Errorf("Expected plugin repository types to be accepted: %v", err)
+	}
+}
+
+func TestNewCreateSnapshotCmd(t *testing.T) {
+	cmd := NewCreateSnapshotCmd()
+
+	if cmd.Use != "snapshot REPOSITORY SNAPSHOT" {
+		t.Errorf("Expected Use 'snapshot REPOSITORY SNAPSHOT', got %s", cmd.Use)
+	}
+	for _, name := range []string{"indices", "include-global-state", "wait"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("Expected flag %s to be defined", name)
+		}
+	}
+}
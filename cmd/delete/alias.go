@@ -0,0 +1,59 @@
+package delete
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/prompt"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewDeleteAliasCmd() *cobra.Command {
+	var index string
+
+	cmd := &cobra.Command{
+		Use:     "alias ALIAS_NAME",
+		Short:   "Delete an index alias",
+		Long:    "Remove an alias from one index, an index pattern, or all indices it points to. The indices themselves are not deleted.",
+		Aliases: []string{"aliases", "al"},
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			aliasName := args[0]
+
+			target := index
+			if target == "" {
+				target = "_all"
+			}
+
+			if viper.GetBool("dry-run") {
+				cmd.Printf("Would delete alias %s from index: %s\n", aliasName, target)
+				return
+			}
+
+			if !prompt.Confirm(cmd, fmt.Sprintf("delete alias '%s' from '%s'", aliasName, target)) {
+				fmt.Println("Delete operation cancelled.")
+				return
+			}
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			if err := c.DeleteAlias(target, aliasName); err != nil {
+				fmt.Fprintf(os.Stderr, "Error deleting alias: %v\n", err)
+				os.Exit(1)
+			}
+
+			cmd.Printf("Alias %s deleted successfully from %s\n", aliasName, target)
+		},
+	}
+
+	cmd.Flags().StringVar(&index, "index", "", "index name or pattern to remove the alias from (default: all indices)")
+	cmd.Flags().BoolP("yes", "y", false, "automatically confirm deletion without prompting")
+
+	return cmd
+}
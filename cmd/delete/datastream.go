@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/prompt"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -88,7 +89,7 @@ func NewDeleteDataStreamCmd() *cobra.Command {
 					fmt.Printf("  - %s\n", ds)
 				}
 
-				if !confirmAction(cmd, fmt.Sprintf("delete %d data streams matching pattern '%s'", len(dataStreams), dataStreamPattern)) {
+				if !prompt.Confirm(cmd, fmt.Sprintf("delete %d data streams matching pattern '%s'", len(dataStreams), dataStreamPattern)) {
 					fmt.Println("Delete operation cancelled.")
 					return
 				}
@@ -111,7 +112,7 @@ func NewDeleteDataStreamCmd() *cobra.Command {
 
 				fmt.Printf("All matching data streams deleted successfully\n")
 			} else {
-				if !confirmAction(cmd, fmt.Sprintf("delete data stream '%s'", dataStreamPattern)) {
+				if !prompt.Confirm(cmd, fmt.Sprintf("delete data stream '%s'", dataStreamPattern)) {
 					fmt.Println("Delete operation cancelled.")
 					return
 				}
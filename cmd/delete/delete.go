@@ -16,6 +16,11 @@ func NewDeleteCmd() *cobra.Command {
 	cmd.AddCommand(NewDeleteIndexTemplateCmd())
 	cmd.AddCommand(NewDeleteComponentTemplateCmd())
 	cmd.AddCommand(NewDeleteLifecyclePolicyCmd())
+	cmd.AddCommand(NewDeleteAliasCmd())
+	cmd.AddCommand(NewDeleteSnapshotRepositoryCmd())
+	cmd.AddCommand(NewDeleteSnapshotCmd())
+	cmd.AddCommand(NewDeleteSnapshotPolicyCmd())
+	cmd.AddCommand(NewDeleteIngestPipelineCmd())
 
 	return cmd
 }
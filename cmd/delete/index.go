@@ -1,64 +1,16 @@
 package delete
 
 import (
-	"bufio"
 	"fmt"
 	"os"
 	"strings"
 
 	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/prompt"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
-// getMatchingIndices returns a list of indices matching the given pattern
-func getMatchingIndices(c client.SearchClient, pattern string) ([]string, error) {
-	// Use the Get indices functionality to list all indices
-	indices, err := c.GetIndices("*") // Get all indices first
-	if err != nil {
-		return nil, err
-	}
-
-	var matches []string
-	// Convert wildcard pattern to simple string matching
-	// For now, we'll do basic prefix matching for patterns ending with *
-	if strings.HasSuffix(pattern, "*") {
-		prefix := strings.TrimSuffix(pattern, "*")
-		for _, index := range indices {
-			if strings.HasPrefix(index.Name, prefix) {
-				matches = append(matches, index.Name)
-			}
-		}
-	} else {
-		// Exact match (shouldn't happen in wildcard path but handle it)
-		for _, index := range indices {
-			if index.Name == pattern {
-				matches = append(matches, index.Name)
-			}
-		}
-	}
-
-	return matches, nil
-}
-
-// confirmAction prompts the user for confirmation unless -y flag is set
-func confirmAction(cmd *cobra.Command, action string) bool {
-	// Check if -y flag is set
-	if yes, _ := cmd.Flags().GetBool("yes"); yes {
-		return true
-	}
-
-	fmt.Printf("Are you sure you want to %s? (y/N): ", action)
-	reader := bufio.NewReader(os.Stdin)
-	response, err := reader.ReadString('\n')
-	if err != nil {
-		return false
-	}
-
-	response = strings.TrimSpace(strings.ToLower(response))
-	return response == "y" || response == "yes"
-}
-
 func NewDeleteIndexCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "index INDEX_NAME_OR_PATTERN",
@@ -90,7 +42,7 @@ func NewDeleteIndexCmd() *cobra.Command {
 				fmt.Printf("Wildcard pattern detected: %s\n", indexPattern)
 
 				// Get list of matching indices
-				indices, err := getMatchingIndices(c, indexPattern)
+				indices, err := client.IndexNames(c, indexPattern)
 				if err != nil {
 					fmt.Fprintf(os.Stderr, "Error listing matching indices: %v\n", err)
 					os.Exit(1)
@@ -107,7 +59,7 @@ func NewDeleteIndexCmd() *cobra.Command {
 					fmt.Printf("  - %s\n", idx)
 				}
 
-				if !confirmAction(cmd, fmt.Sprintf("delete %d indices matching pattern '%s'", len(indices), indexPattern)) {
+				if !prompt.Confirm(cmd, fmt.Sprintf("delete %d indices matching pattern '%s'", len(indices), indexPattern)) {
 					fmt.Println("Delete operation cancelled.")
 					return
 				}
@@ -130,7 +82,7 @@ func NewDeleteIndexCmd() *cobra.Command {
 
 				fmt.Printf("All matching indices deleted successfully\n")
 			} else {
-				if !confirmAction(cmd, fmt.Sprintf("delete index '%s'", indexPattern)) {
+				if !prompt.Confirm(cmd, fmt.Sprintf("delete index '%s'", indexPattern)) {
 					fmt.Println("Delete operation cancelled.")
 					return
 				}
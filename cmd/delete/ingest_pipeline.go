@@ -0,0 +1,51 @@
+package delete
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/prompt"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewDeleteIngestPipelineCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "ingest-pipeline NAME",
+		Short:   "Delete an ingest pipeline",
+		Long:    "Delete an ingest pipeline. Indices whose default_pipeline or final_pipeline setting still names it will reject new documents.",
+		Aliases: []string{"ingest-pipelines", "ingestpipeline", "pipeline", "ip"},
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			name := args[0]
+
+			if viper.GetBool("dry-run") {
+				cmd.Printf("Would delete ingest pipeline: %s\n", name)
+				return
+			}
+
+			if !prompt.Confirm(cmd, fmt.Sprintf("delete ingest pipeline '%s'", name)) {
+				fmt.Println("Delete operation cancelled.")
+				return
+			}
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			if err := c.DeleteIngestPipeline(name); err != nil {
+				fmt.Fprintf(os.Stderr, "Error deleting ingest pipeline: %v\n", err)
+				os.Exit(1)
+			}
+
+			cmd.Printf("Ingest pipeline %s deleted successfully\n", name)
+		},
+	}
+
+	cmd.Flags().BoolP("yes", "y", false, "automatically confirm deletion without prompting")
+
+	return cmd
+}
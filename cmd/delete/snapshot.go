@@ -0,0 +1,131 @@
+package delete
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/prompt"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewDeleteSnapshotRepositoryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "snapshot-repository REPOSITORY",
+		Short:   "Unregister a snapshot repository",
+		Long:    "Unregister a snapshot repository. The snapshots stored in it are left untouched and can be accessed again by registering the repository.",
+		Aliases: []string{"repository", "repo"},
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			name := args[0]
+
+			if viper.GetBool("dry-run") {
+				cmd.Printf("Would delete snapshot repository: %s\n", name)
+				return
+			}
+
+			if !prompt.Confirm(cmd, fmt.Sprintf("delete snapshot repository '%s'", name)) {
+				fmt.Println("Delete operation cancelled.")
+				return
+			}
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			if err := c.DeleteSnapshotRepository(name); err != nil {
+				fmt.Fprintf(os.Stderr, "Error deleting snapshot repository: %v\n", err)
+				os.Exit(1)
+			}
+
+			cmd.Printf("Snapshot repository %s deleted successfully\n", name)
+		},
+	}
+
+	cmd.Flags().BoolP("yes", "y", false, "automatically confirm deletion without prompting")
+
+	return cmd
+}
+
+func NewDeleteSnapshotCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "snapshot REPOSITORY SNAPSHOT",
+		Short:   "Delete a snapshot",
+		Long:    "Delete a snapshot from a repository. Deleting a running snapshot aborts it.",
+		Aliases: []string{"snap"},
+		Args:    cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			repository, name := args[0], args[1]
+
+			if viper.GetBool("dry-run") {
+				cmd.Printf("Would delete snapshot %s from repository %s\n", name, repository)
+				return
+			}
+
+			if !prompt.Confirm(cmd, fmt.Sprintf("delete snapshot '%s' from repository '%s'", name, repository)) {
+				fmt.Println("Delete operation cancelled.")
+				return
+			}
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			if err := c.DeleteSnapshot(repository, name); err != nil {
+				fmt.Fprintf(os.Stderr, "Error deleting snapshot: %v\n", err)
+				os.Exit(1)
+			}
+
+			cmd.Printf("Snapshot %s deleted successfully from %s\n", name, repository)
+		},
+	}
+
+	cmd.Flags().BoolP("yes", "y", false, "automatically confirm deletion without prompting")
+
+	return cmd
+}
+
+func NewDeleteSnapshotPolicyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "snapshot-policy NAME",
+		Short:   "Delete a snapshot policy",
+		Long:    "Delete a snapshot policy (SLM for Elasticsearch, snapshot management for OpenSearch). Snapshots it already took are kept.",
+		Aliases: []string{"snapshot-policies", "snapshotpolicy", "slm", "sm"},
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			name := args[0]
+
+			if viper.GetBool("dry-run") {
+				cmd.Printf("Would delete snapshot policy: %s\n", name)
+				return
+			}
+
+			if !prompt.Confirm(cmd, fmt.Sprintf("delete snapshot policy '%s'", name)) {
+				fmt.Println("Delete operation cancelled.")
+				return
+			}
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			if err := c.DeleteSnapshotPolicy(name); err != nil {
+				fmt.Fprintf(os.Stderr, "Error deleting snapshot policy: %v\n", err)
+				os.Exit(1)
+			}
+
+			cmd.Printf("Snapshot policy %s deleted successfully\n", name)
+		},
+	}
+
+	cmd.Flags().BoolP("yes", "y", false, "automatically confirm deletion without prompting")
+
+	return cmd
+}
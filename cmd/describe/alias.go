@@ -0,0 +1,89 @@
+package describe
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewDescribeAliasCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "alias ALIAS_NAME",
+		Short:   "Describe an index alias",
+		Long:    "Show the indices an alias points to along with filters, routing, and write index flags.",
+		Aliases: []string{"aliases", "al"},
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			name := args[0]
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			defs, err := c.GetAlias(name)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting alias: %v\n", err)
+				os.Exit(1)
+			}
+
+			outFmt := viper.GetString("output")
+			if outFmt == "json" || outFmt == "yaml" {
+				formatter := output.NewFormatter(outFmt)
+				if err := formatter.Format(defs, os.Stdout); err != nil {
+					fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+					os.Exit(1)
+				}
+				return
+			}
+
+			indices := make([]map[string]interface{}, 0, len(defs))
+			writeIndex := ""
+			for _, d := range defs {
+				entry := map[string]interface{}{
+					"Index": d.Index,
+				}
+				if len(d.Filter) > 0 {
+					entry["Filter"] = d.Filter
+				}
+				if d.IndexRouting != "" {
+					entry["IndexRouting"] = d.IndexRouting
+				}
+				if d.SearchRouting != "" {
+					entry["SearchRouting"] = d.SearchRouting
+				}
+				if d.IsWriteIndex != nil {
+					entry["IsWriteIndex"] = *d.IsWriteIndex
+					if *d.IsWriteIndex {
+						writeIndex = d.Index
+					}
+				}
+				indices = append(indices, entry)
+			}
+			// A single-index alias is implicitly the write index
+			if writeIndex == "" && len(defs) == 1 {
+				writeIndex = defs[0].Index
+			}
+
+			data := map[string]interface{}{
+				"Name":        name,
+				"Index Count": len(defs),
+				"Write Index": writeIndex,
+				"Indices":     indices,
+			}
+
+			formatter := output.NewFormatter(outFmt)
+			if err := formatter.Format(data, os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	return cmd
+}
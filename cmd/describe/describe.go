@@ -18,6 +18,12 @@ func NewDescribeCmd() *cobra.Command {
 	cmd.AddCommand(NewDescribeDataStreamCmd())
 	cmd.AddCommand(NewDescribeNodeCmd())
 	cmd.AddCommand(NewDescribeAllocationCmd())
+	cmd.AddCommand(NewDescribeAliasCmd())
+	cmd.AddCommand(NewDescribeTaskCmd())
+	cmd.AddCommand(NewDescribeSnapshotRepositoryCmd())
+	cmd.AddCommand(NewDescribeSnapshotCmd())
+	cmd.AddCommand(NewDescribeSnapshotPolicyCmd())
+	cmd.AddCommand(NewDescribeIngestPipelineCmd())
 
 	return cmd
 }
@@ -0,0 +1,74 @@
+package describe
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewDescribeIngestPipelineCmd() *cobra.Command {
+	var showBody bool
+
+	cmd := &cobra.Command{
+		Use:     "ingest-pipeline NAME",
+		Short:   "Describe an ingest pipeline",
+		Long:    "Show the description, version, processors and failure handlers of an ingest pipeline.",
+		Aliases: []string{"ingest-pipelines", "ingestpipeline", "ingestpipelines", "pipeline", "pipelines", "ip"},
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			pipeline, err := c.GetIngestPipeline(args[0])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting ingest pipeline: %v\n", err)
+				os.Exit(1)
+			}
+
+			outFmt := viper.GetString("output")
+			if outFmt == "json" || outFmt == "yaml" {
+				formatter := output.NewFormatter(outFmt)
+				if err := formatter.Format(pipeline, os.Stdout); err != nil {
+					fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+					os.Exit(1)
+				}
+				return
+			}
+
+			data := map[string]interface{}{
+				"Name":       pipeline.Name,
+				"Processors": strings.Join(pipeline.Processors(false), " -> "),
+			}
+			if d, ok := pipeline.Body["description"].(string); ok && d != "" {
+				data["Description"] = d
+			}
+			if v, ok := pipeline.Body["version"]; ok {
+				data["Version"] = v
+			}
+			if onFailure := pipeline.Processors(true); len(onFailure) > 0 {
+				data["On Failure"] = strings.Join(onFailure, " -> ")
+			}
+			if showBody {
+				data["Pipeline"] = pipeline.Body
+			}
+
+			formatter := output.NewFormatter(outFmt)
+			if err := formatter.Format(data, os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().BoolVar(&showBody, "show-body", false, "include full pipeline body in table output")
+
+	return cmd
+}
@@ -0,0 +1,211 @@
+package describe
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewDescribeSnapshotRepositoryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "snapshot-repository REPOSITORY",
+		Short:   "Describe a snapshot repository",
+		Long:    "Show the type and settings of a snapshot repository.",
+		Aliases: []string{"repository", "repo"},
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			repo, err := c.GetSnapshotRepository(args[0])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting snapshot repository: %v\n", err)
+				os.Exit(1)
+			}
+
+			outFmt := viper.GetString("output")
+			if outFmt == "json" || outFmt == "yaml" {
+				formatter := output.NewFormatter(outFmt)
+				if err := formatter.Format(repo, os.Stdout); err != nil {
+					fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+					os.Exit(1)
+				}
+				return
+			}
+
+			data := map[string]interface{}{
+				"Name":     repo.Name,
+				"Type":     repo.Type,
+				"Settings": repo.Settings,
+			}
+
+			formatter := output.NewFormatter(outFmt)
+			if err := formatter.Format(data, os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	return cmd
+}
+
+func NewDescribeSnapshotCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "snapshot REPOSITORY SNAPSHOT",
+		Short:   "Describe a snapshot",
+		Long:    "Show the state, timing, indices, shard results and failures of a snapshot.",
+		Aliases: []string{"snap"},
+		Args:    cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			repository, name := args[0], args[1]
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			snap, err := c.GetSnapshot(repository, name)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting snapshot: %v\n", err)
+				os.Exit(1)
+			}
+
+			outFmt := viper.GetString("output")
+			if outFmt == "json" || outFmt == "yaml" {
+				formatter := output.NewFormatter(outFmt)
+				if err := formatter.Format(snap, os.Stdout); err != nil {
+					fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+					os.Exit(1)
+				}
+				return
+			}
+
+			indices := append([]string(nil), snap.Indices...)
+			sort.Strings(indices)
+
+			data := map[string]interface{}{
+				"Name":                 snap.Snapshot,
+				"Repository":           repository,
+				"UUID":                 snap.UUID,
+				"State":                snap.State,
+				"Version":              snap.Version,
+				"Include Global State": snap.IncludeGlobalState,
+				"Start Time":           snap.StartTime,
+				"End Time":             snap.EndTime,
+				"Duration":             (time.Duration(snap.DurationInMillis) * time.Millisecond).Round(time.Millisecond).String(),
+				"Shards":               fmt.Sprintf("%d total, %d successful, %d failed", snap.Shards.Total, snap.Shards.Successful, snap.Shards.Failed),
+				"Indices":              indices,
+			}
+			if len(snap.DataStreams) > 0 {
+				data["Data Streams"] = snap.DataStreams
+			}
+			if snap.Reason != "" {
+				data["Reason"] = snap.Reason
+			}
+			if len(snap.Failures) > 0 {
+				data["Failures"] = snap.Failures
+			}
+			if len(snap.Metadata) > 0 {
+				data["Metadata"] = snap.Metadata
+			}
+			if len(snap.IndexDetails) > 0 {
+				var size int64
+				for _, d := range snap.IndexDetails {
+					size += d.SizeInBytes
+				}
+				data["Size"] = output.FormatBytes(size)
+			}
+
+			formatter := output.NewFormatter(outFmt)
+			if err := formatter.Format(data, os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	return cmd
+}
+
+func NewDescribeSnapshotPolicyCmd() *cobra.Command {
+	var showBody bool
+
+	cmd := &cobra.Command{
+		Use:     "snapshot-policy NAME",
+		Short:   "Describe a snapshot policy",
+		Long:    "Show the schedule, repository, retention and last runs of a snapshot policy (SLM or OpenSearch snapshot management).",
+		Aliases: []string{"snapshot-policies", "snapshotpolicy", "slm", "sm"},
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			policy, err := c.GetSnapshotPolicy(args[0])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting snapshot policy: %v\n", err)
+				os.Exit(1)
+			}
+
+			outFmt := viper.GetString("output")
+			if outFmt == "json" || outFmt == "yaml" {
+				formatter := output.NewFormatter(outFmt)
+				if err := formatter.Format(policy, os.Stdout); err != nil {
+					fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+					os.Exit(1)
+				}
+				return
+			}
+
+			data := map[string]interface{}{
+				"Name":       policy.Name,
+				"Schedule":   policy.Schedule,
+				"Repository": policy.Repository,
+			}
+			if policy.Retention != "" {
+				data["Retention"] = policy.Retention
+			}
+			if policy.Version != 0 {
+				data["Version"] = policy.Version
+			}
+			if policy.ModifiedDate != "" {
+				data["Modified Date"] = policy.ModifiedDate
+			}
+			if policy.NextExecution != "" {
+				data["Next Execution"] = policy.NextExecution
+			}
+			if len(policy.LastSuccess) > 0 {
+				data["Last Success"] = policy.LastSuccess
+			}
+			if len(policy.LastFailure) > 0 {
+				data["Last Failure"] = policy.LastFailure
+			}
+			if showBody {
+				data["Policy"] = policy.Policy
+			}
+
+			formatter := output.NewFormatter(outFmt)
+			if err := formatter.Format(data, os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().BoolVar(&showBody, "show-body", false, "include full policy body in table output")
+
+	return cmd
+}
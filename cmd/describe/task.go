@@ -0,0 +1,97 @@
+package describe
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/output"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewDescribeTaskCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "task TASK_ID",
+		Short:   "Describe a task",
+		Long:    "Show details of a running or completed task, including its status, result and child tasks. TASK_ID has the form node_id:task_number.",
+		Aliases: []string{"tasks"},
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			taskID := args[0]
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			result, err := c.GetTask(taskID)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting task: %v\n", err)
+				os.Exit(1)
+			}
+
+			outFmt := viper.GetString("output")
+			if outFmt == "json" || outFmt == "yaml" {
+				formatter := output.NewFormatter(outFmt)
+				if err := formatter.Format(result, os.Stdout); err != nil {
+					fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+					os.Exit(1)
+				}
+				return
+			}
+
+			t := result.Task
+			data := map[string]interface{}{
+				"Task":         t.TaskID(),
+				"Action":       t.Action,
+				"Type":         t.Type,
+				"Start Time":   time.UnixMilli(t.StartTimeInMillis).UTC().Format(time.RFC3339),
+				"Running Time": time.Duration(t.RunningTimeInNanos).Round(time.Millisecond).String(),
+				"Cancellable":  t.Cancellable,
+				"Completed":    result.Completed,
+			}
+			if t.Description != "" {
+				data["Description"] = t.Description
+			}
+			if t.Cancelled {
+				data["Cancelled"] = true
+			}
+			if t.ParentTaskID != "" {
+				data["Parent"] = t.ParentTaskID
+			}
+			if len(t.Status) > 0 {
+				data["Status"] = t.Status
+			}
+			if result.Error != nil {
+				data["Error"] = result.Error
+			}
+			if result.Response != nil {
+				data["Response"] = result.Response
+			}
+
+			// Child tasks only exist while the parent is running
+			if !result.Completed {
+				children, err := c.GetTasks(types.TaskListOptions{Parent: taskID})
+				if err == nil && len(children) > 0 {
+					list := make([]string, len(children))
+					for i, child := range children {
+						list[i] = fmt.Sprintf("%s (%s)", child.TaskID(), child.Action)
+					}
+					data["Children"] = list
+				}
+			}
+
+			formatter := output.NewFormatter(outFmt)
+			if err := formatter.Format(data, os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	return cmd
+}
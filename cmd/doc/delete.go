@@ -0,0 +1,61 @@
+package doc
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/prompt"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewDeleteCmd() *cobra.Command {
+	var opts types.DocumentOptions
+
+	cmd := &cobra.Command{
+		Use:   "delete INDEX ID",
+		Short: "Delete a document by id",
+		Long:  "Delete a document by id. For documents in a data stream the request is sent to the backing index that holds the document.",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			index, id := args[0], args[1]
+
+			if err := validateRefresh(opts.Refresh); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			if viper.GetBool("dry-run") {
+				cmd.Printf("Would delete document %s from %s\n", id, index)
+				return
+			}
+
+			if !prompt.Confirm(cmd, fmt.Sprintf("delete document '%s' from '%s'", id, index)) {
+				fmt.Println("Delete operation cancelled.")
+				return
+			}
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			result, err := c.DeleteDocument(index, id, opts)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error deleting document: %v\n", err)
+				os.Exit(1)
+			}
+
+			printResult(cmd, result)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Routing, "routing", "", "routing value the document was indexed with")
+	addRefreshFlag(cmd, &opts.Refresh)
+	cmd.Flags().BoolP("yes", "y", false, "automatically confirm deletion without prompting")
+
+	return cmd
+}
@@ -0,0 +1,92 @@
+package doc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/chronicblondiee/searchctl/pkg/output"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+func NewDocCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "doc",
+		Short:   "Read and write single documents",
+		Long:    "Get, index, update and delete single documents by id. Data streams are resolved to the backing index that holds the document.",
+		Aliases: []string{"docs", "document"},
+	}
+
+	cmd.AddCommand(NewGetCmd())
+	cmd.AddCommand(NewIndexCmd())
+	cmd.AddCommand(NewUpdateCmd())
+	cmd.AddCommand(NewDeleteCmd())
+
+	return cmd
+}
+
+// addRefreshFlag registers --refresh, which may be given bare (true) or as --refresh=wait_for
+func addRefreshFlag(cmd *cobra.Command, refresh *string) {
+	cmd.Flags().StringVar(refresh, "refresh", "", "refresh the affected shards: true, false or wait_for")
+	cmd.Flags().Lookup("refresh").NoOptDefVal = "true"
+}
+
+func validateRefresh(refresh string) error {
+	switch refresh {
+	case "", "true", "false", "wait_for":
+		return nil
+	}
+	return fmt.Errorf("invalid --refresh %q: must be true, false or wait_for", refresh)
+}
+
+// readDocument reads a JSON or YAML document from a file, or from stdin when filename is "-"
+func readDocument(filename string) (map[string]interface{}, error) {
+	var data []byte
+	var err error
+	if filename == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(filename)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseDocument(data)
+}
+
+// parseDocument decodes JSON with json.Number so large integers survive the round trip,
+// falling back to YAML for anything that does not look like a JSON object
+func parseDocument(data []byte) (map[string]interface{}, error) {
+	var body map[string]interface{}
+	trimmed := bytes.TrimSpace(data)
+	if bytes.HasPrefix(trimmed, []byte("{")) {
+		dec := json.NewDecoder(bytes.NewReader(trimmed))
+		dec.UseNumber()
+		if err := dec.Decode(&body); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON: %w", err)
+		}
+	} else if err := yaml.Unmarshal(trimmed, &body); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	if body == nil {
+		return nil, fmt.Errorf("document is empty")
+	}
+	return body, nil
+}
+
+func printResult(cmd *cobra.Command, result *types.DocumentWriteResult) {
+	outFmt := viper.GetString("output")
+	if outFmt == "json" || outFmt == "yaml" {
+		if err := output.NewFormatter(outFmt).Format(result, cmd.OutOrStdout()); err != nil {
+			fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	cmd.Printf("Document %s %s in %s (version %d)\n", result.ID, result.Result, result.Index, result.Version)
+}
@@ -0,0 +1,80 @@
+package doc
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNewDocCmd(t *testing.T) {
+	cmd := NewDocCmd()
+
+	expected := map[string]bool{"get": false, "index": false, "update": false, "delete": false}
+	for _, sub := range cmd.Commands() {
+		if _, ok := expected[sub.Name()]; ok {
+			expected[sub.Name()] = true
+			if sub.Flags().Lookup("routing") == nil {
+				t.Errorf("Expected --routing on doc %s", sub.Name())
+			}
+		}
+	}
+	for name, found := range expected {
+		if !found {
+			t.Errorf("Expected subcommand '%s' not found", name)
+		}
+	}
+}
+
+func TestBuildUpdateBody(t *testing.T) {
+	if _, err := buildUpdateBody(updateOptions{}); err == nil {
+		t.Error("Expected error when neither --doc nor --script is given")
+	}
+	if _, err := buildUpdateBody(updateOptions{doc: `{"a":1}`, script: "x"}); err == nil {
+		t.Error("Expected error when both --doc and --script are given")
+	}
+	if _, err := buildUpdateBody(updateOptions{script: "x", upsert: true}); err == nil {
+		t.Error("Expected error for --upsert with --script")
+	}
+
+	body, err := buildUpdateBody(updateOptions{doc: `{"status": "shipped"}`, upsert: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if body["doc_as_upsert"] != true {
+		t.Errorf("Expected doc_as_upsert, got %v", body)
+	}
+	if doc, ok := body["doc"].(map[string]interface{}); !ok || doc["status"] != "shipped" {
+		t.Errorf("Expected partial doc, got %v", body["doc"])
+	}
+
+	body, err = buildUpdateBody(updateOptions{script: "ctx._source.n += 1", scriptLang: "painless"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	script := body["script"].(map[string]interface{})
+	if script["source"] != "ctx._source.n += 1" || script["lang"] != "painless" {
+		t.Errorf("Unexpected script body: %v", script)
+	}
+}
+
+func TestParseDocument(t *testing.T) {
+	body, err := parseDocument([]byte(`{"id": 1234567890123456789}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	data, _ := json.Marshal(body)
+	if string(data) != `{"id":1234567890123456789}` {
+		t.Errorf("Expected large integer to be preserved, got %s", data)
+	}
+
+	body, err = parseDocument([]byte("status: open\ncount: 2\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if body["status"] != "open" {
+		t.Errorf("Expected YAML document to be parsed, got %v", body)
+	}
+
+	if _, err := parseDocument([]byte("")); err == nil {
+		t.Error("Expected error for empty document")
+	}
+}
@@ -0,0 +1,84 @@
+package doc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/output"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewGetCmd() *cobra.Command {
+	var opts types.DocumentOptions
+
+	cmd := &cobra.Command{
+		Use:   "get INDEX ID",
+		Short: "Get a document by id",
+		Long: `Get a document by id from an index, alias or data stream.
+
+Data streams do not support get by id directly; searchctl first finds the
+backing index holding the document and reads it from there.`,
+		Example: strings.TrimSpace(`
+# Show a document with its metadata
+searchctl doc get orders 42
+
+# Only fetch some fields of a routed document
+searchctl doc get orders 42 --routing customer-7 --source-includes 'status,items.*'
+
+# Get a document from a data stream as JSON
+searchctl doc get logs-app-default Xk1s2Y8BxJ -o json`),
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			index, id := args[0], args[1]
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			doc, err := c.GetDocument(index, id, opts)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting document: %v\n", err)
+				os.Exit(1)
+			}
+
+			outFmt := viper.GetString("output")
+			if outFmt == "json" || outFmt == "yaml" {
+				if err := output.NewFormatter(outFmt).Format(doc, cmd.OutOrStdout()); err != nil {
+					fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+					os.Exit(1)
+				}
+				return
+			}
+
+			printDocument(cmd.OutOrStdout(), doc)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Routing, "routing", "", "routing value the document was indexed with")
+	cmd.Flags().StringVar(&opts.SourceIncludes, "source-includes", "", "comma-separated source fields to return (wildcards allowed)")
+	cmd.Flags().StringVar(&opts.SourceExcludes, "source-excludes", "", "comma-separated source fields to leave out")
+
+	return cmd
+}
+
+// printDocument writes the document metadata followed by its pretty-printed source
+func printDocument(w io.Writer, doc *types.Document) {
+	fmt.Fprintf(w, "Index:         %s\n", doc.Index)
+	fmt.Fprintf(w, "ID:            %s\n", doc.ID)
+	fmt.Fprintf(w, "Version:       %d\n", doc.Version)
+	fmt.Fprintf(w, "Seq No:        %d\n", doc.SeqNo)
+	fmt.Fprintf(w, "Primary Term:  %d\n", doc.PrimaryTerm)
+	if doc.Routing != "" {
+		fmt.Fprintf(w, "Routing:       %s\n", doc.Routing)
+	}
+	source, _ := json.MarshalIndent(doc.Source, "", "  ")
+	fmt.Fprintf(w, "Source:\n%s\n", string(source))
+}
@@ -0,0 +1,94 @@
+package doc
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewIndexCmd() *cobra.Command {
+	var opts types.DocumentOptions
+	var filename string
+
+	cmd := &cobra.Command{
+		Use:   "index INDEX [ID]",
+		Short: "Index a document",
+		Long: `Index a document from a JSON or YAML file. Use -f - to read from stdin.
+
+Without ID the cluster generates one. An existing document with the same ID is
+replaced unless --op-type create is given. Data streams are append-only, so
+documents are always created with op_type=create there and must contain
+@timestamp.`,
+		Example: strings.TrimSpace(`
+# Index a document with a known id and make it visible to search immediately
+searchctl doc index orders 42 -f order.json --refresh
+
+# Let the cluster pick the id and run the document through an ingest pipeline
+cat event.json | searchctl doc index logs-app-default -f - --pipeline parse-app
+
+# Fail instead of overwriting an existing document
+searchctl doc index orders 42 -f order.json --op-type create`),
+		Args: cobra.RangeArgs(1, 2),
+		Run: func(cmd *cobra.Command, args []string) {
+			index := args[0]
+			id := ""
+			if len(args) > 1 {
+				id = args[1]
+			}
+
+			if err := validateRefresh(opts.Refresh); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if opts.OpType != "" && opts.OpType != "index" && opts.OpType != "create" {
+				fmt.Fprintf(os.Stderr, "Error: invalid --op-type %q: must be index or create\n", opts.OpType)
+				os.Exit(1)
+			}
+
+			body, err := readDocument(filename)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error reading document: %v\n", err)
+				os.Exit(1)
+			}
+
+			if viper.GetBool("dry-run") {
+				data, _ := json.MarshalIndent(body, "", "  ")
+				if id == "" {
+					cmd.Printf("Would index a document into %s\n%s\n", index, string(data))
+				} else {
+					cmd.Printf("Would index document %s into %s\n%s\n", id, index, string(data))
+				}
+				return
+			}
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			result, err := c.IndexDocument(index, id, body, opts)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error indexing document: %v\n", err)
+				os.Exit(1)
+			}
+
+			printResult(cmd, result)
+		},
+	}
+
+	cmd.Flags().StringVarP(&filename, "filename", "f", "", "document file (JSON or YAML, - for stdin)")
+	cmd.Flags().StringVar(&opts.Routing, "routing", "", "custom routing value")
+	cmd.Flags().StringVar(&opts.Pipeline, "pipeline", "", "ingest pipeline to run the document through")
+	cmd.Flags().StringVar(&opts.OpType, "op-type", "", "index (create or replace) or create (fail if the id exists)")
+	addRefreshFlag(cmd, &opts.Refresh)
+	cmd.MarkFlagRequired("filename")
+
+	return cmd
+}
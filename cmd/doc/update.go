@@ -0,0 +1,125 @@
+package doc
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type updateOptions struct {
+	doc        string
+	script     string
+	scriptLang string
+	upsert     bool
+}
+
+func NewUpdateCmd() *cobra.Command {
+	var opts types.DocumentOptions
+	var update updateOptions
+
+	cmd := &cobra.Command{
+		Use:   "update INDEX ID",
+		Short: "Partially update a document",
+		Long: `Update a document with a partial document (--doc) or a script (--script).
+
+--doc takes a JSON object, or @FILE to read it from a JSON or YAML file. Fields
+in it are merged into the existing source. For documents in a data stream the
+update is sent to the backing index that holds the document.`,
+		Example: strings.TrimSpace(`
+# Merge fields into a document
+searchctl doc update orders 42 --doc '{"status": "shipped"}'
+
+# Create the document from --doc if it does not exist yet
+searchctl doc update orders 42 --doc @patch.yaml --upsert
+
+# Increment a counter with a script, retrying on version conflicts
+searchctl doc update counters page-home --script 'ctx._source.views += 1' --retry-on-conflict 3`),
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			index, id := args[0], args[1]
+
+			if err := validateRefresh(opts.Refresh); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			body, err := buildUpdateBody(update)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error building update request: %v\n", err)
+				os.Exit(1)
+			}
+
+			if viper.GetBool("dry-run") {
+				data, _ := json.MarshalIndent(body, "", "  ")
+				cmd.Printf("Would update document %s in %s\n%s\n", id, index, string(data))
+				return
+			}
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			result, err := c.UpdateDocument(index, id, body, opts)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error updating document: %v\n", err)
+				os.Exit(1)
+			}
+
+			printResult(cmd, result)
+		},
+	}
+
+	cmd.Flags().StringVar(&update.doc, "doc", "", "partial document as JSON, or @FILE")
+	cmd.Flags().StringVar(&update.script, "script", "", "script that modifies ctx._source")
+	cmd.Flags().StringVar(&update.scriptLang, "script-lang", "painless", "language of --script")
+	cmd.Flags().BoolVar(&update.upsert, "upsert", false, "index --doc as a new document if the id does not exist")
+	cmd.Flags().StringVar(&opts.Routing, "routing", "", "routing value the document was indexed with")
+	cmd.Flags().IntVar(&opts.RetryOnConflict, "retry-on-conflict", 0, "how many times to retry on version conflicts")
+	addRefreshFlag(cmd, &opts.Refresh)
+
+	return cmd
+}
+
+// buildUpdateBody assembles the _update request from exactly one of --doc or --script
+func buildUpdateBody(opts updateOptions) (map[string]interface{}, error) {
+	if (opts.doc == "") == (opts.script == "") {
+		return nil, fmt.Errorf("exactly one of --doc or --script is required")
+	}
+
+	if opts.script != "" {
+		if opts.upsert {
+			return nil, fmt.Errorf("--upsert can only be used with --doc")
+		}
+		return map[string]interface{}{
+			"script": map[string]interface{}{
+				"source": opts.script,
+				"lang":   opts.scriptLang,
+			},
+		}, nil
+	}
+
+	var partial map[string]interface{}
+	var err error
+	if strings.HasPrefix(opts.doc, "@") {
+		partial, err = readDocument(strings.TrimPrefix(opts.doc, "@"))
+	} else {
+		partial, err = parseDocument([]byte(opts.doc))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid --doc: %w", err)
+	}
+
+	body := map[string]interface{}{"doc": partial}
+	if opts.upsert {
+		body["doc_as_upsert"] = true
+	}
+	return body, nil
+}
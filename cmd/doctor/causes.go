@@ -0,0 +1,190 @@
+package doctor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/chronicblondiee/searchctl/pkg/types"
+)
+
+// cause is a root cause that shard problems are grouped by
+type cause struct {
+	id    string
+	title string
+	hint  string
+	// command is a remediation that applies to the whole group
+	command string
+}
+
+// causes lists the root causes in the order they are reported
+var causes = []cause{
+	{
+		id:    "no-valid-copy",
+		title: "No valid shard copy",
+		hint: "No node holds an in-sync copy of the shard. Bring back the nodes that held it. As a last resort,\n" +
+			"promote a stale copy, which loses the writes it missed, or restore the index from a snapshot.",
+	},
+	{
+		id:      "max-retries",
+		title:   "Allocation retries exhausted",
+		hint:    "Allocation failed too many times in a row. Fix the failure shown in the detail, then retry.",
+		command: "searchctl cluster reroute --retry-failed",
+	},
+	{
+		id:      "allocation-disabled",
+		title:   "Allocation disabled",
+		hint:    "cluster.routing.allocation.enable (or index.routing.allocation.enable) does not allow this shard.",
+		command: "searchctl cluster allocation-settings --enable all",
+	},
+	{
+		id:    "delayed",
+		title: "Allocation delayed",
+		hint: "A node left and the cluster waits for it to return before rebuilding the copies\n" +
+			"(index.unassigned.node_left.delayed_timeout). No action is needed unless the node is gone for good.",
+	},
+	{
+		id:    "disk-watermark",
+		title: "Disk watermark",
+		hint: "Nodes are above the disk watermarks. Free disk space, add nodes, or raise\n" +
+			"cluster.routing.allocation.disk.watermark.low, .high and .flood_stage.",
+	},
+	{
+		id:    "awareness",
+		title: "Allocation awareness",
+		hint: "Allocation awareness leaves no zone for another copy. Add nodes in the missing zones, lower\n" +
+			"index.number_of_replicas, or check cluster.routing.allocation.awareness.* settings.",
+	},
+	{
+		id:    "allocation-filter",
+		title: "Allocation filter",
+		hint: "Allocation filters rule out the remaining nodes. Check index.routing.allocation.require, .include and\n" +
+			".exclude of the index and cluster.routing.allocation.*; 'searchctl node uncordon NODE' undoes a drain.",
+	},
+	{
+		id:    "shards-limit",
+		title: "Shards per node limit",
+		hint:  "The total_shards_per_node limit of the index or cluster is reached. Raise the limit or add nodes.",
+	},
+	{
+		id:    "too-few-nodes",
+		title: "Too few nodes",
+		hint:  "Every eligible node already holds a copy of the shard. Lower index.number_of_replicas or add nodes.",
+	},
+	{
+		id:    "throttled",
+		title: "Allocation throttled",
+		hint:  "Allocation waits for other recoveries to finish and continues on its own.",
+	},
+	{
+		id:    "slow-recovery",
+		title: "Slow recovery",
+		hint: "Shards have been initializing longer than expected. Check the recovery throttle\n" +
+			"indices.recovery.max_bytes_per_sec and the load of the target node.",
+	},
+	{
+		id:    "unknown",
+		title: "Other",
+		hint:  "The cause could not be classified; see the full allocation explanation.",
+	},
+}
+
+// deciderCauses maps allocation deciders to causes, in order of precedence when several
+// deciders say no
+var deciderCauses = []struct {
+	decider string
+	cause   string
+}{
+	{"max_retry", "max-retries"},
+	{"enable", "allocation-disabled"},
+	{"disk_threshold", "disk-watermark"},
+	{"awareness", "awareness"},
+	{"filter", "allocation-filter"},
+	{"shards_limit", "shards-limit"},
+	{"same_shard", "too-few-nodes"},
+	{"throttling", "throttled"},
+}
+
+// classify finds the root cause of an unassigned shard from its allocation explanation.
+// It returns the cause, a detail message and the node holding a stale copy, if any.
+func classify(resp *types.AllocationExplainResponse) (string, string, string) {
+	lastStatus, _ := resp.UnassignedInfo["last_allocation_status"].(string)
+	if resp.CanAllocate == "no_valid_shard_copy" || lastStatus == "no_valid_shard_copy" {
+		return "no-valid-copy", resp.AllocateExplanation, staleCopyNode(resp)
+	}
+	if resp.CanAllocate == "allocation_delayed" {
+		return "delayed", resp.AllocateExplanation, ""
+	}
+
+	for _, dc := range deciderCauses {
+		want := "NO"
+		if dc.decider == "throttling" {
+			want = "THROTTLE"
+		}
+		for _, node := range resp.NodeAllocationDecisions {
+			deciders, _ := node["deciders"].([]interface{})
+			for _, d := range deciders {
+				decider, _ := d.(map[string]interface{})
+				if decider["decider"] == dc.decider && decider["decision"] == want {
+					explanation, _ := decider["explanation"].(string)
+					if dc.cause == "max-retries" {
+						if details, ok := resp.UnassignedInfo["details"].(string); ok && details != "" {
+							explanation = details
+						}
+					}
+					return dc.cause, explanation, ""
+				}
+			}
+		}
+	}
+
+	if resp.CanAllocate == "throttled" {
+		return "throttled", resp.AllocateExplanation, ""
+	}
+	return "unknown", resp.AllocateExplanation, ""
+}
+
+// staleCopyNode returns a node that holds a copy of the shard without a store error
+func staleCopyNode(resp *types.AllocationExplainResponse) string {
+	for _, node := range resp.NodeAllocationDecisions {
+		store, ok := node["store"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if _, failed := store["store_exception"]; failed {
+			continue
+		}
+		if _, found := store["allocation_id"]; found {
+			name, _ := node["node_name"].(string)
+			return name
+		}
+	}
+	return ""
+}
+
+// shardCommand suggests a remediation for a single shard
+func shardCommand(p problem) string {
+	switch p.Cause {
+	case "no-valid-copy":
+		if p.Node != "" {
+			return fmt.Sprintf("searchctl cluster reroute allocate-stale-primary --index %s --shard %s --node %s --accept-data-loss", p.Index, p.Shard, p.Node)
+		}
+	case "delayed":
+		return fmt.Sprintf("searchctl set settings %s index.unassigned.node_left.delayed_timeout=0", p.Index)
+	case "unknown":
+		command := fmt.Sprintf("searchctl describe allocation --index %s --shard %s", p.Index, p.Shard)
+		if p.Prirep == "p" {
+			command += " --primary"
+		}
+		return command
+	}
+	return ""
+}
+
+// oneLine collapses whitespace and shortens a message for table output
+func oneLine(s string, max int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > max {
+		return string(r[:max-3]) + "..."
+	}
+	return s
+}
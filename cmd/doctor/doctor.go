@@ -0,0 +1,148 @@
+package doctor
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewDoctorCmd() *cobra.Command {
+	var failOn, maxShardSize, minShardSize string
+	var skip []string
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose cluster problems",
+		Long: `Find problems in the cluster, explain their cause and suggest how to fix them.
+
+Without a subcommand, run the catalogue of checks listed by 'searchctl doctor rules'
+over cluster health, settings, nodes, disk allocation, indices, data streams and
+templates.
+Every finding has a severity (error, warning or info) and a remediation.
+
+Output is a table, JSON, YAML or SARIF (-o sarif) for code scanning dashboards.
+The command exits with status 1 when a finding is at or above --fail-on, which
+makes it suitable for cron jobs and CI.`,
+		Example: strings.TrimSpace(`
+# Run all checks
+searchctl doctor
+
+# Fail on warnings too, and ignore the template check
+searchctl doctor --fail-on warning --skip unused-template
+
+# Write a SARIF report
+searchctl doctor -o sarif > doctor.sarif
+
+# Explain unassigned shards
+searchctl doctor shards`),
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if _, ok := severityRank[failOn]; !ok && failOn != "none" {
+				fmt.Fprintf(os.Stderr, "Error: invalid --fail-on %q: must be error, warning, info or none\n", failOn)
+				os.Exit(1)
+			}
+			skipped := make(map[string]bool)
+			for _, id := range skip {
+				if _, ok := ruleByID(id); !ok {
+					fmt.Fprintf(os.Stderr, "Error: unknown rule %q; see 'searchctl doctor rules'\n", id)
+					os.Exit(1)
+				}
+				skipped[id] = true
+			}
+			var l limits
+			var err error
+			if l.maxShardSize, err = output.ParseBytes(maxShardSize); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: invalid --max-shard-size: %v\n", err)
+				os.Exit(1)
+			}
+			if l.minShardSize, err = output.ParseBytes(minShardSize); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: invalid --min-shard-size: %v\n", err)
+				os.Exit(1)
+			}
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			d, err := collect(c)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error collecting cluster data: %v\n", err)
+				os.Exit(1)
+			}
+			findings := runRules(d, l, skipped)
+			if err := printFindings(os.Stdout, findings); err != nil {
+				fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+				os.Exit(1)
+			}
+			if failed(findings, failOn) {
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&failOn, "fail-on", severityError, "exit with status 1 on findings of this severity or higher (error|warning|info|none)")
+	cmd.Flags().StringSliceVar(&skip, "skip", nil, "rules to skip, comma separated")
+	cmd.Flags().StringVar(&maxShardSize, "max-shard-size", "50gb", "primary shards above this size are oversized")
+	cmd.Flags().StringVar(&minShardSize, "min-shard-size", "1gb", "average primary shard size below which an index with several primaries is undersized")
+
+	cmd.AddCommand(NewDoctorShardsCmd())
+	cmd.AddCommand(NewDoctorRulesCmd())
+
+	return cmd
+}
+
+func NewDoctorRulesCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rules",
+		Short: "List the checks run by doctor",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			outFmt := viper.GetString("output")
+			var data interface{}
+			if outFmt == "json" || outFmt == "yaml" {
+				data = ruleList()
+			} else {
+				data = ruleRows()
+			}
+			if err := output.NewFormatter(outFmt).Format(data, os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+}
+
+type ruleInfo struct {
+	ID          string `json:"id"`
+	Severity    string `json:"severity"`
+	Description string `json:"description"`
+	Remediation string `json:"remediation"`
+}
+
+func ruleList() []ruleInfo {
+	list := make([]ruleInfo, len(rules))
+	for i, r := range rules {
+		list[i] = ruleInfo{ID: r.id, Severity: r.severity, Description: r.description, Remediation: r.remediation}
+	}
+	return list
+}
+
+func ruleRows() []interface{} {
+	rows := make([]interface{}, len(rules))
+	for i, r := range rules {
+		rows[i] = map[string]interface{}{
+			"__columns":   "RULE,SEVERITY,DESCRIPTION",
+			"RULE":        r.id,
+			"SEVERITY":    r.severity,
+			"DESCRIPTION": r.description,
+		}
+	}
+	return rows
+}
@@ -0,0 +1,331 @@
+package doctor
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chronicblondiee/searchctl/pkg/types"
+)
+
+func explanation(t *testing.T, body string) *types.AllocationExplainResponse {
+	var resp types.AllocationExplainResponse
+	if err := json.Unmarshal([]byte(body), &resp); err != nil {
+		t.Fatal(err)
+	}
+	return &resp
+}
+
+func TestNewDoctorCmd(t *testing.T) {
+	cmd := NewDoctorCmd()
+	sub, _, err := cmd.Find([]string{"shards"})
+	if err != nil || sub.Name() != "shards" {
+		t.Fatal("Expected shards subcommand")
+	}
+	for _, name := range []string{"index", "initializing-after", "limit"} {
+		if sub.Flags().Lookup(name) == nil {
+			t.Errorf("Expected --%s flag", name)
+		}
+	}
+}
+
+// The fixtures follow _cluster/allocation/explain responses of Elasticsearch 8
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name, body, cause, node string
+	}{
+		{"disk", `{
+  "index": "logs-1", "shard": 0, "primary": false, "current_state": "unassigned",
+  "unassigned_info": {"reason": "NODE_LEFT", "at": "2024-05-01T10:00:00.000Z", "details": "node_left [8qt2rY-pT6KNZB3-hGfLnw]", "last_allocation_status": "no_attempt"},
+  "can_allocate": "no",
+  "allocate_explanation": "Elasticsearch isn't allowed to allocate this shard to any of the nodes in the cluster.",
+  "node_allocation_decisions": [
+    {"node_id": "8qt2rY-pT6KNZB3-hGfLnw", "node_name": "es-data-1", "transport_address": "10.0.0.1:9300", "node_decision": "no", "weight_ranking": 1,
+     "deciders": [{"decider": "same_shard", "decision": "NO", "explanation": "a copy of this shard is already allocated to this node [[logs-1][0], node[8qt2rY-pT6KNZB3-hGfLnw], [P], s[STARTED], a[id=u9lnPw8lQ0KUTVyJZ1dJkw]]"}]},
+    {"node_id": "R1s8wBQeSS2Pn7x0l0gI2w", "node_name": "es-data-2", "transport_address": "10.0.0.2:9300", "node_decision": "no", "weight_ranking": 2,
+     "deciders": [{"decider": "disk_threshold", "decision": "NO", "explanation": "the node is above the high watermark cluster setting [cluster.routing.allocation.disk.watermark.high=90%], having less than the minimum required [10%] free space, actual free: [4.2%]"}]}
+  ]
+}`, "disk-watermark", ""},
+		{"retries", `{
+  "index": "logs-1", "shard": 1, "primary": false, "current_state": "unassigned",
+  "unassigned_info": {"reason": "ALLOCATION_FAILED", "at": "2024-05-01T10:00:00.000Z", "failed_allocation_attempts": 5,
+    "details": "failed shard on node [MpTpkn0_RfKYK6yy6SbQmw]: failed recovery, failure RecoveryFailedException", "last_allocation_status": "no"},
+  "can_allocate": "no",
+  "allocate_explanation": "Elasticsearch isn't allowed to allocate this shard to any of the nodes in the cluster.",
+  "node_allocation_decisions": [
+    {"node_id": "3sULLVJrRneSg0EfBB-2Ew", "node_name": "es-data-1", "transport_address": "10.0.0.1:9300", "node_decision": "no",
+     "store": {"matching_size": "4.2kb", "matching_size_in_bytes": 4325},
+     "deciders": [
+       {"decider": "max_retry", "decision": "NO", "explanation": "shard has exceeded the maximum number of retries [5] on failed allocation attempts - manually call [POST /_cluster/reroute?retry_failed] to retry"},
+       {"decider": "filter", "decision": "NO", "explanation": "node matches cluster setting [cluster.routing.allocation.exclude] filters [_name:\"es-data-1\"]"}
+     ]}
+  ]
+}`, "max-retries", ""},
+		{"no copy", `{
+  "index": "orders", "shard": 2, "primary": true, "current_state": "unassigned",
+  "unassigned_info": {"reason": "NODE_LEFT", "at": "2024-05-01T10:00:00.000Z", "details": "node_left [OIWe8UhhThCK0V5XfmdrmQ]", "last_allocation_status": "no_valid_shard_copy"},
+  "can_allocate": "no_valid_shard_copy",
+  "allocate_explanation": "Elasticsearch can't allocate this shard because all the copies of its data in the cluster are stale or corrupt.",
+  "node_allocation_decisions": [
+    {"node_id": "8qt2rY-pT6KNZB3-hGfLnw", "node_name": "es-data-1", "transport_address": "10.0.0.1:9300", "node_decision": "no", "store": {"found": false}},
+    {"node_id": "R1s8wBQeSS2Pn7x0l0gI2w", "node_name": "es-data-2", "transport_address": "10.0.0.2:9300", "node_decision": "no",
+     "store": {"in_sync": false, "allocation_id": "pGKEmbtTQjKEDVXcSjNTrA"}}
+  ]
+}`, "no-valid-copy", "es-data-2"},
+		{"delayed", `{
+  "index": "logs-1", "shard": 0, "primary": false, "current_state": "unassigned",
+  "unassigned_info": {"reason": "NODE_LEFT", "at": "2024-05-01T10:00:00.000Z", "delayed": true, "details": "node_left [8qt2rY-pT6KNZB3-hGfLnw]", "last_allocation_status": "no_attempt"},
+  "can_allocate": "allocation_delayed",
+  "allocate_explanation": "The node containing this shard copy recently left the cluster. Elasticsearch is waiting for it to return.",
+  "configured_delay_in_millis": 60000,
+  "remaining_delay_in_millis": 59824
+}`, "delayed", ""},
+		{"awareness", `{
+  "index": "logs-1", "shard": 0, "primary": false, "current_state": "unassigned",
+  "can_allocate": "no",
+  "allocate_explanation": "Elasticsearch isn't allowed to allocate this shard to any of the nodes in the cluster.",
+  "node_allocation_decisions": [
+    {"node_id": "8qt2rY-pT6KNZB3-hGfLnw", "node_name": "es-data-1", "node_attributes": {"zone": "a"}, "node_decision": "no",
+     "deciders": [{"decider": "awareness", "decision": "NO", "explanation": "there are [2] copies of this shard and [2] values for attribute [zone] ([a, b] from nodes in the cluster and no forced awareness) so there may be at most [1] copies of this shard allocated to nodes with each value, but (including this copy) there would be [2] copies allocated to nodes with [node.attr.zone: a]"}]}
+  ]
+}`, "awareness", ""},
+		{"other", `{"index": "logs-1", "shard": 0, "primary": false, "current_state": "unassigned", "can_allocate": "no", "allocate_explanation": "something else"}`, "unknown", ""},
+	}
+	for _, tt := range tests {
+		cause, detail, node := classify(explanation(t, tt.body))
+		if cause != tt.cause || node != tt.node {
+			t.Errorf("%s: got cause %q node %q, expected %q %q", tt.name, cause, node, tt.cause, tt.node)
+		}
+		if tt.cause == "max-retries" && !strings.Contains(detail, "RecoveryFailedException") {
+			t.Errorf("Expected the failure details, got %q", detail)
+		}
+	}
+}
+
+func TestUnassignedShards(t *testing.T) {
+	shards := []types.CatShardRow{
+		{Index: "logs", Shard: "0", PrimaryOrReplica: "p", State: "STARTED"},
+		{Index: "logs", Shard: "0", PrimaryOrReplica: "r", State: "UNASSIGNED", UnassignedReason: "NODE_LEFT"},
+		{Index: "logs", Shard: "0", PrimaryOrReplica: "r", State: "UNASSIGNED", UnassignedReason: "NODE_LEFT"},
+		{Index: "logs", Shard: "1", PrimaryOrReplica: "p", State: "UNASSIGNED"},
+	}
+	problems := unassignedShards(shards)
+	if len(problems) != 2 || problems[0].Copies != 2 || problems[1].Prirep != "p" {
+		t.Errorf("Unexpected problems %+v", problems)
+	}
+}
+
+func TestSlowRecoveries(t *testing.T) {
+	shards := []types.CatShardRow{
+		{Index: "logs", Shard: "0", PrimaryOrReplica: "r", State: "INITIALIZING", Node: "n2"},
+		{Index: "logs", Shard: "1", PrimaryOrReplica: "r", State: "INITIALIZING", Node: "n3"},
+	}
+	recoveries := []types.CatRecoveryRow{
+		{Index: "logs", Shard: "0", Type: "peer", Stage: "index", TargetNode: "n2", SourceNode: "n1", Time: "42.5m", BytesPercent: "12.0%"},
+		{Index: "logs", Shard: "1", Type: "peer", Stage: "index", TargetNode: "n3", Time: "30s"},
+	}
+	problems := slowRecoveries(shards, recoveries, 10*time.Minute)
+	if len(problems) != 1 || problems[0].Shard != "0" || problems[0].Cause != "slow-recovery" {
+		t.Fatalf("Unexpected problems %+v", problems)
+	}
+	if !strings.Contains(problems[0].Detail, "from n1") {
+		t.Errorf("Expected the source node in %q", problems[0].Detail)
+	}
+}
+
+func TestPrintProblems(t *testing.T) {
+	problems := []problem{
+		{Index: "logs", Shard: "0", Prirep: "r", State: "UNASSIGNED", Copies: 1, Cause: "max-retries", Detail: "failed"},
+		{Index: "orders", Shard: "2", Prirep: "p", State: "UNASSIGNED", Copies: 1, Cause: "no-valid-copy", Node: "n2"},
+	}
+	for i := range problems {
+		problems[i].Command = shardCommand(problems[i])
+	}
+
+	var buf bytes.Buffer
+	if err := printProblems(&buf, problems); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if strings.Index(out, "No valid shard copy") > strings.Index(out, "Allocation retries exhausted") {
+		t.Errorf("Expected groups in cause order:\n%s", out)
+	}
+	for _, want := range []string{
+		"searchctl cluster reroute --retry-failed",
+		"searchctl cluster reroute allocate-stale-primary --index orders --shard 2 --node n2 --accept-data-loss",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestDoctorFlags(t *testing.T) {
+	cmd := NewDoctorCmd()
+	for _, name := range []string{"fail-on", "skip", "max-shard-size", "min-shard-size"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("Expected --%s flag", name)
+		}
+	}
+	if sub, _, err := cmd.Find([]string{"rules"}); err != nil || sub.Name() != "rules" {
+		t.Error("Expected rules subcommand")
+	}
+}
+
+func testClusterData() *clusterData {
+	return &clusterData{
+		health: &types.ClusterHealth{ClusterName: "prod", Status: "yellow", NumberOfDataNodes: 2, UnassignedShards: 1},
+		settings: &types.ClusterSettings{
+			Persistent: map[string]interface{}{"cluster": map[string]interface{}{"max_shards_per_node": "3"}},
+			Transient:  map[string]interface{}{"cluster.routing.allocation.enable": "primaries"},
+		},
+		nodes: []types.Node{
+			{Name: "n1", NodeRole: "dim", HeapPercent: "92"},
+			{Name: "n2", NodeRole: "dim", HeapPercent: "40"},
+		},
+		indices: []types.Index{
+			{Name: "logs", Status: "open", Primary: "3", Replica: "1", PrimaryStoreSize: "30mb"},
+			{Name: "orders", Status: "open", Primary: "1", Replica: "0", PrimaryStoreSize: "60gb"},
+			{Name: ".security", Status: "open", Primary: "1", Replica: "0"},
+		},
+		shards: []types.CatShardRow{
+			{Index: "orders", Shard: "0", PrimaryOrReplica: "p", State: "STARTED", Store: "60gb", Node: "n1"},
+			{Index: "logs", Shard: "0", PrimaryOrReplica: "p", State: "STARTED", Store: "10mb", Node: "n1"},
+			{Index: "logs", Shard: "1", PrimaryOrReplica: "p", State: "STARTED", Store: "10mb", Node: "n1"},
+			{Index: "logs", Shard: "2", PrimaryOrReplica: "p", State: "STARTED", Store: "10mb", Node: "n2"},
+		},
+		dataStreams: []types.DataStream{
+			{Name: "metrics-app", Indices: []types.DataStreamIndex{{IndexName: ".ds-metrics-app-000001"}}},
+			{Name: "logs-app", IlmPolicy: "logs"},
+			{Name: "audit", Indices: []types.DataStreamIndex{{IndexName: ".ds-audit-000001"}}},
+		},
+		templates: []types.IndexTemplate{
+			{Name: "logs", IndexPattern: []string{"logs*"}},
+			{Name: "old", IndexPattern: []string{"legacy-*"}},
+			{Name: "builtin", IndexPattern: []string{"synthetics-*"}, Meta: map[string]interface{}{"managed": true}},
+		},
+		policies: []types.LifecyclePolicy{
+			{Name: "audit", Policy: map[string]interface{}{"ism_template": []interface{}{
+				map[string]interface{}{"index_patterns": []interface{}{".ds-audit-*"}},
+			}}},
+		},
+	}
+}
+
+func TestRunRules(t *testing.T) {
+	l := limits{maxShardSize: 50 << 30, minShardSize: 1 << 30}
+	findings := runRules(testClusterData(), l, map[string]bool{"disk-imbalance": true})
+
+	got := make(map[string]finding)
+	for _, f := range findings {
+		got[f.Rule+"/"+f.Resource] = f
+	}
+	want := map[string]string{
+		"cluster-health/prod":               severityWarning,
+		"shards-per-node/n1":                severityError,
+		"shard-size-oversized/orders":       severityWarning,
+		"shard-size-undersized/logs":        severityInfo,
+		"zero-replicas/orders":              severityWarning,
+		"transient-settings/transient":      severityWarning,
+		"heap-pressure/n1":                  severityError,
+		"data-stream-lifecycle/metrics-app": severityWarning,
+		"unused-template/old":               severityInfo,
+	}
+	for key, severity := range want {
+		f, ok := got[key]
+		if !ok {
+			t.Errorf("Expected finding %s", key)
+			continue
+		}
+		if f.Severity != severity {
+			t.Errorf("Expected %s severity %s, got %s", key, severity, f.Severity)
+		}
+		if f.Remediation == "" {
+			t.Errorf("Expected a remediation for %s", key)
+		}
+	}
+	if len(findings) != len(want) {
+		t.Errorf("Expected %d findings, got %d: %+v", len(want), len(findings), findings)
+	}
+	if findings[0].Severity != severityError {
+		t.Errorf("Expected errors first, got %+v", findings[0])
+	}
+	if f := got["zero-replicas/orders"]; f.Remediation != "searchctl set settings orders number_of_replicas=1" {
+		t.Errorf("Unexpected zero-replicas remediation %q", f.Remediation)
+	}
+}
+
+func TestDiskRules(t *testing.T) {
+	d := testClusterData()
+	// Below the cluster average, n2 alone is past the flood stage watermark
+	d.allocation = []types.CatAllocationRow{
+		{Node: "n1", DiskUsed: "50", DiskTotal: "100"},
+		{Node: "n2", DiskUsed: "96", DiskTotal: "100"},
+		{Node: "n3", DiskUsed: "86", DiskTotal: "100"},
+		{Node: "UNASSIGNED", Shards: "1"},
+	}
+	d.shards = []types.CatShardRow{
+		{Index: "a", Shard: "0", PrimaryOrReplica: "p", Store: "40gb", Node: "n1"},
+		{Index: "a", Shard: "1", PrimaryOrReplica: "p", Store: "5gb", Node: "n2"},
+	}
+	f := checkDiskUsage(d, limits{})
+	if len(f) != 2 || f[0].Resource != "n2" || f[0].Severity != severityError || f[1].Resource != "n3" || f[1].Severity != severityWarning {
+		t.Errorf("Expected n2 above flood stage and n3 above low, got %+v", f)
+	}
+	if !strings.Contains(f[0].Message, "flood-stage watermark 95%") {
+		t.Errorf("Unexpected message %q", f[0].Message)
+	}
+	if f := checkDiskImbalance(d, limits{}); len(f) != 1 || f[0].Resource != "n1" {
+		t.Errorf("Expected a disk imbalance on n1, got %+v", f)
+	}
+}
+
+func TestFailed(t *testing.T) {
+	findings := []finding{{Severity: severityWarning}}
+	if failed(findings, severityError) {
+		t.Error("Expected warnings not to fail on error")
+	}
+	if !failed(findings, severityWarning) || !failed(findings, severityInfo) {
+		t.Error("Expected warnings to fail on warning and info")
+	}
+	if failed(findings, "none") {
+		t.Error("Expected nothing to fail on none")
+	}
+}
+
+func TestWriteSARIF(t *testing.T) {
+	var buf bytes.Buffer
+	findings := []finding{{Rule: "unused-template", Severity: severityInfo, Resource: "old", Message: "unused"}}
+	if err := writeSARIF(&buf, findings); err != nil {
+		t.Fatal(err)
+	}
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Name  string        `json:"name"`
+					Rules []interface{} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID string `json:"ruleId"`
+				Level  string `json:"level"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatal(err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 || log.Runs[0].Tool.Driver.Name != "searchctl" {
+		t.Fatalf("Unexpected SARIF log:\n%s", buf.String())
+	}
+	if len(log.Runs[0].Tool.Driver.Rules) != len(rules) {
+		t.Errorf("Expected %d rules, got %d", len(rules), len(log.Runs[0].Tool.Driver.Rules))
+	}
+	if r := log.Runs[0].Results; len(r) != 1 || r[0].RuleID != "unused-template" || r[0].Level != "note" {
+		t.Errorf("Unexpected results %+v", r)
+	}
+}
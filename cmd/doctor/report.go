@@ -0,0 +1,192 @@
+package doctor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/output"
+	"github.com/spf13/viper"
+)
+
+const sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+// collect fetches what the rules need. Health, settings, nodes, indices and shards
+// are required; the rest is skipped with a warning when the cluster lacks the API.
+func collect(c client.SearchClient) (*clusterData, error) {
+	var d clusterData
+	var err error
+	if d.health, err = c.ClusterHealth(); err != nil {
+		return nil, fmt.Errorf("error getting cluster health: %v", err)
+	}
+	if d.settings, err = c.GetClusterSettings(); err != nil {
+		return nil, fmt.Errorf("error getting cluster settings: %v", err)
+	}
+	if d.nodes, err = c.GetNodes(); err != nil {
+		return nil, fmt.Errorf("error getting nodes: %v", err)
+	}
+	if d.indices, err = c.GetIndices(""); err != nil {
+		return nil, fmt.Errorf("error getting indices: %v", err)
+	}
+	if d.shards, err = c.GetShards(""); err != nil {
+		return nil, fmt.Errorf("error getting shards: %v", err)
+	}
+
+	if d.allocation, err = c.GetAllocation(""); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: skipping disk usage check: %v\n", err)
+	}
+	// Templates are only checked against data streams that could be listed
+	if d.dataStreams, err = c.GetDataStreams("*"); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: skipping data stream and index template checks: %v\n", err)
+	} else if d.templates, err = c.GetIndexTemplates(""); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: skipping index template checks: %v\n", err)
+	}
+	// Only needed to find data streams managed by OpenSearch ISM policy templates
+	d.policies, _ = c.GetLifecyclePolicies("")
+	return &d, nil
+}
+
+// runRules runs every rule not skipped and returns the findings ordered by severity
+func runRules(d *clusterData, l limits, skip map[string]bool) []finding {
+	var findings []finding
+	for _, r := range rules {
+		if skip[r.id] {
+			continue
+		}
+		for _, f := range r.check(d, l) {
+			f.Rule = r.id
+			if f.Remediation == "" {
+				f.Remediation = r.remediation
+			}
+			findings = append(findings, f)
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		return severityRank[findings[i].Severity] > severityRank[findings[j].Severity]
+	})
+	return findings
+}
+
+// failed reports whether a finding is at or above the --fail-on severity
+func failed(findings []finding, failOn string) bool {
+	threshold, ok := severityRank[failOn]
+	if !ok {
+		return false
+	}
+	for _, f := range findings {
+		if severityRank[f.Severity] >= threshold {
+			return true
+		}
+	}
+	return false
+}
+
+func printFindings(w io.Writer, findings []finding) error {
+	outFmt := viper.GetString("output")
+	switch outFmt {
+	case "sarif":
+		return writeSARIF(w, findings)
+	case "json", "yaml":
+		if findings == nil {
+			findings = []finding{}
+		}
+		return output.NewFormatter(outFmt).Format(findings, w)
+	}
+	if len(findings) == 0 {
+		fmt.Fprintln(os.Stderr, "No problems found")
+		return nil
+	}
+
+	if err := output.NewFormatter(outFmt).Format(findingRows(findings), w); err != nil {
+		return err
+	}
+	// Remediations are too long for the table; print each once below it
+	fmt.Fprintln(w)
+	seen := make(map[string]bool)
+	for _, f := range findings {
+		key := f.Rule + "\x00" + f.Remediation
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		fmt.Fprintf(w, "%s: %s\n", f.Rule, f.Remediation)
+	}
+
+	counts := make(map[string]int)
+	for _, f := range findings {
+		counts[f.Severity]++
+	}
+	fmt.Fprintf(os.Stderr, "%d finding(s): %d error(s), %d warning(s), %d info\n",
+		len(findings), counts[severityError], counts[severityWarning], counts[severityInfo])
+	return nil
+}
+
+func findingRows(findings []finding) []interface{} {
+	rows := make([]interface{}, len(findings))
+	for i, f := range findings {
+		rows[i] = map[string]interface{}{
+			"__columns": "SEVERITY,RULE,RESOURCE,MESSAGE",
+			"SEVERITY":  strings.ToUpper(f.Severity),
+			"RULE":      f.Rule,
+			"RESOURCE":  f.Resource,
+			"MESSAGE":   oneLine(f.Message, maxDetail),
+		}
+	}
+	return rows
+}
+
+// sarifLevel maps a severity to a SARIF result level
+func sarifLevel(severity string) string {
+	if severity == severityInfo {
+		return "note"
+	}
+	return severity
+}
+
+// writeSARIF writes the findings as a SARIF 2.1.0 log, for code scanning dashboards
+func writeSARIF(w io.Writer, findings []finding) error {
+	driverRules := make([]map[string]interface{}, len(rules))
+	for i, r := range rules {
+		driverRules[i] = map[string]interface{}{
+			"id":                   r.id,
+			"shortDescription":     map[string]string{"text": r.description},
+			"help":                 map[string]string{"text": r.remediation},
+			"defaultConfiguration": map[string]string{"level": sarifLevel(r.severity)},
+		}
+	}
+
+	results := make([]map[string]interface{}, len(findings))
+	for i, f := range findings {
+		results[i] = map[string]interface{}{
+			"ruleId":  f.Rule,
+			"level":   sarifLevel(f.Severity),
+			"message": map[string]string{"text": f.Message + " " + f.Remediation},
+			"locations": []map[string]interface{}{{
+				"logicalLocations": []map[string]string{{"name": f.Resource}},
+			}},
+		}
+	}
+
+	sarifLog := map[string]interface{}{
+		"$schema": sarifSchema,
+		"version": "2.1.0",
+		"runs": []map[string]interface{}{{
+			"tool": map[string]interface{}{
+				"driver": map[string]interface{}{
+					"name":           "searchctl",
+					"informationUri": "https://github.com/chronicblondiee/searchctl",
+					"rules":          driverRules,
+				},
+			},
+			"results": results,
+		}},
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog)
+}
@@ -0,0 +1,488 @@
+package doctor
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/chronicblondiee/searchctl/pkg/disk"
+	"github.com/chronicblondiee/searchctl/pkg/output"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+)
+
+const (
+	severityError   = "error"
+	severityWarning = "warning"
+	severityInfo    = "info"
+)
+
+var severityRank = map[string]int{severityInfo: 1, severityWarning: 2, severityError: 3}
+
+// finding is a problem reported by a rule for one resource
+type finding struct {
+	Rule        string `json:"rule"`
+	Severity    string `json:"severity"`
+	Resource    string `json:"resource"`
+	Message     string `json:"message"`
+	Remediation string `json:"remediation"`
+}
+
+// clusterData is what the rules check. Optional parts are nil when the cluster
+// does not support them.
+type clusterData struct {
+	health      *types.ClusterHealth
+	allocation  []types.CatAllocationRow
+	settings    *types.ClusterSettings
+	nodes       []types.Node
+	indices     []types.Index
+	shards      []types.CatShardRow
+	dataStreams []types.DataStream
+	templates   []types.IndexTemplate
+	policies    []types.LifecyclePolicy
+}
+
+// limits are the thresholds of the size rules
+type limits struct {
+	maxShardSize int64
+	minShardSize int64
+}
+
+// rule is a check in the doctor catalogue
+type rule struct {
+	id          string
+	severity    string
+	description string
+	remediation string
+	check       func(d *clusterData, l limits) []finding
+}
+
+var rules = []rule{
+	{
+		id:          "cluster-health",
+		severity:    severityError,
+		description: "The cluster is green",
+		remediation: "Run 'searchctl doctor shards' to find out why shards are not assigned.",
+		check:       checkHealth,
+	},
+	{
+		id:          "shards-per-node",
+		severity:    severityError,
+		description: "Nodes stay below cluster.max_shards_per_node",
+		remediation: "Delete or shrink small indices, use fewer primary shards, or add data nodes. Raising cluster.max_shards_per_node only hides the problem.",
+		check:       checkShardsPerNode,
+	},
+	{
+		id:          "shard-size-oversized",
+		severity:    severityWarning,
+		description: "Primary shards are not larger than the maximum shard size",
+		remediation: "Roll over earlier with max_primary_shard_size, or split the index with 'searchctl split'.",
+		check:       checkOversizedShards,
+	},
+	{
+		id:          "shard-size-undersized",
+		severity:    severityInfo,
+		description: "Indices with several primary shards are not made of tiny shards",
+		remediation: "Shrink the index with 'searchctl shrink', or use fewer primary shards in its template.",
+		check:       checkUndersizedShards,
+	},
+	{
+		id:          "zero-replicas",
+		severity:    severityWarning,
+		description: "Indices have replicas when the cluster has more than one data node",
+		remediation: "Add a replica, for example 'searchctl set settings INDEX number_of_replicas=1'.",
+		check:       checkZeroReplicas,
+	},
+	{
+		id:          "transient-settings",
+		severity:    severityWarning,
+		description: "No transient cluster settings are in use",
+		remediation: "Transient settings are deprecated and lost on a full cluster restart. Set them as persistent settings and reset the transient ones to null.",
+		check:       checkTransientSettings,
+	},
+	{
+		id:          "disk-usage",
+		severity:    severityError,
+		description: "Nodes stay below the disk watermarks",
+		remediation: "Delete old indices, add data nodes or disk, or lower replicas of cold indices before the flood stage watermark makes indices read-only.",
+		check:       checkDiskUsage,
+	},
+	{
+		id:          "disk-imbalance",
+		severity:    severityWarning,
+		description: "Shard data is spread evenly across data nodes",
+		remediation: "Check allocation filters and awareness, and cluster.routing.allocation.balance.* settings; move shards with 'searchctl cluster reroute move'.",
+		check:       checkDiskImbalance,
+	},
+	{
+		id:          "heap-pressure",
+		severity:    severityError,
+		description: "Node heap usage stays below 75%",
+		remediation: "Reduce the shard count, fielddata and heavy aggregations, or give the node more memory (heap at most half of RAM and below 31gb).",
+		check:       checkHeapPressure,
+	},
+	{
+		id:          "data-stream-lifecycle",
+		severity:    severityWarning,
+		description: "Data streams are managed by a lifecycle policy",
+		remediation: "Set index.lifecycle.name in the data stream's index template, or add an ISM policy whose ism_template matches it.",
+		check:       checkDataStreamLifecycle,
+	},
+	{
+		id:          "unused-template",
+		severity:    severityInfo,
+		description: "Index templates match existing indices or data streams",
+		remediation: "Delete the template with 'searchctl delete index-template NAME' if it is no longer needed, or fix its index_patterns.",
+		check:       checkUnusedTemplates,
+	},
+}
+
+func ruleByID(id string) (rule, bool) {
+	for _, r := range rules {
+		if r.id == id {
+			return r, true
+		}
+	}
+	return rule{}, false
+}
+
+func checkHealth(d *clusterData, l limits) []finding {
+	switch d.health.Status {
+	case "red":
+		return []finding{{Severity: severityError, Resource: d.health.ClusterName,
+			Message: fmt.Sprintf("cluster is red: %d unassigned shard(s), some primaries are missing", d.health.UnassignedShards)}}
+	case "yellow":
+		return []finding{{Severity: severityWarning, Resource: d.health.ClusterName,
+			Message: fmt.Sprintf("cluster is yellow: %d unassigned replica shard(s)", d.health.UnassignedShards)}}
+	}
+	return nil
+}
+
+func checkShardsPerNode(d *clusterData, l limits) []finding {
+	limit := 1000
+	if v, _ := d.settings.Value("cluster.max_shards_per_node"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			limit = n
+		}
+	}
+
+	var findings []finding
+	total := 0
+	perNode := make(map[string]int)
+	for _, s := range d.shards {
+		total++
+		if fields := strings.Fields(s.Node); len(fields) > 0 {
+			perNode[fields[0]]++
+		}
+	}
+	for _, node := range sortedKeys(perNode) {
+		count := perNode[node]
+		switch {
+		case count >= limit:
+			findings = append(findings, finding{Severity: severityError, Resource: node,
+				Message: fmt.Sprintf("node holds %d shards, at or over the limit of %d", count, limit)})
+		case count*10 >= limit*8:
+			findings = append(findings, finding{Severity: severityWarning, Resource: node,
+				Message: fmt.Sprintf("node holds %d shards, over 80%% of the limit of %d", count, limit)})
+		}
+	}
+	if capacity := limit * d.health.NumberOfDataNodes; capacity > 0 && total >= capacity {
+		findings = append(findings, finding{Severity: severityError, Resource: d.health.ClusterName,
+			Message: fmt.Sprintf("cluster holds %d shards, at or over its capacity of %d; new indices cannot be created", total, capacity)})
+	}
+	return findings
+}
+
+func checkOversizedShards(d *clusterData, l limits) []finding {
+	type oversized struct {
+		count   int
+		largest int64
+	}
+	byIndex := make(map[string]*oversized)
+	for _, s := range d.shards {
+		if s.PrimaryOrReplica != "p" || s.Store == "" {
+			continue
+		}
+		size, err := output.ParseBytes(s.Store)
+		if err != nil || size <= l.maxShardSize {
+			continue
+		}
+		o, ok := byIndex[s.Index]
+		if !ok {
+			o = &oversized{}
+			byIndex[s.Index] = o
+		}
+		o.count++
+		if size > o.largest {
+			o.largest = size
+		}
+	}
+
+	var findings []finding
+	for _, index := range sortedKeys(byIndex) {
+		o := byIndex[index]
+		findings = append(findings, finding{Severity: severityWarning, Resource: index,
+			Message: fmt.Sprintf("%d primary shard(s) larger than %s, the largest is %s",
+				o.count, output.FormatBytes(l.maxShardSize), output.FormatBytes(o.largest))})
+	}
+	return findings
+}
+
+func checkUndersizedShards(d *clusterData, l limits) []finding {
+	var findings []finding
+	for _, idx := range d.indices {
+		primaries, _ := strconv.Atoi(idx.Primary)
+		if primaries < 2 || isSystem(idx.Name) || idx.Status == "close" {
+			continue
+		}
+		size, err := output.ParseBytes(idx.PrimaryStoreSize)
+		if err != nil {
+			continue
+		}
+		if avg := size / int64(primaries); avg < l.minShardSize {
+			findings = append(findings, finding{Severity: severityInfo, Resource: idx.Name,
+				Message: fmt.Sprintf("%d primary shards of %s on average, below %s",
+					primaries, output.FormatBytes(avg), output.FormatBytes(l.minShardSize))})
+		}
+	}
+	return findings
+}
+
+func checkZeroReplicas(d *clusterData, l limits) []finding {
+	if d.health.NumberOfDataNodes < 2 {
+		return nil
+	}
+	var findings []finding
+	for _, idx := range d.indices {
+		if idx.Replica != "0" || isSystem(idx.Name) || idx.Status == "close" {
+			continue
+		}
+		findings = append(findings, finding{Severity: severityWarning, Resource: idx.Name,
+			Message:     "index has no replicas; losing a node loses data",
+			Remediation: fmt.Sprintf("searchctl set settings %s number_of_replicas=1", idx.Name)})
+	}
+	return findings
+}
+
+func checkTransientSettings(d *clusterData, l limits) []finding {
+	keys := flattenKeys("", d.settings.Transient)
+	if len(keys) == 0 {
+		return nil
+	}
+	return []finding{{Severity: severityWarning, Resource: "transient",
+		Message: fmt.Sprintf("%d transient setting(s) in use: %s", len(keys), strings.Join(keys, ", "))}}
+}
+
+// checkDiskUsage reports each node above a disk watermark. The low watermark only
+// stops new shards, so it is a warning; above high, shards move away.
+func checkDiskUsage(d *clusterData, l limits) []finding {
+	enabled, marks := disk.Watermarks(d.settings)
+	if !enabled {
+		return nil
+	}
+	var findings []finding
+	for _, r := range d.allocation {
+		used, _ := strconv.ParseInt(r.DiskUsed, 10, 64)
+		total, _ := strconv.ParseInt(r.DiskTotal, 10, 64)
+		if r.Node == "UNASSIGNED" || total <= 0 {
+			continue
+		}
+		var crossed *disk.Watermark
+		for i, w := range marks {
+			if limit, err := w.Threshold(total); err == nil && used > limit {
+				crossed = &marks[i]
+			}
+		}
+		if crossed == nil {
+			continue
+		}
+		severity := severityError
+		if crossed.Level == disk.Low {
+			severity = severityWarning
+		}
+		findings = append(findings, finding{Severity: severity, Resource: r.Node,
+			Message: fmt.Sprintf("%.0f%% of %s disk used, above the %s watermark %s",
+				float64(used)/float64(total)*100, output.FormatBytes(total), crossed.Level, crossed.Value)})
+	}
+	return findings
+}
+
+// checkDiskImbalance compares the shard data held by each data node
+func checkDiskImbalance(d *clusterData, l limits) []finding {
+	perNode := make(map[string]int64)
+	for _, n := range d.nodes {
+		// d, h, w, c, f and s are the data roles, including the data tiers
+		if strings.ContainsAny(n.NodeRole, "dhwcfs") || n.NodeRole == "" {
+			perNode[n.Name] = 0
+		}
+	}
+	for _, s := range d.shards {
+		fields := strings.Fields(s.Node)
+		if len(fields) == 0 {
+			continue
+		}
+		if _, ok := perNode[fields[0]]; !ok {
+			continue
+		}
+		size, err := output.ParseBytes(s.Store)
+		if err == nil {
+			perNode[fields[0]] += size
+		}
+	}
+	if len(perNode) < 2 {
+		return nil
+	}
+
+	var sum, max int64
+	min := int64(-1)
+	var maxNode, minNode string
+	for _, node := range sortedKeys(perNode) {
+		size := perNode[node]
+		sum += size
+		if size > max {
+			max, maxNode = size, node
+		}
+		if min < 0 || size < min {
+			min, minNode = size, node
+		}
+	}
+	avg := sum / int64(len(perNode))
+	// Ignore small clusters where a single shard skews the numbers
+	if max < 1<<30 || avg == 0 || float64(max-min)/float64(avg) < 0.5 {
+		return nil
+	}
+	return []finding{{Severity: severityWarning, Resource: maxNode,
+		Message: fmt.Sprintf("node holds %s of shard data while %s holds %s (average %s)",
+			output.FormatBytes(max), minNode, output.FormatBytes(min), output.FormatBytes(avg))}}
+}
+
+func checkHeapPressure(d *clusterData, l limits) []finding {
+	var findings []finding
+	for _, n := range d.nodes {
+		heap, err := strconv.Atoi(n.HeapPercent)
+		if err != nil {
+			continue
+		}
+		switch {
+		case heap >= 90:
+			findings = append(findings, finding{Severity: severityError, Resource: n.Name,
+				Message: fmt.Sprintf("heap is %d%% used", heap)})
+		case heap >= 75:
+			findings = append(findings, finding{Severity: severityWarning, Resource: n.Name,
+				Message: fmt.Sprintf("heap is %d%% used", heap)})
+		}
+	}
+	return findings
+}
+
+func checkDataStreamLifecycle(d *clusterData, l limits) []finding {
+	var findings []finding
+	for _, ds := range d.dataStreams {
+		if ds.Hidden || ds.System || isSystem(ds.Name) || ds.IlmPolicy != "" || ds.Lifecycle != nil {
+			continue
+		}
+		if ismManaged(d.policies, ds) {
+			continue
+		}
+		findings = append(findings, finding{Severity: severityWarning, Resource: ds.Name,
+			Message: "data stream has no lifecycle policy; its backing indices are never rolled over or deleted"})
+	}
+	return findings
+}
+
+// ismManaged reports whether an OpenSearch ISM policy template matches the data stream
+func ismManaged(policies []types.LifecyclePolicy, ds types.DataStream) bool {
+	names := []string{ds.Name}
+	for _, idx := range ds.Indices {
+		names = append(names, idx.IndexName)
+	}
+	for _, p := range policies {
+		var templates []interface{}
+		switch t := p.Policy["ism_template"].(type) {
+		case []interface{}:
+			templates = t
+		case map[string]interface{}:
+			templates = []interface{}{t}
+		}
+		for _, t := range templates {
+			tm, _ := t.(map[string]interface{})
+			patterns, _ := tm["index_patterns"].([]interface{})
+			for _, pattern := range patterns {
+				ps, _ := pattern.(string)
+				for _, name := range names {
+					if ok, _ := path.Match(ps, name); ok {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+func checkUnusedTemplates(d *clusterData, l limits) []finding {
+	var names []string
+	for _, idx := range d.indices {
+		names = append(names, idx.Name)
+	}
+	for _, ds := range d.dataStreams {
+		names = append(names, ds.Name)
+	}
+
+	var findings []finding
+	for _, t := range d.templates {
+		// Built-in templates are managed by the cluster
+		if managed, _ := t.Meta["managed"].(bool); managed || isSystem(t.Name) {
+			continue
+		}
+		if matchesAny(t.IndexPattern, names) {
+			continue
+		}
+		findings = append(findings, finding{Severity: severityInfo, Resource: t.Name,
+			Message: fmt.Sprintf("index patterns %s match no index or data stream", strings.Join(t.IndexPattern, ", "))})
+	}
+	return findings
+}
+
+func matchesAny(patterns, names []string) bool {
+	for _, p := range patterns {
+		for _, name := range names {
+			if ok, _ := path.Match(p, name); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isSystem(name string) bool {
+	return strings.HasPrefix(name, ".")
+}
+
+// flattenKeys returns the dotted keys of nested settings
+func flattenKeys(prefix string, m map[string]interface{}) []string {
+	var keys []string
+	for k, v := range m {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		if sub, ok := v.(map[string]interface{}); ok {
+			keys = append(keys, flattenKeys(key, sub)...)
+		} else {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,270 @@
+package doctor
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/output"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const maxDetail = 100
+
+type shardsOptions struct {
+	index             string
+	initializingAfter time.Duration
+	limit             int
+}
+
+// problem is an unassigned or slow initializing shard and its diagnosis
+type problem struct {
+	Index   string `json:"index"`
+	Shard   string `json:"shard"`
+	Prirep  string `json:"prirep"`
+	State   string `json:"state"`
+	Copies  int    `json:"copies"`
+	Reason  string `json:"unassigned_reason,omitempty"`
+	Cause   string `json:"cause"`
+	Detail  string `json:"detail,omitempty"`
+	Node    string `json:"node,omitempty"`
+	Command string `json:"command,omitempty"`
+}
+
+func NewDoctorShardsCmd() *cobra.Command {
+	var opts shardsOptions
+
+	cmd := &cobra.Command{
+		Use:   "shards",
+		Short: "Explain unassigned and slow initializing shards",
+		Long: `Find unassigned shards and shards that have been initializing for too long, explain
+why with the cluster allocation explain API and group them by root cause:
+disk watermarks, allocation awareness, allocation filters, exhausted retries,
+missing valid copies and more. Every group comes with a remediation hint and,
+where possible, the command to run.
+
+Replica copies of the same shard are explained once. The command exits with
+status 1 when problems are found.`,
+		Example: strings.TrimSpace(`
+# Diagnose all shards
+searchctl doctor shards
+
+# Only logs indices, treating recoveries over 30 minutes as slow
+searchctl doctor shards --index 'logs-*' --initializing-after 30m
+
+# Machine-readable diagnosis
+searchctl doctor shards -o json`),
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			problems, err := diagnoseShards(c, opts)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error diagnosing shards: %v\n", err)
+				os.Exit(1)
+			}
+			if err := printProblems(os.Stdout, problems); err != nil {
+				fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+				os.Exit(1)
+			}
+			if len(problems) > 0 {
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.index, "index", "", "index pattern to check (default all indices)")
+	cmd.Flags().DurationVar(&opts.initializingAfter, "initializing-after", 10*time.Minute, "report shards initializing for longer than this")
+	cmd.Flags().IntVar(&opts.limit, "limit", 100, "maximum number of shards to explain")
+
+	return cmd
+}
+
+func diagnoseShards(c client.SearchClient, opts shardsOptions) ([]problem, error) {
+	shards, err := c.GetShards(opts.index)
+	if err != nil {
+		return nil, err
+	}
+
+	problems := unassignedShards(shards)
+	explained := 0
+	for i := range problems {
+		if opts.limit > 0 && explained >= opts.limit {
+			fmt.Fprintf(os.Stderr, "Warning: %d more unassigned shard(s) not explained; raise --limit\n", len(problems)-explained)
+			problems = problems[:explained]
+			break
+		}
+		p := &problems[i]
+		shard, _ := strconv.Atoi(p.Shard)
+		req := types.AllocationExplainRequest{Index: p.Index, Shard: shard, Primary: p.Prirep == "p"}
+		resp, err := c.ExplainAllocation(req, false, false)
+		explained++
+		if err != nil {
+			// The shard may have been assigned in the meantime
+			p.Cause, p.Detail = "unknown", err.Error()
+		} else {
+			p.Cause, p.Detail, p.Node = classify(resp)
+		}
+		p.Command = shardCommand(*p)
+	}
+
+	if opts.initializingAfter > 0 {
+		recoveries, err := c.GetRecovery(opts.index, true)
+		if err != nil {
+			return nil, err
+		}
+		problems = append(problems, slowRecoveries(shards, recoveries, opts.initializingAfter)...)
+	}
+	return problems, nil
+}
+
+// unassignedShards returns one problem per unassigned primary, and per shard for
+// unassigned replicas, counting the copies
+func unassignedShards(shards []types.CatShardRow) []problem {
+	var problems []problem
+	seen := make(map[string]int)
+	for _, s := range shards {
+		if s.State != "UNASSIGNED" {
+			continue
+		}
+		key := s.Index + "/" + s.Shard + "/" + s.PrimaryOrReplica
+		if i, ok := seen[key]; ok {
+			problems[i].Copies++
+			continue
+		}
+		seen[key] = len(problems)
+		problems = append(problems, problem{
+			Index:  s.Index,
+			Shard:  s.Shard,
+			Prirep: s.PrimaryOrReplica,
+			State:  s.State,
+			Copies: 1,
+			Reason: s.UnassignedReason,
+		})
+	}
+	return problems
+}
+
+// slowRecoveries returns initializing shards whose active recovery runs longer than
+// the threshold
+func slowRecoveries(shards []types.CatShardRow, recoveries []types.CatRecoveryRow, threshold time.Duration) []problem {
+	var problems []problem
+	for _, s := range shards {
+		if s.State != "INITIALIZING" {
+			continue
+		}
+		for _, r := range recoveries {
+			if r.Index != s.Index || r.Shard != s.Shard || r.TargetNode != s.Node || strings.EqualFold(r.Stage, "done") {
+				continue
+			}
+			elapsed, err := output.ParseDuration(r.Time)
+			if err != nil || elapsed < threshold {
+				continue
+			}
+			detail := fmt.Sprintf("%s recovery in stage %s for %s, %s of bytes done", strings.ToLower(r.Type), strings.ToLower(r.Stage), r.Time, r.BytesPercent)
+			if r.SourceNode != "" && r.SourceNode != "n/a" {
+				detail += " from " + r.SourceNode
+			}
+			problems = append(problems, problem{
+				Index:  s.Index,
+				Shard:  s.Shard,
+				Prirep: s.PrimaryOrReplica,
+				State:  s.State,
+				Copies: 1,
+				Cause:  "slow-recovery",
+				Detail: detail,
+				Node:   s.Node,
+			})
+		}
+	}
+	return problems
+}
+
+func printProblems(w io.Writer, problems []problem) error {
+	outFmt := viper.GetString("output")
+	if outFmt == "json" || outFmt == "yaml" {
+		if problems == nil {
+			problems = []problem{}
+		}
+		return output.NewFormatter(outFmt).Format(problems, w)
+	}
+	if len(problems) == 0 {
+		fmt.Fprintln(os.Stderr, "No unassigned or slow initializing shards found")
+		return nil
+	}
+
+	formatter := output.NewFormatter(outFmt)
+	groups := 0
+	for _, c := range causes {
+		var group []problem
+		copies := 0
+		for _, p := range problems {
+			if p.Cause == c.id {
+				group = append(group, p)
+				copies += p.Copies
+			}
+		}
+		if len(group) == 0 {
+			continue
+		}
+		if groups > 0 {
+			fmt.Fprintln(w)
+		}
+		groups++
+
+		fmt.Fprintf(w, "%s: %d shard(s)\n", c.title, copies)
+		if err := formatter.Format(problemRows(group), w); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "Hint: %s\n", strings.ReplaceAll(c.hint, "\n", "\n      "))
+		for _, command := range remediations(c, group) {
+			fmt.Fprintf(w, "  %s\n", command)
+		}
+	}
+	fmt.Fprintf(os.Stderr, "%d shard problem(s) in %d group(s)\n", len(problems), groups)
+	return nil
+}
+
+func problemRows(group []problem) []interface{} {
+	rows := make([]interface{}, len(group))
+	for i, p := range group {
+		rows[i] = map[string]interface{}{
+			"__columns": "INDEX,SHARD,PRIREP,STATE,COPIES,REASON,DETAIL",
+			"INDEX":     p.Index,
+			"SHARD":     p.Shard,
+			"PRIREP":    p.Prirep,
+			"STATE":     p.State,
+			"COPIES":    p.Copies,
+			"REASON":    p.Reason,
+			"DETAIL":    oneLine(p.Detail, maxDetail),
+		}
+	}
+	return rows
+}
+
+// remediations returns the group command followed by the distinct shard commands
+func remediations(c cause, group []problem) []string {
+	var commands []string
+	seen := make(map[string]bool)
+	if c.command != "" {
+		commands = append(commands, c.command)
+		seen[c.command] = true
+	}
+	for _, p := range group {
+		if p.Command != "" && !seen[p.Command] {
+			commands = append(commands, p.Command)
+			seen[p.Command] = true
+		}
+	}
+	return commands
+}
@@ -0,0 +1,116 @@
+package dump
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/chronicblondiee/searchctl/pkg/types"
+)
+
+const (
+	modePIT    = "pit"
+	modeScroll = "scroll"
+)
+
+// checkpoint records how far every slice has got and how many bytes of output belong to
+// completed pages, so an interrupted dump can truncate the output and carry on
+type checkpoint struct {
+	Index    string                 `json:"index"`
+	Query    map[string]interface{} `json:"query"`
+	Fields   []string               `json:"fields,omitempty"`
+	Sort     []interface{}          `json:"sort,omitempty"`
+	Gzip     bool                   `json:"gzip"`
+	WithMeta bool                   `json:"with_meta"`
+	Mode     string                 `json:"mode"`
+	PIT      *types.PointInTime     `json:"pit,omitempty"`
+	Offset   int64                  `json:"offset"`
+	Slices   []*sliceState          `json:"slices"`
+	// Stopped is set when the dump was interrupted cleanly, with no request in flight
+	Stopped bool `json:"stopped,omitempty"`
+}
+
+type sliceState struct {
+	ID          int           `json:"id"`
+	SearchAfter []interface{} `json:"search_after,omitempty"`
+	ScrollID    string        `json:"scroll_id,omitempty"`
+	Docs        int64         `json:"docs"`
+	Total       int64         `json:"total"`
+	Done        bool          `json:"done"`
+}
+
+// loadCheckpoint returns nil when the file does not exist. Numbers are kept as json.Number
+// so search_after values such as _shard_doc positions are sent back exactly.
+func loadCheckpoint(path string) (*checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var cp checkpoint
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&cp); err != nil {
+		return nil, err
+	}
+	return &cp, nil
+}
+
+// save writes the checkpoint to a temporary file and renames it, so a crash never leaves
+// a partial checkpoint behind
+func (c *checkpoint) save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// sameDump reports whether two checkpoints describe the same export
+func (c *checkpoint) sameDump(other *checkpoint) bool {
+	identity := func(cp *checkpoint) []byte {
+		data, _ := json.Marshal(struct {
+			Index    string                 `json:"index"`
+			Query    map[string]interface{} `json:"query"`
+			Fields   []string               `json:"fields,omitempty"`
+			Sort     []interface{}          `json:"sort,omitempty"`
+			Gzip     bool                   `json:"gzip"`
+			WithMeta bool                   `json:"with_meta"`
+			Slices   int                    `json:"slices"`
+		}{cp.Index, cp.Query, cp.Fields, cp.Sort, cp.Gzip, cp.WithMeta, len(cp.Slices)})
+		return data
+	}
+	return bytes.Equal(identity(c), identity(other))
+}
+
+// resumable reports why the checkpoint cannot be resumed. A scroll moves on as soon as
+// a page is returned, so after a crash the saved scroll id may be a page behind the
+// server and resuming would skip that page; only a clean interrupt leaves them in step.
+func (c *checkpoint) resumable() error {
+	if c.Mode != modeScroll || c.Stopped {
+		return nil
+	}
+	for _, s := range c.Slices {
+		if !s.Done && s.ScrollID != "" {
+			return fmt.Errorf("the scroll may have moved past the last saved page because the dump did not stop cleanly")
+		}
+	}
+	return nil
+}
+
+func (c *checkpoint) complete() bool {
+	for _, s := range c.Slices {
+		if !s.Done {
+			return false
+		}
+	}
+	return true
+}
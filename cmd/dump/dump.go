@@ -0,0 +1,314 @@
+package dump
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/output"
+	"github.com/chronicblondiee/searchctl/pkg/query"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type options struct {
+	query      string
+	fields     []string
+	size       int
+	slices     int
+	keepAlive  string
+	sort       []string
+	out        string
+	gzip       bool
+	checkpoint string
+	withMeta   bool
+	interval   time.Duration
+}
+
+func NewDumpCmd() *cobra.Command {
+	var opts options
+
+	cmd := &cobra.Command{
+		Use:   "dump INDEX",
+		Short: "Export the documents of an index or data stream as NDJSON",
+		Long: `Export documents matching a query from INDEX as NDJSON, one _source per line.
+
+Documents are read page by page with a point in time and search_after, or with
+a scroll on clusters without point in time support and on OpenSearch when no
+--sort is given. Pages are streamed to the output as they arrive, so memory use
+does not grow with the size of the index. --slices splits the export into
+parallel sliced searches; documents from different slices are interleaved.
+
+Output ending in .gz, or any output with --gzip, is gzip compressed. Each page is
+written as its own gzip member, which gzip tools read as a single stream.
+
+With --checkpoint, progress is saved after every page. If the dump is interrupted,
+running the same command again truncates the output to the last completed page
+and continues from there; the checkpoint is removed once the dump completes.
+A point in time or scroll must still be alive to resume, so pick --keep-alive
+accordingly. When --sort is given, an expired point in time is replaced by a new
+one; _shard_doc and scroll positions cannot be carried over.
+
+A scroll dump can only be resumed after a clean interrupt (Ctrl-C or SIGTERM). A
+scroll moves on as soon as it returns a page, so after a crash or a failed request
+the checkpoint may be a page behind and the dump has to start over.`,
+		Example: strings.TrimSpace(`
+# Dump an index to a compressed file
+searchctl dump products --out products.ndjson.gz
+
+# Export selected fields of matching documents
+searchctl dump logs-app --query 'level:ERROR' --fields '@timestamp,message,host.name' --out errors.ndjson
+
+# Large export with 4 slices that can be resumed after an interruption
+searchctl dump events --slices 4 --size-per-page 5000 --out events.ndjson.gz --checkpoint events.ckpt
+
+# Keep index, id and routing to load the documents somewhere else
+searchctl dump orders --with-meta | gzip > orders.ndjson.gz`),
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			index := args[0]
+			if err := run(cmd, index, opts); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.query, "query", "q", "", "query string or JSON query DSL (default match_all)")
+	cmd.Flags().StringSliceVar(&opts.fields, "fields", nil, "source fields to export (default all)")
+	cmd.Flags().IntVar(&opts.size, "size-per-page", 1000, "documents fetched per request")
+	cmd.Flags().IntVar(&opts.slices, "slices", 1, "number of slices exported in parallel")
+	cmd.Flags().StringVar(&opts.keepAlive, "keep-alive", "5m", "how long the point in time or scroll is kept between pages")
+	cmd.Flags().StringSliceVar(&opts.sort, "sort", nil, "sort by FIELD[:asc|desc]; together the fields should be unique")
+	cmd.Flags().StringVar(&opts.out, "out", "", "output file (default stdout)")
+	cmd.Flags().BoolVar(&opts.gzip, "gzip", false, "gzip the output (implied by a .gz output file)")
+	cmd.Flags().StringVar(&opts.checkpoint, "checkpoint", "", "file used to save progress and resume an interrupted dump")
+	cmd.Flags().BoolVar(&opts.withMeta, "with-meta", false, "write _index, _id and _routing alongside _source")
+	cmd.Flags().DurationVar(&opts.interval, "interval", 2*time.Second, "progress reporting interval")
+
+	return cmd
+}
+
+func run(cmd *cobra.Command, index string, opts options) error {
+	if opts.size <= 0 || opts.slices <= 0 {
+		return fmt.Errorf("--size-per-page and --slices must be positive")
+	}
+	toFile := opts.out != "" && opts.out != "-"
+	if opts.checkpoint != "" && !toFile {
+		return fmt.Errorf("--checkpoint requires --out")
+	}
+	q, err := query.Parse(opts.query)
+	if err != nil {
+		return err
+	}
+	userSort, err := query.ParseSort(opts.sort)
+	if err != nil {
+		return err
+	}
+	dest := "stdout"
+	if toFile {
+		dest = opts.out
+	}
+
+	c, err := client.NewClient()
+	if err != nil {
+		return fmt.Errorf("error creating client: %v", err)
+	}
+
+	if viper.GetBool("dry-run") {
+		resp, err := c.Search(index, map[string]interface{}{"size": 0, "query": q, "track_total_hits": true}, types.SearchOptions{})
+		if err != nil {
+			return err
+		}
+		cmd.Printf("Would dump %d documents from %s to %s\n", resp.Hits.Total.Value, index, dest)
+		return nil
+	}
+
+	state := &checkpoint{
+		Index:    index,
+		Query:    q,
+		Fields:   opts.fields,
+		Sort:     userSort,
+		Gzip:     opts.gzip || strings.HasSuffix(opts.out, ".gz"),
+		WithMeta: opts.withMeta,
+	}
+	for i := 0; i < opts.slices; i++ {
+		state.Slices = append(state.Slices, &sliceState{ID: i})
+	}
+
+	resumed := false
+	if opts.checkpoint != "" {
+		saved, err := loadCheckpoint(opts.checkpoint)
+		if err != nil {
+			return fmt.Errorf("error reading checkpoint: %v", err)
+		}
+		if saved != nil {
+			if !saved.sameDump(state) {
+				return fmt.Errorf("checkpoint %s belongs to a different dump; remove it to start over", opts.checkpoint)
+			}
+			if err := saved.resumable(); err != nil {
+				return fmt.Errorf("cannot resume from checkpoint %s: %v; remove it to start over", opts.checkpoint, err)
+			}
+			state, resumed = saved, true
+		}
+	}
+
+	out, closeOut, err := openOutput(opts.out, resumed, state.Offset)
+	if err != nil {
+		return err
+	}
+	defer closeOut()
+
+	d := &dumper{
+		client:         c,
+		index:          index,
+		query:          q,
+		fields:         opts.fields,
+		userSort:       len(userSort) > 0,
+		size:           opts.size,
+		keepAlive:      opts.keepAlive,
+		withMeta:       opts.withMeta,
+		out:            out,
+		gzip:           state.Gzip,
+		state:          state,
+		checkpointPath: opts.checkpoint,
+	}
+	if resumed {
+		// Until the next clean interrupt, a crash leaves the checkpoint unsafe to resume
+		state.Stopped = false
+		if err := d.saveLocked(); err != nil {
+			return err
+		}
+		var done int64
+		for _, st := range state.Slices {
+			done += st.Docs
+			d.total.Add(st.Total)
+		}
+		d.docs.Store(done)
+		d.sort = effectiveSort(state.Mode, state.Sort)
+		cmd.Printf("Resuming dump of %s from %s: %d documents already written\n", index, opts.checkpoint, done)
+	} else if err := d.start(); err != nil {
+		return err
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	finished := make(chan struct{})
+	go func() {
+		select {
+		case <-signals:
+			d.stopped.Store(true)
+		case <-finished:
+		}
+	}()
+
+	start := time.Now()
+	stopProgress := output.StartProgress(cmd.OutOrStderr(), opts.interval, func() string {
+		return progressLine(d.docs.Load(), d.total.Load(), d.written.Load(), d.bytes.Load(), time.Since(start))
+	})
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(state.Slices))
+	for i, st := range state.Slices {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := d.runSlice(st); err != nil {
+				errs[i] = err
+				d.stopped.Store(true)
+			}
+		}()
+	}
+	wg.Wait()
+	close(finished)
+	stopProgress()
+
+	if state.complete() {
+		d.release()
+		if opts.checkpoint != "" {
+			if err := os.Remove(opts.checkpoint); err != nil {
+				fmt.Fprintf(os.Stderr, "Error removing checkpoint: %v\n", err)
+			}
+		}
+		printSummary(cmd, d, dest, time.Since(start))
+		return nil
+	}
+
+	err = errors.Join(errs...)
+	// Without a checkpoint nothing can resume, so the search contexts are released
+	if opts.checkpoint == "" {
+		d.release()
+	} else {
+		if err == nil {
+			state.Stopped = true
+			if saveErr := d.saveLocked(); saveErr != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", saveErr)
+			}
+		}
+		fmt.Fprintf(os.Stderr, "Progress saved to %s; run the same command again to resume\n", opts.checkpoint)
+	}
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("interrupted after %d documents", d.docs.Load())
+}
+
+// openOutput opens stdout or the output file. When resuming, anything written after the
+// last checkpointed page is cut off before appending.
+func openOutput(path string, resume bool, offset int64) (io.Writer, func(), error) {
+	if path == "" || path == "-" {
+		return os.Stdout, func() {}, nil
+	}
+	if !resume {
+		f, err := os.Create(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		return f, func() { f.Close() }, nil
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error opening output to resume: %v", err)
+	}
+	if err := f.Truncate(offset); err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+	return f, func() { f.Close() }, nil
+}
+
+func progressLine(docs, total, written, bytes int64, elapsed time.Duration) string {
+	secs := elapsed.Seconds()
+	if secs <= 0 {
+		secs = 1
+	}
+	progress := fmt.Sprintf("%d documents", docs)
+	if total > 0 {
+		progress = fmt.Sprintf("%d/%d documents (%.1f%%)", docs, total, float64(docs)*100/float64(total))
+	}
+	return fmt.Sprintf("%s | %.0f docs/s, %s/s", progress, float64(written)/secs, output.FormatBytes(int64(float64(bytes)/secs)))
+}
+
+func printSummary(cmd *cobra.Command, d *dumper, dest string, elapsed time.Duration) {
+	secs := elapsed.Seconds()
+	if secs <= 0 {
+		secs = 1
+	}
+	cmd.Printf("Dumped %d documents from %s to %s in %s (%.0f docs/s, %s written)\n",
+		d.docs.Load(), d.index, dest, elapsed.Round(time.Millisecond),
+		float64(d.written.Load())/secs, output.FormatBytes(d.bytes.Load()))
+}
@@ -0,0 +1,141 @@
+package dump
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/chronicblondiee/searchctl/pkg/types"
+)
+
+func TestNewDumpCmd(t *testing.T) {
+	cmd := NewDumpCmd()
+
+	if cmd.Use != "dump INDEX" {
+		t.Errorf("Expected Use 'dump INDEX', got %s", cmd.Use)
+	}
+	for _, name := range []string{"query", "fields", "size-per-page", "slices", "keep-alive", "sort", "out", "gzip", "checkpoint", "with-meta"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("Expected flag %s", name)
+		}
+	}
+}
+
+func TestEffectiveSort(t *testing.T) {
+	if got := effectiveSort(modePIT, nil); !reflect.DeepEqual(got, []interface{}{map[string]interface{}{"_shard_doc": "asc"}}) {
+		t.Errorf("Unexpected point in time sort %v", got)
+	}
+	if got := effectiveSort(modeScroll, nil); !reflect.DeepEqual(got, []interface{}{"_doc"}) {
+		t.Errorf("Unexpected scroll sort %v", got)
+	}
+	user := []interface{}{map[string]interface{}{"id": "asc"}}
+	if got := effectiveSort(modeScroll, user); !reflect.DeepEqual(got, user) {
+		t.Errorf("Expected user sort, got %v", got)
+	}
+}
+
+func TestRender(t *testing.T) {
+	hits := []types.SearchHit{
+		{Index: "orders", ID: "1", Routing: "r1", Source: json.RawMessage("{\n  \"a\": 1\n}")},
+		{Index: "orders", ID: "2"},
+	}
+
+	if got := string(render(hits, false)); got != "{\"a\":1}\n{}\n" {
+		t.Errorf("Unexpected output %q", got)
+	}
+
+	expected := "{\"_id\":\"1\",\"_index\":\"orders\",\"_routing\":\"r1\",\"_source\":{\"a\":1}}\n" +
+		"{\"_id\":\"2\",\"_index\":\"orders\",\"_source\":{}}\n"
+	if got := string(render(hits, true)); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
+
+func TestCommitGzipMembers(t *testing.T) {
+	var out bytes.Buffer
+	d := &dumper{out: &out, gzip: true, state: &checkpoint{}}
+
+	for _, page := range []string{"{\"a\":1}\n", "{\"a\":2}\n"} {
+		if err := d.commit([]byte(page), func() {}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if d.state.Offset != int64(out.Len()) {
+		t.Errorf("Expected offset %d, got %d", out.Len(), d.state.Offset)
+	}
+
+	zr, err := gzip.NewReader(&out)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(data) != "{\"a\":1}\n{\"a\":2}\n" {
+		t.Errorf("Unexpected content %q", data)
+	}
+}
+
+func TestCheckpointRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dump.ckpt")
+
+	if cp, err := loadCheckpoint(path); err != nil || cp != nil {
+		t.Fatalf("Expected no checkpoint, got %v, %v", cp, err)
+	}
+
+	cp := &checkpoint{
+		Index:  "events",
+		Query:  map[string]interface{}{"match_all": map[string]interface{}{}},
+		Mode:   modePIT,
+		PIT:    &types.PointInTime{ID: "pit-1"},
+		Offset: 1234,
+		Slices: []*sliceState{{ID: 0, SearchAfter: []interface{}{json.Number("9007199254740993")}, Docs: 10}},
+	}
+	if err := cp.save(path); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	loaded, err := loadCheckpoint(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if loaded.Offset != 1234 || loaded.PIT.ID != "pit-1" || loaded.Slices[0].Docs != 10 {
+		t.Errorf("Unexpected checkpoint %+v", loaded)
+	}
+	if got := loaded.Slices[0].SearchAfter[0]; got != json.Number("9007199254740993") {
+		t.Errorf("Expected exact search_after value, got %v", got)
+	}
+	if !loaded.sameDump(cp) {
+		t.Error("Expected loaded checkpoint to match")
+	}
+
+	other := *cp
+	other.Fields = []string{"message"}
+	if cp.sameDump(&other) {
+		t.Error("Expected different fields not to match")
+	}
+}
+
+func TestCheckpointResumable(t *testing.T) {
+	tests := []struct {
+		name      string
+		cp        checkpoint
+		resumable bool
+	}{
+		{"point in time", checkpoint{Mode: modePIT, Slices: []*sliceState{{SearchAfter: []interface{}{1}}}}, true},
+		{"scroll not started", checkpoint{Mode: modeScroll, Slices: []*sliceState{{}}}, true},
+		{"scroll after crash", checkpoint{Mode: modeScroll, Slices: []*sliceState{{ScrollID: "s1"}}}, false},
+		{"scroll after interrupt", checkpoint{Mode: modeScroll, Stopped: true, Slices: []*sliceState{{ScrollID: "s1"}}}, true},
+		{"scroll slice done", checkpoint{Mode: modeScroll, Slices: []*sliceState{{ScrollID: "s1", Done: true}}}, true},
+	}
+	for _, tt := range tests {
+		if err := tt.cp.resumable(); (err == nil) != tt.resumable {
+			t.Errorf("%s: expected resumable %v, got %v", tt.name, tt.resumable, err)
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package dump
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+)
+
+// dumper pages through one slice per goroutine. Pages are written whole under mu, together
+// with the checkpoint update, so the checkpoint offset always ends on a page boundary.
+type dumper struct {
+	client    client.SearchClient
+	index     string
+	query     map[string]interface{}
+	fields    []string
+	sort      []interface{}
+	userSort  bool
+	size      int
+	keepAlive string
+	withMeta  bool
+
+	mu             sync.Mutex
+	out            io.Writer
+	gzip           bool
+	state          *checkpoint
+	checkpointPath string
+
+	docs    atomic.Int64
+	total   atomic.Int64
+	written atomic.Int64
+	bytes   atomic.Int64
+	stopped atomic.Bool
+}
+
+// effectiveSort returns the sort clause for a mode. Without a user sort, point in time
+// searches use the _shard_doc tiebreaker and scrolls use the cheapest _doc order.
+func effectiveSort(mode string, userSort []interface{}) []interface{} {
+	if len(userSort) > 0 {
+		return userSort
+	}
+	if mode == modePIT {
+		return []interface{}{map[string]interface{}{"_shard_doc": "asc"}}
+	}
+	return []interface{}{"_doc"}
+}
+
+// start chooses between a point in time and a scroll. OpenSearch has no _shard_doc
+// tiebreaker, so without --sort it scrolls as well.
+func (d *dumper) start() error {
+	pit, err := d.client.OpenPointInTime(d.index, d.keepAlive)
+	switch {
+	case errors.Is(err, client.ErrPointInTimeUnsupported):
+		fmt.Fprintf(os.Stderr, "Point in time is not supported by this cluster; using scroll\n")
+		d.state.Mode = modeScroll
+	case err != nil:
+		return err
+	case pit.OpenSearch && !d.userSort:
+		if err := d.client.ClosePointInTime(pit); err != nil {
+			fmt.Fprintf(os.Stderr, "Error closing point in time: %v\n", err)
+		}
+		d.state.Mode = modeScroll
+	default:
+		d.state.Mode = modePIT
+		d.state.PIT = pit
+	}
+	d.sort = effectiveSort(d.state.Mode, d.state.Sort)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.saveLocked()
+}
+
+func (d *dumper) body(st *sliceState, slices int) map[string]interface{} {
+	body := map[string]interface{}{
+		"size":  d.size,
+		"query": d.query,
+		"sort":  d.sort,
+	}
+	if len(d.fields) > 0 {
+		body["_source"] = d.fields
+	}
+	if slices > 1 {
+		body["slice"] = map[string]interface{}{"id": st.ID, "max": slices}
+	}
+	if st.SearchAfter != nil {
+		body["search_after"] = st.SearchAfter
+	} else {
+		body["track_total_hits"] = true
+	}
+	if d.state.Mode == modePIT {
+		d.mu.Lock()
+		body["pit"] = map[string]interface{}{"id": d.state.PIT.ID, "keep_alive": d.keepAlive}
+		d.mu.Unlock()
+	}
+	return body
+}
+
+func (d *dumper) fetch(st *sliceState) (*types.SearchResponse, error) {
+	slices := len(d.state.Slices)
+	if d.state.Mode == modeScroll {
+		if st.ScrollID == "" {
+			return d.client.Search(d.index, d.body(st, slices), types.SearchOptions{Scroll: d.keepAlive})
+		}
+		resp, err := d.client.Scroll(st.ScrollID, d.keepAlive)
+		if errors.Is(err, client.ErrSearchContextMissing) {
+			return nil, fmt.Errorf("scroll expired; scrolls cannot be resumed, remove the checkpoint to start over")
+		}
+		return resp, err
+	}
+
+	body := d.body(st, slices)
+	resp, err := d.client.Search("", body, types.SearchOptions{})
+	if !errors.Is(err, client.ErrSearchContextMissing) {
+		return resp, err
+	}
+	if !d.userSort {
+		return nil, fmt.Errorf("point in time expired; _shard_doc positions are only valid for the original point in time, use --sort with unique fields to make the dump resumable or remove the checkpoint to start over")
+	}
+	expired := body["pit"].(map[string]interface{})["id"].(string)
+	if err := d.reopen(expired); err != nil {
+		return nil, err
+	}
+	return d.client.Search("", d.body(st, slices), types.SearchOptions{})
+}
+
+// reopen replaces an expired point in time unless another slice already has
+func (d *dumper) reopen(expired string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.state.PIT.ID != expired {
+		return nil
+	}
+	pit, err := d.client.OpenPointInTime(d.index, d.keepAlive)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Point in time expired; continuing with a new one, documents changed since the dump started may differ\n")
+	d.state.PIT = pit
+	return d.saveLocked()
+}
+
+// runSlice pages through one slice until it is exhausted or the dump is stopped
+func (d *dumper) runSlice(st *sliceState) error {
+	for !st.Done && !d.stopped.Load() {
+		first := st.SearchAfter == nil && st.ScrollID == ""
+		resp, err := d.fetch(st)
+		if err != nil {
+			return fmt.Errorf("slice %d: %v", st.ID, err)
+		}
+		hits := resp.Hits.Hits
+		if first {
+			d.total.Add(resp.Hits.Total.Value)
+		}
+
+		err = d.commit(render(hits, d.withMeta), func() {
+			if first {
+				st.Total = resp.Hits.Total.Value
+			}
+			st.Docs += int64(len(hits))
+			if resp.PitID != "" {
+				d.state.PIT.ID = resp.PitID
+			}
+			if d.state.Mode == modeScroll {
+				st.ScrollID = resp.ScrollID
+			} else if len(hits) > 0 {
+				st.SearchAfter = hits[len(hits)-1].Sort
+			}
+			st.Done = len(hits) == 0 || (d.state.Mode == modePIT && len(hits) < d.size)
+		})
+		if err != nil {
+			return err
+		}
+		d.docs.Add(int64(len(hits)))
+		d.written.Add(int64(len(hits)))
+	}
+	return nil
+}
+
+// commit writes a page and updates the checkpoint. With gzip each page is a complete
+// gzip member; concatenated members are read back as one stream by gzip tools.
+func (d *dumper) commit(page []byte, update func()) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if len(page) > 0 {
+		data := page
+		if d.gzip {
+			var err error
+			if data, err = gzipMember(page); err != nil {
+				return err
+			}
+		}
+		n, err := d.out.Write(data)
+		d.state.Offset += int64(n)
+		d.bytes.Add(int64(n))
+		if err != nil {
+			return fmt.Errorf("error writing output: %v", err)
+		}
+	}
+	update()
+	return d.saveLocked()
+}
+
+func (d *dumper) saveLocked() error {
+	if d.checkpointPath == "" {
+		return nil
+	}
+	if err := d.state.save(d.checkpointPath); err != nil {
+		return fmt.Errorf("error saving checkpoint: %v", err)
+	}
+	return nil
+}
+
+// release closes the point in time or clears the scrolls
+func (d *dumper) release() {
+	if d.state.PIT != nil {
+		if err := d.client.ClosePointInTime(d.state.PIT); err != nil {
+			fmt.Fprintf(os.Stderr, "Error closing point in time: %v\n", err)
+		}
+	}
+	for _, st := range d.state.Slices {
+		if st.ScrollID != "" {
+			if err := d.client.ClearScroll(st.ScrollID); err != nil {
+				fmt.Fprintf(os.Stderr, "Error clearing scroll: %v\n", err)
+			}
+		}
+	}
+}
+
+// render writes one NDJSON line per hit: the compacted _source, or the source with its metadata
+func render(hits []types.SearchHit, withMeta bool) []byte {
+	var buf bytes.Buffer
+	for _, h := range hits {
+		source := h.Source
+		if len(source) == 0 {
+			source = json.RawMessage("{}")
+		}
+		if withMeta {
+			meta := map[string]interface{}{"_index": h.Index, "_id": h.ID, "_source": source}
+			if h.Routing != "" {
+				meta["_routing"] = h.Routing
+			}
+			line, _ := json.Marshal(meta)
+			buf.Write(line)
+		} else if err := json.Compact(&buf, source); err != nil {
+			buf.Write(source)
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+func gzipMember(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,218 @@
+package fields
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/output"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type options struct {
+	fields          string
+	includeUnmapped bool
+	conflicts       bool
+	meta            bool
+}
+
+// field summarises the capabilities of one field across all matching indices
+type field struct {
+	Name         string              `json:"name"`
+	Types        []string            `json:"types"`
+	Searchable   string              `json:"searchable"`
+	Aggregatable string              `json:"aggregatable"`
+	Conflict     bool                `json:"conflict"`
+	Indices      map[string][]string `json:"indices,omitempty"`
+}
+
+func NewFieldsCmd() *cobra.Command {
+	var opts options
+
+	cmd := &cobra.Command{
+		Use:   "fields INDEX_PATTERN",
+		Short: "List the fields of the indices matching a pattern",
+		Long: `List every field of the indices matching INDEX_PATTERN with the field
+capabilities API.
+
+For each field the type, and whether it is searchable and aggregatable, is
+shown. A field mapped with different types in different indices is a conflict
+and lists all of its types; -o wide shows which indices use each type. A flag
+is "partial" when only some indices allow it. Object fields and metadata fields
+such as _id are hidden unless --meta is given.`,
+		Example: strings.TrimSpace(`
+# All fields of the log indices
+searchctl fields "logs-*"
+
+# Only fields whose type differs between indices, with the indices per type
+searchctl fields "logs-*" --conflicts -o wide
+
+# Fields under a prefix
+searchctl fields "logs-*" --fields "host.*"`),
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			pattern := args[0]
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			caps, err := c.GetFieldCaps(pattern, opts.fields, opts.includeUnmapped)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting fields: %v\n", err)
+				os.Exit(1)
+			}
+
+			summary := summarize(caps, opts)
+			outFmt := viper.GetString("output")
+			var data interface{} = summary
+			if outFmt != "json" && outFmt != "yaml" {
+				data = fieldRows(summary, outFmt == "wide")
+			}
+			if err := output.NewFormatter(outFmt).Format(data, cmd.OutOrStdout()); err != nil {
+				fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+				os.Exit(1)
+			}
+			if len(summary) == 0 {
+				cmd.Printf("No fields found\n")
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.fields, "fields", "*", "comma-separated field names or wildcard patterns")
+	cmd.Flags().BoolVar(&opts.includeUnmapped, "include-unmapped", false, "report fields missing from some indices as unmapped there")
+	cmd.Flags().BoolVar(&opts.conflicts, "conflicts", false, "only show fields with conflicting types")
+	cmd.Flags().BoolVar(&opts.meta, "meta", false, "include metadata fields")
+
+	return cmd
+}
+
+// summarize merges the per-type capabilities of every field, sorted by name
+func summarize(caps *types.FieldCapsResponse, opts options) []field {
+	var result []field
+	for name, byType := range caps.Fields {
+		delete(byType, "object")
+		delete(byType, "nested")
+		if len(byType) == 0 {
+			continue
+		}
+
+		f := field{Name: name, Indices: map[string][]string{}}
+		var searchable, aggregatable tally
+		metadata := false
+		for typ, c := range byType {
+			metadata = metadata || c.MetadataField
+			if typ == "unmapped" {
+				f.Indices[typ] = c.Indices
+				continue
+			}
+			f.Types = append(f.Types, typ)
+			f.Indices[typ] = c.Indices
+			searchable.add(c.Searchable, c.NonSearchableIndices)
+			aggregatable.add(c.Aggregatable, c.NonAggregatableIndices)
+		}
+		if metadata && !opts.meta {
+			continue
+		}
+		if len(f.Types) == 0 {
+			f.Types = []string{"unmapped"}
+		}
+		sort.Strings(f.Types)
+		f.Conflict = len(f.Types) > 1
+		if opts.conflicts && !f.Conflict {
+			continue
+		}
+		f.Searchable = searchable.String()
+		f.Aggregatable = aggregatable.String()
+		if _, ok := byType["unmapped"]; ok && f.Types[0] != "unmapped" {
+			f.Searchable = partial(f.Searchable)
+			f.Aggregatable = partial(f.Aggregatable)
+		}
+		if !f.Conflict && len(f.Indices) == 1 {
+			// Indices are only reported when a field differs between them
+			f.Indices = nil
+		}
+		result = append(result, f)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// tally combines a capability over the types of a field. The API reports a capability
+// as true only when every index has it, and lists the indices without it only when
+// some indices do have it.
+type tally struct {
+	types, all, some int
+}
+
+func (t *tally) add(value bool, without []string) {
+	t.types++
+	if value {
+		t.all++
+		t.some++
+	} else if len(without) > 0 {
+		t.some++
+	}
+}
+
+func (t tally) String() string {
+	switch {
+	case t.types > 0 && t.all == t.types:
+		return "true"
+	case t.some > 0:
+		return "partial"
+	default:
+		return "false"
+	}
+}
+
+// partial downgrades true to partial for fields that are unmapped in some indices
+func partial(v string) string {
+	if v == "true" {
+		return "partial"
+	}
+	return v
+}
+
+func fieldRows(fields []field, wide bool) []interface{} {
+	columns := "FIELD,TYPE,SEARCHABLE,AGGREGATABLE,CONFLICT"
+	if wide {
+		columns += ",INDICES"
+	}
+	rows := make([]interface{}, len(fields))
+	for i, f := range fields {
+		row := map[string]interface{}{
+			"__columns":    columns,
+			"FIELD":        f.Name,
+			"TYPE":         strings.Join(f.Types, ","),
+			"SEARCHABLE":   f.Searchable,
+			"AGGREGATABLE": f.Aggregatable,
+			"CONFLICT":     f.Conflict,
+		}
+		if wide {
+			row["INDICES"] = indicesByType(f.Indices)
+		}
+		rows[i] = row
+	}
+	return rows
+}
+
+// indicesByType renders the indices of each type as "type: a,b; type2: c"
+func indicesByType(indices map[string][]string) string {
+	typeNames := make([]string, 0, len(indices))
+	for t := range indices {
+		typeNames = append(typeNames, t)
+	}
+	sort.Strings(typeNames)
+	parts := make([]string, len(typeNames))
+	for i, t := range typeNames {
+		parts[i] = t + ": " + strings.Join(indices[t], ",")
+	}
+	return strings.Join(parts, "; ")
+}
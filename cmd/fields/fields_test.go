@@ -0,0 +1,86 @@
+package fields
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/chronicblondiee/searchctl/pkg/types"
+)
+
+func TestNewFieldsCmd(t *testing.T) {
+	cmd := NewFieldsCmd()
+	if cmd.Use != "fields INDEX_PATTERN" {
+		t.Errorf("Expected Use 'fields INDEX_PATTERN', got %q", cmd.Use)
+	}
+	for _, name := range []string{"fields", "include-unmapped", "conflicts", "meta"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("Expected flag %s", name)
+		}
+	}
+}
+
+func testCaps() *types.FieldCapsResponse {
+	return &types.FieldCapsResponse{
+		Indices: []string{"logs-1", "logs-2"},
+		Fields: map[string]map[string]types.FieldCapability{
+			"_id":       {"_id": {Type: "_id", MetadataField: true, Searchable: true}},
+			"host":      {"object": {Type: "object"}},
+			"host.name": {"keyword": {Type: "keyword", Searchable: true, Aggregatable: true}},
+			"status": {
+				"keyword": {Type: "keyword", Searchable: true, Aggregatable: true, Indices: []string{"logs-1"}},
+				"long":    {Type: "long", Searchable: true, Aggregatable: true, Indices: []string{"logs-2"}},
+			},
+			"message": {"text": {Type: "text", Searchable: true, Aggregatable: false}},
+			"payload": {"keyword": {Type: "keyword", Searchable: false, NonSearchableIndices: []string{"logs-2"}, Aggregatable: false}},
+		},
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	summary := summarize(testCaps(), options{})
+
+	var names []string
+	for _, f := range summary {
+		names = append(names, f.Name)
+	}
+	expected := []string{"host.name", "message", "payload", "status"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Fatalf("Expected fields %v, got %v", expected, names)
+	}
+
+	message := summary[1]
+	if message.Searchable != "true" || message.Aggregatable != "false" || message.Conflict || message.Indices != nil {
+		t.Errorf("Unexpected summary for message: %+v", message)
+	}
+	if summary[2].Searchable != "partial" {
+		t.Errorf("Expected payload to be partially searchable, got %s", summary[2].Searchable)
+	}
+
+	status := summary[3]
+	if !status.Conflict || !reflect.DeepEqual(status.Types, []string{"keyword", "long"}) {
+		t.Errorf("Expected status to conflict between keyword and long, got %+v", status)
+	}
+	if got := indicesByType(status.Indices); got != "keyword: logs-1; long: logs-2" {
+		t.Errorf("Unexpected indices %q", got)
+	}
+}
+
+func TestSummarizeOptions(t *testing.T) {
+	conflicts := summarize(testCaps(), options{conflicts: true})
+	if len(conflicts) != 1 || conflicts[0].Name != "status" {
+		t.Errorf("Expected only status with --conflicts, got %+v", conflicts)
+	}
+
+	withMeta := summarize(testCaps(), options{meta: true})
+	if withMeta[0].Name != "_id" {
+		t.Errorf("Expected _id with --meta, got %s", withMeta[0].Name)
+	}
+
+	caps := testCaps()
+	caps.Fields["message"]["unmapped"] = types.FieldCapability{Type: "unmapped", Indices: []string{"logs-2"}}
+	for _, f := range summarize(caps, options{}) {
+		if f.Name == "message" && (f.Searchable != "partial" || f.Conflict) {
+			t.Errorf("Expected message unmapped in logs-2 to be partially searchable, got %+v", f)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package get
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewGetAliasesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "aliases [ALIAS_PATTERN]",
+		Short:   "List index aliases",
+		Long:    "List all index aliases or aliases matching a pattern.",
+		Aliases: []string{"alias", "al"},
+		Args:    cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			pattern := ""
+			if len(args) > 0 {
+				pattern = args[0]
+			}
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			aliases, err := c.GetAliases(pattern)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting aliases: %v\n", err)
+				os.Exit(1)
+			}
+
+			data := make([]interface{}, len(aliases))
+			for i, a := range aliases {
+				data[i] = map[string]interface{}{
+					"__columns":      "ALIAS,INDEX,FILTER,ROUTING.INDEX,ROUTING.SEARCH,IS_WRITE_INDEX",
+					"ALIAS":          a.Alias,
+					"INDEX":          a.Index,
+					"FILTER":         a.Filter,
+					"ROUTING.INDEX":  a.RoutingIndex,
+					"ROUTING.SEARCH": a.RoutingSearch,
+					"IS_WRITE_INDEX": a.IsWriteIndex,
+				}
+			}
+
+			formatter := output.NewFormatter(viper.GetString("output"))
+			if err := formatter.Format(data, cmd.OutOrStdout()); err != nil {
+				fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	return cmd
+}
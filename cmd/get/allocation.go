@@ -0,0 +1,268 @@
+package get
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/disk"
+	"github.com/chronicblondiee/searchctl/pkg/output"
+	pkgtypes "github.com/chronicblondiee/searchctl/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	levelOK         = "ok"
+	levelLow        = disk.Low
+	levelHigh       = disk.High
+	levelFloodStage = disk.FloodStage
+)
+
+// levelColors color the LEVEL column, which comes last so that the escape codes do
+// not throw off the table alignment
+var levelColors = map[string]string{
+	levelOK:         "\033[32m",
+	levelLow:        "\033[33m",
+	levelHigh:       "\033[31m",
+	levelFloodStage: "\033[35m",
+}
+
+// allocationReport is the output of get allocation
+type allocationReport struct {
+	ThresholdEnabled bool             `json:"threshold_enabled"`
+	Watermarks       []disk.Watermark `json:"watermarks"`
+	Nodes            []nodeDisk       `json:"nodes"`
+	UnassignedShards int              `json:"unassigned_shards"`
+}
+
+// nodeDisk is the disk usage of a node and its projected growth
+type nodeDisk struct {
+	Node           string           `json:"node"`
+	Host           string           `json:"host"`
+	IP             string           `json:"ip"`
+	Shards         int              `json:"shards"`
+	IndicesBytes   int64            `json:"indices_bytes"`
+	UsedBytes      int64            `json:"used_bytes"`
+	AvailableBytes int64            `json:"available_bytes"`
+	TotalBytes     int64            `json:"total_bytes"`
+	Percent        float64          `json:"percent"`
+	Level          string           `json:"level"`
+	Thresholds     map[string]int64 `json:"thresholds"`
+	GrowthPerDay   *int64           `json:"growth_per_day,omitempty"`
+	Next           string           `json:"next,omitempty"`
+	DaysToNext     *float64         `json:"days_to_next,omitempty"`
+}
+
+func NewGetAllocationCmd() *cobra.Command {
+	var sample time.Duration
+	var noColor bool
+
+	cmd := &cobra.Command{
+		Use:   "allocation [NODE]",
+		Short: "Show disk usage per node against the disk watermarks",
+		Long: `Show shards and disk usage per data node from _cat/allocation, compared with the
+low, high and flood stage disk watermarks from the cluster settings, including defaults.
+
+Each node gets the highest watermark it is above: ok, low (no new shards), high
+(shards move away) or flood-stage (indices become read-only). The level is color
+coded when writing a table to a terminal, unless --no-color or NO_COLOR is set.
+
+With --sample, disk usage is read twice over the interval to measure how fast each
+node grows and to project the days until it crosses its next watermark. Relocating
+shards skew the sample; use an interval long enough to average them out.`,
+		Aliases: []string{"alloc"},
+		Example: strings.TrimSpace(`
+# Disk usage and watermark level per node
+searchctl get allocation
+
+# Project days until the next watermark from ten minutes of ingest
+searchctl get allocation --sample 10m
+
+# A single node, as JSON
+searchctl get allocation es-data-1 -o json`),
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			nodes := ""
+			if len(args) > 0 {
+				nodes = args[0]
+			}
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			settings, err := c.GetClusterSettingsWithDefaults()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting cluster settings: %v\n", err)
+				os.Exit(1)
+			}
+			rows, err := c.GetAllocation(nodes)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting allocation: %v\n", err)
+				os.Exit(1)
+			}
+
+			var before []pkgtypes.CatAllocationRow
+			if sample > 0 {
+				before = rows
+				cmd.Printf("Sampling disk usage for %s...\n", sample)
+				time.Sleep(sample)
+				if rows, err = c.GetAllocation(nodes); err != nil {
+					fmt.Fprintf(os.Stderr, "Error getting allocation: %v\n", err)
+					os.Exit(1)
+				}
+			}
+
+			report := buildAllocationReport(settings, rows, before, sample)
+
+			outFmt := viper.GetString("output")
+			if outFmt == "json" || outFmt == "yaml" {
+				if err := output.NewFormatter(outFmt).Format(report, os.Stdout); err != nil {
+					fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+					os.Exit(1)
+				}
+				return
+			}
+
+			printWatermarks(report)
+			color := !noColor && os.Getenv("NO_COLOR") == "" && outFmt != "csv" && output.IsTerminal(os.Stdout)
+			data := allocationRows(report, outFmt == "wide", sample > 0, color)
+			if err := output.NewFormatter(outFmt).Format(data, os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().DurationVar(&sample, "sample", 0, "measure disk growth over this interval and project days until the next watermark")
+	cmd.Flags().BoolVar(&noColor, "no-color", false, "do not color code watermark levels")
+
+	return cmd
+}
+
+func buildAllocationReport(settings *pkgtypes.ClusterSettings, rows, before []pkgtypes.CatAllocationRow, interval time.Duration) allocationReport {
+	enabled, marks := disk.Watermarks(settings)
+	report := allocationReport{ThresholdEnabled: enabled, Watermarks: marks, Nodes: []nodeDisk{}}
+
+	previous := make(map[string]int64)
+	for _, r := range before {
+		previous[r.Node], _ = strconv.ParseInt(r.DiskUsed, 10, 64)
+	}
+
+	for _, r := range rows {
+		shards, _ := strconv.Atoi(r.Shards)
+		if r.Node == "UNASSIGNED" {
+			report.UnassignedShards = shards
+			continue
+		}
+		n := nodeDisk{Node: r.Node, Host: r.Host, IP: r.IP, Shards: shards, Level: levelOK, Thresholds: make(map[string]int64)}
+		n.IndicesBytes, _ = strconv.ParseInt(r.DiskIndices, 10, 64)
+		n.UsedBytes, _ = strconv.ParseInt(r.DiskUsed, 10, 64)
+		n.AvailableBytes, _ = strconv.ParseInt(r.DiskAvail, 10, 64)
+		n.TotalBytes, _ = strconv.ParseInt(r.DiskTotal, 10, 64)
+		if n.TotalBytes > 0 {
+			n.Percent = float64(n.UsedBytes) / float64(n.TotalBytes) * 100
+		}
+
+		var growth float64
+		if used, ok := previous[r.Node]; ok && interval > 0 {
+			growth = float64(n.UsedBytes-used) / interval.Seconds() * 86400
+			perDay := int64(growth)
+			n.GrowthPerDay = &perDay
+		}
+
+		for _, w := range marks {
+			limit, err := w.Threshold(n.TotalBytes)
+			if err != nil || n.TotalBytes == 0 {
+				continue
+			}
+			n.Thresholds[w.Level] = limit
+			if n.UsedBytes > limit {
+				n.Level = w.Level
+			} else if n.Next == "" {
+				n.Next = w.Level
+				if growth > 0 {
+					days := float64(limit-n.UsedBytes) / growth
+					n.DaysToNext = &days
+				}
+			}
+		}
+		report.Nodes = append(report.Nodes, n)
+	}
+
+	sort.Slice(report.Nodes, func(i, j int) bool { return report.Nodes[i].Node < report.Nodes[j].Node })
+	return report
+}
+
+func printWatermarks(report allocationReport) {
+	parts := make([]string, len(report.Watermarks))
+	for i, w := range report.Watermarks {
+		parts[i] = w.Level + " " + w.Value
+		if w.MaxHeadroom != "" {
+			parts[i] += fmt.Sprintf(" (max headroom %s)", w.MaxHeadroom)
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Watermarks: %s\n", strings.Join(parts, ", "))
+	if !report.ThresholdEnabled {
+		fmt.Fprintln(os.Stderr, "Warning: disk thresholds are disabled (cluster.routing.allocation.disk.threshold_enabled=false)")
+	}
+	if report.UnassignedShards > 0 {
+		fmt.Fprintf(os.Stderr, "%d unassigned shard(s)\n", report.UnassignedShards)
+	}
+}
+
+func allocationRows(report allocationReport, wide, sampled, color bool) []interface{} {
+	columns := "NODE,SHARDS,USED,AVAIL,TOTAL,PERCENT"
+	if sampled {
+		columns += ",GROWTH/DAY,NEXT,DAYS"
+	}
+	if wide {
+		columns += ",INDICES,HOST,IP"
+	}
+	columns += ",LEVEL"
+
+	rows := make([]interface{}, len(report.Nodes))
+	for i, n := range report.Nodes {
+		level := n.Level
+		if color {
+			level = levelColors[n.Level] + level + "\033[0m"
+		}
+		row := map[string]interface{}{
+			"__columns": columns,
+			"NODE":      n.Node,
+			"SHARDS":    n.Shards,
+			"USED":      output.FormatBytes(n.UsedBytes),
+			"AVAIL":     output.FormatBytes(n.AvailableBytes),
+			"TOTAL":     output.FormatBytes(n.TotalBytes),
+			"PERCENT":   fmt.Sprintf("%.1f%%", n.Percent),
+			"LEVEL":     level,
+			"INDICES":   output.FormatBytes(n.IndicesBytes),
+			"HOST":      n.Host,
+			"IP":        n.IP,
+		}
+		if sampled {
+			row["GROWTH/DAY"], row["NEXT"], row["DAYS"] = "-", "-", "-"
+			if n.GrowthPerDay != nil {
+				row["GROWTH/DAY"] = output.FormatBytes(*n.GrowthPerDay)
+				if *n.GrowthPerDay < 0 {
+					row["GROWTH/DAY"] = "-" + output.FormatBytes(-*n.GrowthPerDay)
+				}
+			}
+			if n.Next != "" {
+				row["NEXT"] = n.Next
+			}
+			if n.DaysToNext != nil {
+				row["DAYS"] = fmt.Sprintf("%.1f", *n.DaysToNext)
+			}
+		}
+		rows[i] = row
+	}
+	return rows
+}
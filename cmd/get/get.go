@@ -18,6 +18,15 @@ func NewGetCmd() *cobra.Command {
 	cmd.AddCommand(NewGetComponentTemplatesCmd())
 	cmd.AddCommand(NewGetLifecyclePoliciesCmd())
 	cmd.AddCommand(NewGetShardsCmd())
+	cmd.AddCommand(NewGetAllocationCmd())
+	cmd.AddCommand(NewGetAliasesCmd())
+	cmd.AddCommand(NewGetSettingsCmd())
+	cmd.AddCommand(NewGetMappingCmd())
+	cmd.AddCommand(NewGetTasksCmd())
+	cmd.AddCommand(NewGetSnapshotRepositoriesCmd())
+	cmd.AddCommand(NewGetSnapshotsCmd())
+	cmd.AddCommand(NewGetSnapshotPoliciesCmd())
+	cmd.AddCommand(NewGetIngestPipelinesCmd())
 
 	return cmd
 }
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/chronicblondiee/searchctl/pkg/types"
 	"github.com/spf13/cobra"
 )
 
@@ -75,3 +77,100 @@ func TestGetCommandValidSubcommands(t *testing.T) {
 		}
 	}
 }
+
+func TestGroupTasks(t *testing.T) {
+	tasks := []types.TaskInfo{
+		{Node: "n1", ID: 1, Action: "indices:data/write/reindex"},
+		{Node: "n2", ID: 7, Action: "cluster:monitor/tasks/lists"},
+		{Node: "n1", ID: 2, Action: "indices:data/write/bulk", ParentTaskID: "n1:1"},
+		{Node: "n1", ID: 3, Action: "indices:data/write/bulk[s]", ParentTaskID: "n1:2"},
+		{Node: "n3", ID: 9, Action: "orphan", ParentTaskID: "gone:1"},
+	}
+
+	rows := groupTasks(tasks)
+	order := make([]string, len(rows))
+	for i, r := range rows {
+		order[i] = taskLabel(r)
+	}
+	expected := []string{"n1:1", "└─ n1:2", "   └─ n1:3", "n2:7", "n3:9"}
+	if strings.Join(order, "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected %v, got %v", expected, order)
+	}
+}
+
+func TestLastRun(t *testing.T) {
+	tests := []struct {
+		run      map[string]interface{}
+		expected string
+	}{
+		{nil, "-"},
+		{map[string]interface{}{"snapshot_name": "nightly-1", "time_string": "2024-05-01T01:30:00.000Z"}, "nightly-1 at 2024-05-01T01:30:00.000Z"},
+		{map[string]interface{}{"snapshot_name": "nightly-2", "time": float64(1714527000000)}, "nightly-2 at 2024-05-01T01:30:00Z"},
+	}
+	for _, tt := range tests {
+		if got := lastRun(tt.run); got != tt.expected {
+			t.Errorf("lastRun(%v) = %q, expected %q", tt.run, got, tt.expected)
+		}
+	}
+}
+
+func TestDrainingNodes(t *testing.T) {
+	settings := &types.ClusterSettings{
+		Persistent: map[string]interface{}{"cluster.routing.allocation.exclude._name": "es-data-1"},
+		Transient:  map[string]interface{}{"cluster.routing.allocation.exclude._ip": "10.0.1.*"},
+	}
+	nodes := []types.Node{
+		{Name: "es-data-1", IP: "10.0.0.1"},
+		{Name: "es-data-2", IP: "10.0.0.2"},
+		{Name: "es-data-3", IP: "10.0.1.3"},
+	}
+	draining := drainingNodes(settings, nodes)
+	if !draining["es-data-1"] || draining["es-data-2"] || !draining["es-data-3"] {
+		t.Errorf("Unexpected draining nodes %v", draining)
+	}
+}
+
+func TestBuildAllocationReport(t *testing.T) {
+	settings := &types.ClusterSettings{
+		Persistent: map[string]interface{}{"cluster": map[string]interface{}{"routing": map[string]interface{}{
+			"allocation": map[string]interface{}{"disk": map[string]interface{}{"watermark": map[string]interface{}{"low": "80%"}}},
+		}}},
+		Defaults: map[string]interface{}{
+			"cluster.routing.allocation.disk.watermark.high":        "90%",
+			"cluster.routing.allocation.disk.watermark.flood_stage": "95%",
+		},
+	}
+	before := []types.CatAllocationRow{
+		{Node: "n1", DiskUsed: "700", DiskTotal: "1000"},
+		{Node: "n2", DiskUsed: "500", DiskTotal: "1000"},
+	}
+	rows := []types.CatAllocationRow{
+		{Node: "n2", Shards: "3", DiskUsed: "500", DiskAvail: "500", DiskTotal: "1000"},
+		{Node: "n1", Shards: "5", DiskUsed: "850", DiskAvail: "150", DiskTotal: "1000"},
+		{Node: "UNASSIGNED", Shards: "2"},
+	}
+
+	report := buildAllocationReport(settings, rows, before, 24*time.Hour)
+	if report.UnassignedShards != 2 || len(report.Nodes) != 2 {
+		t.Fatalf("Unexpected report %+v", report)
+	}
+	if report.Watermarks[0].Value != "80%" || report.Watermarks[1].Value != "90%" {
+		t.Errorf("Unexpected watermarks %+v", report.Watermarks)
+	}
+
+	n1, n2 := report.Nodes[0], report.Nodes[1]
+	if n1.Node != "n1" || n1.Level != levelLow || n1.Next != levelHigh {
+		t.Errorf("Expected n1 above low with high next, got %+v", n1)
+	}
+	if n1.DaysToNext == nil || *n1.DaysToNext < 0.33 || *n1.DaysToNext > 0.34 {
+		t.Errorf("Expected n1 to reach high in a third of a day, got %v", n1.DaysToNext)
+	}
+	if n2.Level != levelOK || n2.Next != levelLow || n2.DaysToNext != nil {
+		t.Errorf("Expected n2 ok without growth, got %+v", n2)
+	}
+
+	rowsOut := allocationRows(report, false, true, false)
+	if row := rowsOut[0].(map[string]interface{}); row["LEVEL"] != levelLow || row["DAYS"] != "0.3" {
+		t.Errorf("Unexpected row %v", row)
+	}
+}
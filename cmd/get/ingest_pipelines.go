@@ -0,0 +1,81 @@
+package get
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewGetIngestPipelinesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "ingest-pipelines [PATTERN]",
+		Short:   "Get ingest pipelines",
+		Long:    "Get ingest pipelines from the search cluster with their description, version and processors.",
+		Aliases: []string{"ingest-pipeline", "ingestpipelines", "ingestpipeline", "pipelines", "pipeline", "ip"},
+		Args:    cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			pattern := ""
+			if len(args) > 0 {
+				pattern = args[0]
+			}
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			pipelines, err := c.GetIngestPipelines(pattern)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting ingest pipelines: %v\n", err)
+				os.Exit(1)
+			}
+			sort.Slice(pipelines, func(i, j int) bool { return pipelines[i].Name < pipelines[j].Name })
+
+			outFmt := viper.GetString("output")
+			if outFmt == "json" || outFmt == "yaml" {
+				if err := output.NewFormatter(outFmt).Format(pipelines, cmd.OutOrStdout()); err != nil {
+					fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+					os.Exit(1)
+				}
+				return
+			}
+
+			data := make([]interface{}, len(pipelines))
+			for i, p := range pipelines {
+				row := map[string]interface{}{
+					"__columns":   "NAME,PROCESSORS,VERSION,DESCRIPTION",
+					"NAME":        p.Name,
+					"PROCESSORS":  len(p.Processors(false)),
+					"VERSION":     "-",
+					"DESCRIPTION": "-",
+				}
+				if v, ok := p.Body["version"]; ok {
+					row["VERSION"] = fmt.Sprint(v)
+				}
+				if d, ok := p.Body["description"].(string); ok && d != "" {
+					row["DESCRIPTION"] = d
+				}
+				if outFmt == "wide" {
+					row["__columns"] = "NAME,PROCESSORS,VERSION,DESCRIPTION,TYPES"
+					row["TYPES"] = strings.Join(p.Processors(false), ",")
+				}
+				data[i] = row
+			}
+
+			formatter := output.NewFormatter(outFmt)
+			if err := formatter.Format(data, cmd.OutOrStdout()); err != nil {
+				fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,110 @@
+package get
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewGetMappingCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "mapping INDEX_PATTERN",
+		Short:   "Show index mappings",
+		Long:    "Show the mappings of an index or indices matching a pattern. Table output prints each index as a field tree with types; use -o json|yaml for the raw mapping.",
+		Aliases: []string{"mappings", "map"},
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			mappings, err := c.GetIndexMapping(args[0])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting index mapping: %v\n", err)
+				os.Exit(1)
+			}
+
+			outFmt := viper.GetString("output")
+			if outFmt == "json" || outFmt == "yaml" {
+				formatter := output.NewFormatter(outFmt)
+				if err := formatter.Format(mappings, cmd.OutOrStdout()); err != nil {
+					fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+					os.Exit(1)
+				}
+				return
+			}
+
+			if len(mappings) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No resources found")
+				return
+			}
+			for i, m := range mappings {
+				if i > 0 {
+					fmt.Fprintln(cmd.OutOrStdout())
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), m.Index)
+				props, _ := m.Mappings["properties"].(map[string]interface{})
+				printFieldTree(cmd.OutOrStdout(), props, "")
+			}
+		},
+	}
+
+	return cmd
+}
+
+// printFieldTree renders mapping properties (and multi-fields) as an indented tree
+func printFieldTree(w io.Writer, props map[string]interface{}, indent string) {
+	names := make([]string, 0, len(props))
+	for name := range props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for i, name := range names {
+		def, _ := props[name].(map[string]interface{})
+		last := i == len(names)-1
+		branch, childIndent := "├── ", indent+"│   "
+		if last {
+			branch, childIndent = "└── ", indent+"    "
+		}
+
+		fmt.Fprintf(w, "%s%s%s %s\n", indent, branch, name, fieldTypeLabel(def))
+
+		if sub, ok := def["properties"].(map[string]interface{}); ok {
+			printFieldTree(w, sub, childIndent)
+		}
+		if multi, ok := def["fields"].(map[string]interface{}); ok {
+			printFieldTree(w, multi, childIndent)
+		}
+	}
+}
+
+func fieldTypeLabel(def map[string]interface{}) string {
+	t, _ := def["type"].(string)
+	if t == "" {
+		if _, ok := def["properties"]; ok {
+			t = "object"
+		} else {
+			t = "unknown"
+		}
+	}
+	label := "(" + t + ")"
+	if analyzer, ok := def["analyzer"].(string); ok {
+		label += " analyzer=" + analyzer
+	}
+	if enabled, ok := def["enabled"].(bool); ok && !enabled {
+		label += " enabled=false"
+	}
+	if index, ok := def["index"].(bool); ok && !index {
+		label += " index=false"
+	}
+	return label
+}
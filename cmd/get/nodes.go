@@ -3,6 +3,7 @@ package get
 import (
 	"fmt"
 	"os"
+	"path"
 	"sort"
 	"strconv"
 	"strings"
@@ -42,7 +43,7 @@ func NewGetNodesCmd() *cobra.Command {
   # Choose exact columns
   searchctl get nodes --columns NAME,IP,CPU,HEAP.PERCENT
 
-  # Wide output adds additional load columns automatically
+  # Wide output adds additional load columns and draining status automatically
   searchctl get nodes -o wide
         `),
 		Run: func(cmd *cobra.Command, args []string) {
@@ -73,12 +74,24 @@ func NewGetNodesCmd() *cobra.Command {
 			// Columns
 			cols := defaultColumns()
 			if viper.GetString("output") == "wide" {
-				cols = append(cols, "LOAD_5M", "LOAD_15M")
+				cols = append(cols, "LOAD_5M", "LOAD_15M", "DRAINING")
 			}
 			if columnsCSV != "" {
 				cols = parseColumns(columnsCSV)
 			}
 
+			// Draining status comes from the allocation exclude filters, so the settings
+			// are only fetched when the column is shown
+			var draining map[string]bool
+			for _, col := range cols {
+				if col == "DRAINING" {
+					if settings, err := c.GetClusterSettings(); err == nil {
+						draining = drainingNodes(settings, filtered)
+					}
+					break
+				}
+			}
+
 			// Convert to interface{} slice for formatting with deterministic column order
 			data := make([]interface{}, len(filtered))
 			pref := strings.Join(cols, ",")
@@ -86,6 +99,12 @@ func NewGetNodesCmd() *cobra.Command {
 				row := map[string]interface{}{"__columns": pref}
 				for _, col := range cols {
 					row[col] = valueForColumn(col, node)
+					if col == "DRAINING" {
+						row[col] = "-"
+						if draining[node.Name] {
+							row[col] = "yes"
+						}
+					}
 				}
 				data[i] = row
 			}
@@ -104,7 +123,7 @@ func NewGetNodesCmd() *cobra.Command {
 	cmd.Flags().StringVar(&sortBy, "sort", "", "Comma-separated sort columns (case-insensitive). Common: NAME, IP, CPU, HEAP.PERCENT, RAM.PERCENT, LOAD_1M, LOAD_5M, LOAD_15M")
 	cmd.Flags().BoolVar(&desc, "desc", false, "Sort in descending order")
 	cmd.Flags().IntVar(&limit, "limit", 0, "Limit number of rows after filtering and sorting")
-	cmd.Flags().StringVar(&columnsCSV, "columns", "", "Override table columns (CSV). Default: NAME,HOST,IP,HEAP.PERCENT,RAM.PERCENT,CPU,LOAD_1M,ROLE,MASTER. With -o wide: adds LOAD_5M,LOAD_15M")
+	cmd.Flags().StringVar(&columnsCSV, "columns", "", "Override table columns (CSV). Default: NAME,HOST,IP,HEAP.PERCENT,RAM.PERCENT,CPU,LOAD_1M,ROLE,MASTER. With -o wide: adds LOAD_5M,LOAD_15M,DRAINING. Also available: UPTIME")
 
 	return cmd
 }
@@ -212,11 +231,41 @@ func valueForColumn(col string, n pkgtypes.Node) interface{} {
 		return n.NodeRole
 	case "MASTER":
 		return n.Master
+	case "UPTIME":
+		return n.Uptime
 	default:
 		return ""
 	}
 }
 
+// drainingNodes reports the nodes matched by the allocation exclude filters on name,
+// IP or host. Filters may use wildcards.
+func drainingNodes(settings *pkgtypes.ClusterSettings, nodes []pkgtypes.Node) map[string]bool {
+	draining := make(map[string]bool)
+	for _, attr := range []string{"_name", "_ip", "_host"} {
+		value, _ := settings.Value("cluster.routing.allocation.exclude." + attr)
+		for _, pattern := range strings.Split(value, ",") {
+			pattern = strings.TrimSpace(pattern)
+			if pattern == "" {
+				continue
+			}
+			for _, n := range nodes {
+				candidate := n.Name
+				switch attr {
+				case "_ip":
+					candidate = n.IP
+				case "_host":
+					candidate = n.Host
+				}
+				if ok, _ := path.Match(pattern, candidate); ok {
+					draining[n.Name] = true
+				}
+			}
+		}
+	}
+	return draining
+}
+
 // matchesRole supports friendly role aliases and one-letter role codes from _cat/nodes
 func matchesRole(n pkgtypes.Node, roleFilter string) bool {
 	rf := strings.ToLower(strings.TrimSpace(roleFilter))
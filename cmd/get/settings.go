@@ -0,0 +1,115 @@
+package get
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewGetSettingsCmd() *cobra.Command {
+	var includeDefaults bool
+	var flat bool
+
+	cmd := &cobra.Command{
+		Use:     "settings INDEX_PATTERN",
+		Short:   "Show index settings",
+		Long:    "Show the settings of an index or indices matching a pattern. Table output lists one setting per row using dotted keys.",
+		Aliases: []string{"setting", "index-settings"},
+		Args:    cobra.ExactArgs(1),
+		Example: strings.TrimSpace(`
+  # Settings of a single index
+  searchctl get settings logs-v1
+
+  # Include cluster defaults (useful to check effective values)
+  searchctl get settings logs-v1 --include-defaults
+
+  # Flat keys in JSON output
+  searchctl get settings 'logs-*' --flat -o json
+        `),
+		Run: func(cmd *cobra.Command, args []string) {
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			settings, err := c.GetIndexSettings(args[0], includeDefaults, flat)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting index settings: %v\n", err)
+				os.Exit(1)
+			}
+
+			outFmt := viper.GetString("output")
+			if outFmt == "json" || outFmt == "yaml" {
+				formatter := output.NewFormatter(outFmt)
+				if err := formatter.Format(settings, cmd.OutOrStdout()); err != nil {
+					fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+					os.Exit(1)
+				}
+				return
+			}
+
+			var data []interface{}
+			for _, s := range settings {
+				data = append(data, settingsRows(s.Index, "explicit", s.Settings)...)
+				if includeDefaults {
+					data = append(data, settingsRows(s.Index, "default", s.Defaults)...)
+				}
+			}
+
+			formatter := output.NewFormatter(outFmt)
+			if err := formatter.Format(data, cmd.OutOrStdout()); err != nil {
+				fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().BoolVar(&includeDefaults, "include-defaults", false, "include default values for settings that are not explicitly set")
+	cmd.Flags().BoolVar(&flat, "flat", false, "return settings with flat dotted keys instead of nested objects")
+
+	return cmd
+}
+
+func settingsRows(index, source string, settings map[string]interface{}) []interface{} {
+	flat := map[string]interface{}{}
+	flattenSettings("", settings, flat)
+	keys := make([]string, 0, len(flat))
+	for k := range flat {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	rows := make([]interface{}, 0, len(keys))
+	for _, k := range keys {
+		rows = append(rows, map[string]interface{}{
+			"__columns": "INDEX,SETTING,VALUE,SOURCE",
+			"INDEX":     index,
+			"SETTING":   k,
+			"VALUE":     flat[k],
+			"SOURCE":    source,
+		})
+	}
+	return rows
+}
+
+// flattenSettings converts nested settings objects into dotted keys
+func flattenSettings(prefix string, in map[string]interface{}, out map[string]interface{}) {
+	for k, v := range in {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		if nestedMap, ok := v.(map[string]interface{}); ok {
+			flattenSettings(key, nestedMap, out)
+			continue
+		}
+		out[key] = v
+	}
+}
@@ -0,0 +1,93 @@
+package get
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewGetSnapshotPoliciesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "snapshot-policies [PATTERN]",
+		Short:   "Get snapshot policies",
+		Long:    "Get snapshot policies from the search cluster (SLM for Elasticsearch, snapshot management for OpenSearch).",
+		Aliases: []string{"snapshot-policy", "snapshotpolicies", "snapshotpolicy", "slm", "sm"},
+		Args:    cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			pattern := ""
+			if len(args) > 0 {
+				pattern = args[0]
+			}
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			policies, err := c.GetSnapshotPolicies(pattern)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting snapshot policies: %v\n", err)
+				os.Exit(1)
+			}
+
+			outFmt := viper.GetString("output")
+			if outFmt == "json" || outFmt == "yaml" {
+				if err := output.NewFormatter(outFmt).Format(policies, cmd.OutOrStdout()); err != nil {
+					fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+					os.Exit(1)
+				}
+				return
+			}
+
+			data := make([]interface{}, len(policies))
+			for i, p := range policies {
+				data[i] = map[string]interface{}{
+					"__columns":      "NAME,SCHEDULE,REPOSITORY,RETENTION,NEXT EXECUTION,LAST SUCCESS",
+					"NAME":           p.Name,
+					"SCHEDULE":       p.Schedule,
+					"REPOSITORY":     p.Repository,
+					"RETENTION":      dash(p.Retention),
+					"NEXT EXECUTION": dash(p.NextExecution),
+					"LAST SUCCESS":   lastRun(p.LastSuccess),
+				}
+			}
+
+			formatter := output.NewFormatter(outFmt)
+			if err := formatter.Format(data, cmd.OutOrStdout()); err != nil {
+				fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	return cmd
+}
+
+// lastRun summarizes an SLM last_success/last_failure object as "SNAPSHOT at TIME"
+func lastRun(run map[string]interface{}) string {
+	if len(run) == 0 {
+		return "-"
+	}
+	when := "-"
+	if s, ok := run["time_string"].(string); ok && s != "" {
+		when = s
+	} else if ms, ok := run["time"].(float64); ok {
+		when = formatMillis(int64(ms))
+	}
+	if name, ok := run["snapshot_name"].(string); ok && name != "" {
+		return name + " at " + when
+	}
+	return when
+}
+
+func dash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
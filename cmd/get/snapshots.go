@@ -0,0 +1,175 @@
+package get
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/output"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewGetSnapshotRepositoriesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "snapshot-repositories [REPOSITORY_PATTERN]",
+		Short:   "List snapshot repositories",
+		Long:    "List all registered snapshot repositories or repositories matching a pattern.",
+		Aliases: []string{"snapshot-repository", "repositories", "repos", "repo"},
+		Args:    cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			pattern := ""
+			if len(args) > 0 {
+				pattern = args[0]
+			}
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			repos, err := c.GetSnapshotRepositories(pattern)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting snapshot repositories: %v\n", err)
+				os.Exit(1)
+			}
+
+			outFmt := viper.GetString("output")
+			if outFmt == "json" || outFmt == "yaml" {
+				if err := output.NewFormatter(outFmt).Format(repos, cmd.OutOrStdout()); err != nil {
+					fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+					os.Exit(1)
+				}
+				return
+			}
+
+			data := make([]interface{}, len(repos))
+			for i, r := range repos {
+				data[i] = map[string]interface{}{
+					"__columns": "NAME,TYPE,SETTINGS",
+					"NAME":      r.Name,
+					"TYPE":      r.Type,
+					"SETTINGS":  settingsSummary(r.Settings),
+				}
+			}
+
+			formatter := output.NewFormatter(outFmt)
+			if err := formatter.Format(data, cmd.OutOrStdout()); err != nil {
+				fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	return cmd
+}
+
+func NewGetSnapshotsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "snapshots REPOSITORY [SNAPSHOT_PATTERN]",
+		Short:   "List snapshots in a repository",
+		Long:    "List snapshots in a repository with their state, shard counts, duration and size. Sizes are only reported by Elasticsearch 7.13 and later.",
+		Aliases: []string{"snapshot", "snap"},
+		Args:    cobra.RangeArgs(1, 2),
+		Run: func(cmd *cobra.Command, args []string) {
+			repository := args[0]
+			pattern := ""
+			if len(args) > 1 {
+				pattern = args[1]
+			}
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			snapshots, err := c.GetSnapshots(repository, pattern)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting snapshots: %v\n", err)
+				os.Exit(1)
+			}
+
+			outFmt := viper.GetString("output")
+			if outFmt == "json" || outFmt == "yaml" {
+				if err := output.NewFormatter(outFmt).Format(snapshots, cmd.OutOrStdout()); err != nil {
+					fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+					os.Exit(1)
+				}
+				return
+			}
+
+			data := make([]interface{}, len(snapshots))
+			for i, s := range snapshots {
+				data[i] = map[string]interface{}{
+					"__columns": "SNAPSHOT,STATE,INDICES,SHARDS,FAILED,START,DURATION,SIZE",
+					"SNAPSHOT":  s.Snapshot,
+					"STATE":     s.State,
+					"INDICES":   len(s.Indices),
+					"SHARDS":    fmt.Sprintf("%d/%d", s.Shards.Successful, s.Shards.Total),
+					"FAILED":    s.Shards.Failed,
+					"START":     formatMillis(s.StartTimeInMillis),
+					"DURATION":  snapshotDuration(s),
+					"SIZE":      snapshotSize(s),
+				}
+			}
+
+			formatter := output.NewFormatter(outFmt)
+			if err := formatter.Format(data, cmd.OutOrStdout()); err != nil {
+				fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	return cmd
+}
+
+// settingsSummary renders repository settings as sorted key=value pairs
+func settingsSummary(settings map[string]interface{}) string {
+	keys := make([]string, 0, len(settings))
+	for k := range settings {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("%s=%v", k, settings[k])
+	}
+	return truncate(strings.Join(parts, ","), 80)
+}
+
+func formatMillis(ms int64) string {
+	if ms <= 0 {
+		return "-"
+	}
+	return time.UnixMilli(ms).UTC().Format("2006-01-02T15:04:05Z")
+}
+
+// snapshotDuration is the final duration, or the time elapsed so far for running snapshots
+func snapshotDuration(s types.Snapshot) string {
+	ms := s.DurationInMillis
+	if ms == 0 && s.StartTimeInMillis > 0 && s.State == "IN_PROGRESS" {
+		ms = time.Now().UnixMilli() - s.StartTimeInMillis
+	}
+	if ms <= 0 {
+		return "-"
+	}
+	return (time.Duration(ms) * time.Millisecond).Round(time.Second).String()
+}
+
+func snapshotSize(s types.Snapshot) string {
+	if len(s.IndexDetails) == 0 {
+		return "-"
+	}
+	var total int64
+	for _, d := range s.IndexDetails {
+		total += d.SizeInBytes
+	}
+	return output.FormatBytes(total)
+}
@@ -0,0 +1,158 @@
+package get
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/output"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewGetTasksCmd() *cobra.Command {
+	var opts types.TaskListOptions
+
+	cmd := &cobra.Command{
+		Use:     "tasks",
+		Short:   "List running tasks",
+		Long:    "List tasks currently running in the cluster. Child tasks are shown indented under their parent task.",
+		Aliases: []string{"task"},
+		Example: strings.TrimSpace(`
+# List all tasks
+searchctl get tasks
+
+# Only reindex tasks, with descriptions
+searchctl get tasks --actions '*reindex*' --detailed
+
+# Tasks running on one node
+searchctl get tasks --node data-1`),
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			tasks, err := c.GetTasks(opts)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting tasks: %v\n", err)
+				os.Exit(1)
+			}
+
+			outFmt := viper.GetString("output")
+			if outFmt == "json" || outFmt == "yaml" {
+				if err := output.NewFormatter(outFmt).Format(tasks, cmd.OutOrStdout()); err != nil {
+					fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+					os.Exit(1)
+				}
+				return
+			}
+
+			columns := "TASK,ACTION,NODE,TYPE,RUNNING,CANCELLABLE"
+			if opts.Detailed {
+				columns += ",DESCRIPTION"
+			}
+			rows := groupTasks(tasks)
+			data := make([]interface{}, len(rows))
+			for i, r := range rows {
+				node := r.task.NodeName
+				if node == "" {
+					node = r.task.Node
+				}
+				row := map[string]interface{}{
+					"__columns":   columns,
+					"TASK":        taskLabel(r),
+					"ACTION":      r.task.Action,
+					"NODE":        node,
+					"TYPE":        r.task.Type,
+					"RUNNING":     formatRunningTime(r.task.RunningTimeInNanos),
+					"CANCELLABLE": r.task.Cancellable,
+				}
+				if opts.Detailed {
+					row["DESCRIPTION"] = truncate(r.task.Description, 80)
+				}
+				data[i] = row
+			}
+
+			formatter := output.NewFormatter(outFmt)
+			if err := formatter.Format(data, cmd.OutOrStdout()); err != nil {
+				fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Actions, "actions", "", "comma-separated action patterns to filter on (e.g. '*reindex*')")
+	cmd.Flags().BoolVar(&opts.Detailed, "detailed", false, "include task descriptions")
+	cmd.Flags().StringVar(&opts.Nodes, "node", "", "only list tasks running on these nodes (comma-separated names or IDs)")
+	cmd.Flags().StringVar(&opts.Parent, "parent", "", "only list children of this task")
+
+	return cmd
+}
+
+type taskRow struct {
+	task  types.TaskInfo
+	depth int
+}
+
+// groupTasks orders tasks so that children directly follow their parent.
+// Tasks whose parent is not in the list are treated as top-level tasks.
+func groupTasks(tasks []types.TaskInfo) []taskRow {
+	known := make(map[string]bool, len(tasks))
+	for _, t := range tasks {
+		known[t.TaskID()] = true
+	}
+	children := make(map[string][]types.TaskInfo)
+	var roots []types.TaskInfo
+	for _, t := range tasks {
+		if t.ParentTaskID != "" && known[t.ParentTaskID] {
+			children[t.ParentTaskID] = append(children[t.ParentTaskID], t)
+		} else {
+			roots = append(roots, t)
+		}
+	}
+
+	rows := make([]taskRow, 0, len(tasks))
+	var walk func(t types.TaskInfo, depth int)
+	walk = func(t types.TaskInfo, depth int) {
+		rows = append(rows, taskRow{task: t, depth: depth})
+		for _, child := range children[t.TaskID()] {
+			walk(child, depth+1)
+		}
+	}
+	for _, t := range roots {
+		walk(t, 0)
+	}
+	return rows
+}
+
+func taskLabel(r taskRow) string {
+	if r.depth == 0 {
+		return r.task.TaskID()
+	}
+	return strings.Repeat("   ", r.depth-1) + "└─ " + r.task.TaskID()
+}
+
+func formatRunningTime(nanos int64) string {
+	d := time.Duration(nanos)
+	switch {
+	case d >= time.Minute:
+		return d.Round(time.Second).String()
+	case d >= time.Second:
+		return d.Round(10 * time.Millisecond).String()
+	default:
+		return d.Round(time.Microsecond).String()
+	}
+}
+
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max-3] + "..."
+}
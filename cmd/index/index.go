@@ -0,0 +1,178 @@
+package index
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/output"
+	"github.com/chronicblondiee/searchctl/pkg/prompt"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewIndexCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "index",
+		Short:   "Index maintenance operations",
+		Long:    "Open, close, block, refresh, flush, force merge and clear caches of indices. All operations accept wildcard patterns.",
+		Aliases: []string{"indices", "idx"},
+	}
+
+	cmd.AddCommand(NewOpenCmd())
+	cmd.AddCommand(NewCloseCmd())
+	cmd.AddCommand(NewFreezeCmd())
+	cmd.AddCommand(NewUnfreezeCmd())
+	cmd.AddCommand(NewBlockCmd())
+	cmd.AddCommand(NewUnblockCmd())
+	cmd.AddCommand(NewRefreshCmd())
+	cmd.AddCommand(NewFlushCmd())
+	cmd.AddCommand(NewForceMergeCmd())
+	cmd.AddCommand(NewClearCacheCmd())
+
+	return cmd
+}
+
+// operation describes a per-index maintenance call
+type operation struct {
+	verb    string
+	confirm bool
+	run     func(c client.SearchClient, index string) (*types.IndexOperationResponse, error)
+}
+
+// runOperation resolves the pattern, optionally confirms, and applies op to each matching index
+func runOperation(cmd *cobra.Command, pattern string, op operation) {
+	wildcard := strings.Contains(pattern, "*")
+
+	if viper.GetBool("dry-run") {
+		if wildcard {
+			cmd.Printf("Would %s indices matching pattern: %s\n", op.verb, pattern)
+		} else {
+			cmd.Printf("Would %s index: %s\n", op.verb, pattern)
+		}
+		return
+	}
+
+	c, err := client.NewClient()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+		os.Exit(1)
+	}
+
+	targets := []string{pattern}
+	if wildcard {
+		targets, err = client.IndexNames(c, pattern)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error listing matching indices: %v\n", err)
+			os.Exit(1)
+		}
+		if len(targets) == 0 {
+			cmd.Printf("No indices match pattern: %s\n", pattern)
+			return
+		}
+		cmd.Printf("Found %d matching indices:\n", len(targets))
+		for _, idx := range targets {
+			cmd.Printf("  - %s\n", idx)
+		}
+	}
+
+	if op.confirm {
+		action := fmt.Sprintf("%s index '%s'", op.verb, pattern)
+		if wildcard {
+			action = fmt.Sprintf("%s %d indices matching pattern '%s'", op.verb, len(targets), pattern)
+		}
+		if !prompt.Confirm(cmd, action) {
+			fmt.Println("Operation cancelled.")
+			return
+		}
+	}
+
+	results := make(map[string]*types.IndexOperationResponse, len(targets))
+	var errors []string
+	for _, idx := range targets {
+		resp, err := op.run(c, idx)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("failed to %s %s: %v", op.verb, idx, err))
+			continue
+		}
+		results[idx] = resp
+	}
+
+	if len(results) > 0 {
+		if err := printResults(cmd, targets, results); err != nil {
+			fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if len(errors) > 0 {
+		fmt.Fprintf(os.Stderr, "Errors occurred:\n%s\n", strings.Join(errors, "\n"))
+		os.Exit(1)
+	}
+}
+
+func printResults(cmd *cobra.Command, targets []string, results map[string]*types.IndexOperationResponse) error {
+	outFmt := viper.GetString("output")
+	formatter := output.NewFormatter(outFmt)
+	if outFmt == "json" || outFmt == "yaml" {
+		return formatter.Format(results, cmd.OutOrStdout())
+	}
+
+	var rows, failures []interface{}
+	for _, idx := range targets {
+		resp, ok := results[idx]
+		if !ok {
+			continue
+		}
+		row := map[string]interface{}{
+			"__columns":     "INDEX,ACKNOWLEDGED,SHARDS.TOTAL,SHARDS.OK,SHARDS.FAILED",
+			"INDEX":         idx,
+			"ACKNOWLEDGED":  "-",
+			"SHARDS.TOTAL":  "-",
+			"SHARDS.OK":     "-",
+			"SHARDS.FAILED": "-",
+		}
+		if resp.Shards == nil {
+			row["ACKNOWLEDGED"] = resp.Acknowledged
+		} else {
+			row["SHARDS.TOTAL"] = resp.Shards.Total
+			row["SHARDS.OK"] = resp.Shards.Successful
+			row["SHARDS.FAILED"] = resp.Shards.Failed
+			for _, f := range resp.Shards.Failures {
+				failures = append(failures, map[string]interface{}{
+					"__columns": "INDEX,SHARD,NODE,STATUS,REASON",
+					"INDEX":     f.Index,
+					"SHARD":     f.Shard,
+					"NODE":      f.Node,
+					"STATUS":    f.Status,
+					"REASON":    failureReason(f.Reason),
+				})
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	if err := formatter.Format(rows, cmd.OutOrStdout()); err != nil {
+		return err
+	}
+	if len(failures) > 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "\nShard failures:")
+		return formatter.Format(failures, cmd.OutOrStdout())
+	}
+	return nil
+}
+
+func failureReason(reason map[string]interface{}) string {
+	t, _ := reason["type"].(string)
+	r, _ := reason["reason"].(string)
+	switch {
+	case t != "" && r != "":
+		return t + ": " + r
+	case r != "":
+		return r
+	default:
+		return t
+	}
+}
@@ -0,0 +1,46 @@
+package index
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewIndexCmd(t *testing.T) {
+	cmd := NewIndexCmd()
+
+	expected := map[string]bool{
+		"open": false, "close": false, "freeze": false, "unfreeze": false, "block": false,
+		"unblock": false, "refresh": false, "flush": false, "forcemerge": false, "clear-cache": false,
+	}
+	for _, sub := range cmd.Commands() {
+		name := strings.Fields(sub.Use)[0]
+		if _, ok := expected[name]; ok {
+			expected[name] = true
+		}
+	}
+	for name, found := range expected {
+		if !found {
+			t.Errorf("Expected subcommand '%s' not found", name)
+		}
+	}
+}
+
+func TestDisruptiveOperationsHaveYesFlag(t *testing.T) {
+	for _, c := range NewIndexCmd().Commands() {
+		switch c.Name() {
+		case "close", "freeze", "block", "forcemerge":
+			if c.Flag("yes") == nil {
+				t.Errorf("Expected -y/--yes flag on %s", c.Name())
+			}
+		}
+	}
+}
+
+func TestForceMergeFlags(t *testing.T) {
+	cmd := NewForceMergeCmd()
+	for _, name := range []string{"max-num-segments", "only-expunge-deletes"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("Expected flag %s to be defined", name)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package index
+
+import (
+	"fmt"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var validBlocks = map[string]bool{"write": true, "read": true, "read_only": true, "metadata": true}
+
+func NewOpenCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "open INDEX_NAME_OR_PATTERN",
+		Short: "Open closed indices",
+		Long:  "Open a closed index or closed indices matching a pattern.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			runOperation(cmd, args[0], operation{
+				verb: "open",
+				run: func(c client.SearchClient, index string) (*types.IndexOperationResponse, error) {
+					return c.OpenIndex(index)
+				},
+			})
+		},
+	}
+}
+
+func NewCloseCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "close INDEX_NAME_OR_PATTERN",
+		Short: "Close indices",
+		Long:  "Close an index or indices matching a pattern. Closed indices cannot be read or written until reopened.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			runOperation(cmd, args[0], operation{
+				verb:    "close",
+				confirm: true,
+				run: func(c client.SearchClient, index string) (*types.IndexOperationResponse, error) {
+					return c.CloseIndex(index)
+				},
+			})
+		},
+	}
+	cmd.Flags().BoolP("yes", "y", false, "automatically confirm without prompting")
+	return cmd
+}
+
+func NewFreezeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "freeze INDEX_NAME_OR_PATTERN",
+		Short: "Freeze indices (Elasticsearch 7.x only)",
+		Long:  "Freeze an index or indices matching a pattern. The freeze API was removed in Elasticsearch 8 and is not available on OpenSearch.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			runOperation(cmd, args[0], operation{
+				verb:    "freeze",
+				confirm: true,
+				run: func(c client.SearchClient, index string) (*types.IndexOperationResponse, error) {
+					return c.FreezeIndex(index)
+				},
+			})
+		},
+	}
+	cmd.Flags().BoolP("yes", "y", false, "automatically confirm without prompting")
+	return cmd
+}
+
+func NewUnfreezeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unfreeze INDEX_NAME_OR_PATTERN",
+		Short: "Unfreeze frozen indices (Elasticsearch 7.x only)",
+		Long:  "Unfreeze a frozen index or indices matching a pattern.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			runOperation(cmd, args[0], operation{
+				verb: "unfreeze",
+				run: func(c client.SearchClient, index string) (*types.IndexOperationResponse, error) {
+					return c.UnfreezeIndex(index)
+				},
+			})
+		},
+	}
+}
+
+func NewBlockCmd() *cobra.Command {
+	var block string
+	cmd := &cobra.Command{
+		Use:   "block INDEX_NAME_OR_PATTERN",
+		Short: "Add a block to indices",
+		Long:  "Add a write, read, read_only or metadata block to an index or indices matching a pattern.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !validBlocks[block] {
+				return fmt.Errorf("invalid block %q (write|read|read_only|metadata)", block)
+			}
+			runOperation(cmd, args[0], operation{
+				verb:    "add a " + block + " block to",
+				confirm: true,
+				run: func(c client.SearchClient, index string) (*types.IndexOperationResponse, error) {
+					return c.AddIndexBlock(index, block)
+				},
+			})
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&block, "type", "write", "block type (write|read|read_only|metadata)")
+	cmd.Flags().BoolP("yes", "y", false, "automatically confirm without prompting")
+	return cmd
+}
+
+func NewUnblockCmd() *cobra.Command {
+	var block string
+	cmd := &cobra.Command{
+		Use:   "unblock INDEX_NAME_OR_PATTERN",
+		Short: "Remove a block from indices",
+		Long:  "Remove a block from an index or indices matching a pattern by resetting the index.blocks.* setting.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !validBlocks[block] {
+				return fmt.Errorf("invalid block %q (write|read|read_only|metadata)", block)
+			}
+			runOperation(cmd, args[0], operation{
+				verb: "remove the " + block + " block from",
+				run: func(c client.SearchClient, index string) (*types.IndexOperationResponse, error) {
+					body := map[string]interface{}{"index.blocks." + block: false}
+					if err := c.UpdateIndexSettings(index, body); err != nil {
+						return nil, err
+					}
+					return &types.IndexOperationResponse{Acknowledged: true}, nil
+				},
+			})
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&block, "type", "write", "block type (write|read|read_only|metadata)")
+	return cmd
+}
+
+func NewRefreshCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "refresh INDEX_NAME_OR_PATTERN",
+		Short: "Refresh indices",
+		Long:  "Refresh an index or indices matching a pattern, making recent writes visible to search.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			runOperation(cmd, args[0], operation{
+				verb: "refresh",
+				run: func(c client.SearchClient, index string) (*types.IndexOperationResponse, error) {
+					return c.RefreshIndex(index)
+				},
+			})
+		},
+	}
+}
+
+func NewFlushCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "flush INDEX_NAME_OR_PATTERN",
+		Short: "Flush indices",
+		Long:  "Flush an index or indices matching a pattern, committing the translog to disk.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			runOperation(cmd, args[0], operation{
+				verb: "flush",
+				run: func(c client.SearchClient, index string) (*types.IndexOperationResponse, error) {
+					return c.FlushIndex(index)
+				},
+			})
+		},
+	}
+}
+
+func NewForceMergeCmd() *cobra.Command {
+	var opts types.ForceMergeOptions
+	cmd := &cobra.Command{
+		Use:     "forcemerge INDEX_NAME_OR_PATTERN",
+		Short:   "Force merge index segments",
+		Long:    "Force merge the segments of an index or indices matching a pattern. This is I/O intensive and should only run on indices that are no longer written to.",
+		Aliases: []string{"force-merge"},
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			runOperation(cmd, args[0], operation{
+				verb:    "force merge",
+				confirm: true,
+				run: func(c client.SearchClient, index string) (*types.IndexOperationResponse, error) {
+					return c.ForceMergeIndex(index, opts)
+				},
+			})
+		},
+	}
+	cmd.Flags().IntVar(&opts.MaxNumSegments, "max-num-segments", 0, "number of segments to merge to (e.g. 1)")
+	cmd.Flags().BoolVar(&opts.OnlyExpungeDeletes, "only-expunge-deletes", false, "only expunge segments containing deleted documents")
+	cmd.Flags().BoolP("yes", "y", false, "automatically confirm without prompting")
+	return cmd
+}
+
+func NewClearCacheCmd() *cobra.Command {
+	var opts types.ClearCacheOptions
+	cmd := &cobra.Command{
+		Use:   "clear-cache INDEX_NAME_OR_PATTERN",
+		Short: "Clear index caches",
+		Long:  "Clear the query, fielddata and request caches of an index or indices matching a pattern. With no cache flags all caches are cleared.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			runOperation(cmd, args[0], operation{
+				verb: "clear caches of",
+				run: func(c client.SearchClient, index string) (*types.IndexOperationResponse, error) {
+					return c.ClearIndexCache(index, opts)
+				},
+			})
+		},
+	}
+	cmd.Flags().BoolVar(&opts.Query, "query", false, "clear the query cache")
+	cmd.Flags().BoolVar(&opts.Fielddata, "fielddata", false, "clear the fielddata cache")
+	cmd.Flags().BoolVar(&opts.Request, "request", false, "clear the request cache")
+	cmd.Flags().StringSliceVar(&opts.Fields, "fields", []string{}, "limit fielddata clearing to these fields")
+	return cmd
+}
@@ -0,0 +1,200 @@
+package lint
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+const maxLabel = 40
+
+// printTree prints every pipeline as a tree of processors. Pipeline processors are
+// expanded into the pipeline they call when it was loaded.
+func printTree(w io.Writer, l *linter) {
+	for i, p := range l.pipelines {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%s (%s)\n", p.name, p.file)
+		l.treePipeline(w, p, "", map[string]bool{p.name: true})
+	}
+}
+
+func (l *linter) treePipeline(w io.Writer, p *pipelineDef, indent string, visiting map[string]bool) {
+	list, _ := p.body["processors"].([]interface{})
+	onFailure, hasFailure := p.body["on_failure"].([]interface{})
+	l.treeList(w, list, "processors", indent, !hasFailure, visiting)
+	if hasFailure {
+		fmt.Fprintf(w, "%s└── on_failure\n", indent)
+		l.treeList(w, onFailure, "on_failure", indent+"    ", true, visiting)
+	}
+}
+
+func (l *linter) treeList(w io.Writer, list []interface{}, path, indent string, last bool, visiting map[string]bool) {
+	for i, item := range list {
+		isLast := last && i == len(list)-1
+		branch, child := "├── ", "│   "
+		if isLast {
+			branch, child = "└── ", "    "
+		}
+		p, ok := parseProcessor(item, fmt.Sprintf("%s[%d]", path, i))
+		if !ok {
+			fmt.Fprintf(w, "%s%s[%d] (invalid)\n", indent, branch, i)
+			continue
+		}
+		fmt.Fprintf(w, "%s%s[%d] %s\n", indent, branch, i, describe(p))
+		l.treeChildren(w, p, indent+child, visiting)
+	}
+}
+
+// treeChildren prints what runs inside a processor: the foreach processor, the called
+// pipeline and the failure handlers
+func (l *linter) treeChildren(w io.Writer, p processor, indent string, visiting map[string]bool) {
+	onFailure, hasFailure := p.config["on_failure"].([]interface{})
+
+	if inner, ok := parseProcessor(p.config["processor"], p.path+".processor"); ok && p.typ == "foreach" {
+		branch, child := "├── ", "│   "
+		if !hasFailure {
+			branch, child = "└── ", "    "
+		}
+		fmt.Fprintf(w, "%s%s%s\n", indent, branch, describe(inner))
+		l.treeChildren(w, inner, indent+child, visiting)
+	}
+
+	if p.typ == "pipeline" {
+		name := stringOption(p.config, "name")
+		branch, child := "├── ", "│   "
+		if !hasFailure {
+			branch, child = "└── ", "    "
+		}
+		called, ok := l.byName[name]
+		switch {
+		case !ok:
+			fmt.Fprintf(w, "%s%s%s (not loaded)\n", indent, branch, name)
+		case visiting[name]:
+			fmt.Fprintf(w, "%s%s%s (cycle)\n", indent, branch, name)
+		default:
+			fmt.Fprintf(w, "%s%s%s\n", indent, branch, name)
+			visiting[name] = true
+			l.treePipeline(w, called, indent+child, visiting)
+			delete(visiting, name)
+		}
+	}
+
+	if hasFailure {
+		fmt.Fprintf(w, "%s└── on_failure\n", indent)
+		l.treeList(w, onFailure, p.path+".on_failure", indent+"    ", true, visiting)
+	}
+}
+
+// describe is a one-line summary of a processor: type, tag, main options and condition
+func describe(p processor) string {
+	parts := []string{p.typ}
+	if tag := stringOption(p.config, "tag"); tag != "" {
+		parts = append(parts, "("+tag+")")
+	}
+	for _, key := range []string{"field", "target_field", "name"} {
+		if v := stringOption(p.config, key); v != "" {
+			parts = append(parts, key+"="+v)
+		}
+	}
+	if p.config["ignore_failure"] == true {
+		parts = append(parts, "ignore_failure")
+	}
+	if cond, ok := p.config["if"].(string); ok {
+		parts = append(parts, "if "+shorten(cond))
+	}
+	return strings.Join(parts, " ")
+}
+
+func shorten(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > maxLabel {
+		return string(r[:maxLabel-3]) + "..."
+	}
+	return s
+}
+
+// printDot prints the pipelines as a Graphviz digraph. Each pipeline is a cluster;
+// dashed red edges lead to failure handlers and dotted edges to called pipelines.
+func printDot(w io.Writer, l *linter) {
+	fmt.Fprintln(w, "digraph pipelines {")
+	fmt.Fprintln(w, "  rankdir=TB;")
+	fmt.Fprintln(w, `  node [shape=box, fontname="Helvetica"];`)
+
+	var calls []string
+	for _, p := range l.pipelines {
+		start := dotID(p.name, "start")
+		fmt.Fprintf(w, "  subgraph %s {\n", dotID("cluster", p.name))
+		fmt.Fprintf(w, "    label=%s;\n", dotString(p.name))
+		fmt.Fprintf(w, "    %s [shape=oval, label=%s];\n", start, dotString(p.name))
+
+		list, _ := p.body["processors"].([]interface{})
+		calls = append(calls, l.dotChain(w, p.name, start, list, "processors", "")...)
+		if onFailure, ok := p.body["on_failure"].([]interface{}); ok {
+			calls = append(calls, l.dotChain(w, p.name, start, onFailure, "on_failure", "on_failure")...)
+		}
+		fmt.Fprintln(w, "  }")
+	}
+
+	for _, c := range calls {
+		fmt.Fprintln(w, c)
+	}
+	fmt.Fprintln(w, "}")
+}
+
+// dotChain writes a processor list as a chain of nodes starting from the node from.
+// A label marks the first edge as a failure path. It returns the edges to called
+// pipelines that were loaded, which are written outside the clusters.
+func (l *linter) dotChain(w io.Writer, pipeline, from string, list []interface{}, path, label string) []string {
+	var calls []string
+	prev := from
+	for i, item := range list {
+		p, ok := parseProcessor(item, fmt.Sprintf("%s[%d]", path, i))
+		if !ok {
+			continue
+		}
+		id := dotID(pipeline, p.path)
+		nodeLabel := p.typ
+		if tag := stringOption(p.config, "tag"); tag != "" {
+			nodeLabel += "\n" + tag
+		}
+		if cond, ok := p.config["if"].(string); ok {
+			nodeLabel += "\nif " + shorten(cond)
+		}
+		fmt.Fprintf(w, "    %s [label=%s];\n", id, dotString(nodeLabel))
+		if i == 0 && label != "" {
+			fmt.Fprintf(w, "    %s -> %s [style=dashed, color=red, label=%s];\n", prev, id, dotString(label))
+		} else {
+			fmt.Fprintf(w, "    %s -> %s;\n", prev, id)
+		}
+
+		if p.typ == "pipeline" {
+			name := stringOption(p.config, "name")
+			if _, ok := l.byName[name]; ok {
+				calls = append(calls, fmt.Sprintf("  %s -> %s [style=dotted, label=\"calls\"];", id, dotID(name, "start")))
+			}
+		}
+		if inner, ok := parseProcessor(p.config["processor"], p.path+".processor"); ok && p.typ == "foreach" {
+			innerID := dotID(pipeline, inner.path)
+			fmt.Fprintf(w, "    %s [label=%s];\n", innerID, dotString(inner.typ))
+			fmt.Fprintf(w, "    %s -> %s [label=\"each\"];\n", id, innerID)
+		}
+		if onFailure, ok := p.config["on_failure"].([]interface{}); ok {
+			calls = append(calls, l.dotChain(w, pipeline, id, onFailure, p.path+".on_failure", "on_failure")...)
+		}
+		prev = id
+	}
+	return calls
+}
+
+func dotID(parts ...string) string {
+	return dotString(strings.Join(parts, "/"))
+}
+
+func dotString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
@@ -0,0 +1,302 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/chronicblondiee/searchctl/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+func NewLintCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Check resource definitions offline",
+		Long:  "Check resource definition files for mistakes without contacting a cluster.",
+	}
+
+	cmd.AddCommand(NewLintPipelineCmd())
+
+	return cmd
+}
+
+type options struct {
+	filenames   []string
+	inputFields []string
+	graph       string
+	strict      bool
+}
+
+func NewLintPipelineCmd() *cobra.Command {
+	var opts options
+
+	cmd := &cobra.Command{
+		Use:   "pipeline -f FILE...",
+		Short: "Check ingest pipeline definitions and show their processor flow",
+		Long: `Check ingest pipeline definition files without contacting a cluster.
+
+The linter reports:
+  - unknown processor types and missing required options of common processors
+  - invalid on_failure chains, including on_failure that never runs because
+    ignore_failure is set
+  - pipeline processors naming a pipeline that is not among the given files,
+    and pipelines that call each other in a cycle
+  - fields used in 'if' conditions that are neither set by an earlier
+    processor, read by a processor, nor listed in --input-fields
+
+Files are JSON or YAML and hold a pipeline body, a kind: IngestPipeline resource
+as written by 'searchctl clone export', or the output of
+'searchctl get ingest-pipelines -o json'. A directory lints every .json, .yaml
+and .yml file in it. A pipeline body is named after its file.
+
+--graph tree or --graph dot prints the processor flow, following pipeline
+processors into the pipelines they call; problems are then printed on stderr.
+The command exits with status 1 when errors are found, or with --strict when
+warnings are found.`,
+		Aliases: []string{"pipelines", "ingest-pipeline", "ip"},
+		Example: strings.TrimSpace(`
+# Lint a pipeline
+searchctl lint pipeline -f access-logs.yaml
+
+# Lint every exported pipeline, checking references between them
+searchctl lint pipeline -f export/ingest-pipelines --strict
+
+# Render the processor flow with Graphviz
+searchctl lint pipeline -f access-logs.yaml -f geo.yaml --graph dot | dot -Tsvg > flow.svg`),
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			failed, err := run(cmd, opts)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if failed {
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&opts.filenames, "filename", "f", nil, "pipeline file or directory (repeatable)")
+	cmd.Flags().StringSliceVar(&opts.inputFields, "input-fields", nil, "fields present in incoming documents, such as message or event.* (comma-separated)")
+	cmd.Flags().StringVar(&opts.graph, "graph", "", "print the processor flow: tree or dot")
+	cmd.Flags().BoolVar(&opts.strict, "strict", false, "exit with status 1 on warnings as well as errors")
+	cmd.MarkFlagRequired("filename")
+
+	return cmd
+}
+
+// run lints the files and prints the result. It reports whether the command should fail.
+func run(cmd *cobra.Command, opts options) (bool, error) {
+	if opts.graph != "" && opts.graph != "tree" && opts.graph != "dot" {
+		return false, fmt.Errorf("--graph must be tree or dot")
+	}
+
+	var pipelines []*pipelineDef
+	for _, name := range opts.filenames {
+		loaded, err := loadPath(name)
+		if err != nil {
+			return false, err
+		}
+		pipelines = append(pipelines, loaded...)
+	}
+	if len(pipelines) == 0 {
+		return false, fmt.Errorf("no pipeline definitions found")
+	}
+
+	l := newLinter(pipelines, opts.inputFields)
+	findings := l.lint()
+
+	w := cmd.OutOrStdout()
+	switch opts.graph {
+	case "tree":
+		printTree(w, l)
+	case "dot":
+		printDot(w, l)
+	default:
+		if err := printFindings(w, findings, len(pipelines)); err != nil {
+			return false, err
+		}
+	}
+	if opts.graph != "" {
+		for _, f := range findings {
+			fmt.Fprintf(os.Stderr, "%s: %s %s: %s\n", f.Severity, f.Pipeline, f.Location, f.Message)
+		}
+	}
+
+	for _, f := range findings {
+		if f.Severity == severityError || opts.strict {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func printFindings(w io.Writer, findings []finding, pipelines int) error {
+	outFmt := viper.GetString("output")
+	if outFmt == "json" || outFmt == "yaml" {
+		if findings == nil {
+			findings = []finding{}
+		}
+		return output.NewFormatter(outFmt).Format(findings, w)
+	}
+	if len(findings) == 0 {
+		fmt.Fprintf(os.Stderr, "No problems found in %d pipeline(s)\n", pipelines)
+		return nil
+	}
+
+	rows := make([]interface{}, len(findings))
+	for i, f := range findings {
+		rows[i] = map[string]interface{}{
+			"__columns": "SEVERITY,PIPELINE,LOCATION,MESSAGE",
+			"SEVERITY":  f.Severity,
+			"PIPELINE":  f.Pipeline,
+			"LOCATION":  f.Location,
+			"MESSAGE":   f.Message,
+		}
+	}
+	return output.NewFormatter(outFmt).Format(rows, w)
+}
+
+// loadPath loads the pipelines in a file, or in every definition file of a directory
+func loadPath(path string) ([]*pipelineDef, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return loadFile(path)
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	var result []*pipelineDef
+	for _, e := range entries {
+		switch strings.ToLower(filepath.Ext(e.Name())) {
+		case ".json", ".yaml", ".yml":
+		default:
+			continue
+		}
+		if e.IsDir() {
+			continue
+		}
+		loaded, err := loadFile(filepath.Join(path, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, loaded...)
+	}
+	return result, nil
+}
+
+func loadFile(filename string) ([]*pipelineDef, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	value, err := decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", filename, err)
+	}
+	base := strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+	pipelines, err := parsePipelines(value, base)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", filename, err)
+	}
+	for _, p := range pipelines {
+		p.file = filename
+	}
+	return pipelines, nil
+}
+
+func decode(data []byte) (interface{}, error) {
+	var value interface{}
+	trimmed := bytes.TrimSpace(data)
+	if bytes.HasPrefix(trimmed, []byte("{")) || bytes.HasPrefix(trimmed, []byte("[")) {
+		if err := json.Unmarshal(trimmed, &value); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON: %w", err)
+		}
+		return value, nil
+	}
+	if err := yaml.Unmarshal(trimmed, &value); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	return value, nil
+}
+
+// parsePipelines recognizes a pipeline body, an IngestPipeline resource, a list of
+// name/body pairs and the GET _ingest/pipeline response keyed by name
+func parsePipelines(value interface{}, base string) ([]*pipelineDef, error) {
+	switch v := value.(type) {
+	case []interface{}:
+		var result []*pipelineDef
+		for i, item := range v {
+			m, _ := item.(map[string]interface{})
+			name, _ := m["name"].(string)
+			body, ok := m["body"].(map[string]interface{})
+			if name == "" || !ok {
+				return nil, fmt.Errorf("item %d is not a pipeline with name and body", i+1)
+			}
+			result = append(result, &pipelineDef{name: name, body: body})
+		}
+		return result, nil
+
+	case map[string]interface{}:
+		if kind, ok := v["kind"].(string); ok {
+			if kind != "IngestPipeline" {
+				return nil, fmt.Errorf("resource kind %s is not IngestPipeline", kind)
+			}
+			name := base
+			if meta, ok := v["metadata"].(map[string]interface{}); ok {
+				if n, ok := meta["name"].(string); ok && n != "" {
+					name = n
+				}
+			}
+			spec, ok := v["spec"].(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("IngestPipeline resource has no spec")
+			}
+			return []*pipelineDef{{name: name, body: spec}}, nil
+		}
+		if _, ok := v["processors"]; ok || len(v) == 0 {
+			return []*pipelineDef{{name: base, body: v}}, nil
+		}
+		if keyedByName(v) {
+			names := make([]string, 0, len(v))
+			for name := range v {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			result := make([]*pipelineDef, len(names))
+			for i, name := range names {
+				result[i] = &pipelineDef{name: name, body: v[name].(map[string]interface{})}
+			}
+			return result, nil
+		}
+		// Leave a body without processors to the linter to report
+		return []*pipelineDef{{name: base, body: v}}, nil
+	}
+	return nil, fmt.Errorf("not a pipeline definition")
+}
+
+func keyedByName(v map[string]interface{}) bool {
+	for _, item := range v {
+		body, ok := item.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if _, ok := body["processors"]; !ok {
+			return false
+		}
+	}
+	return true
+}
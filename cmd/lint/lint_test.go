@@ -0,0 +1,214 @@
+package lint
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewLintPipelineCmd(t *testing.T) {
+	cmd := NewLintCmd()
+	sub, _, err := cmd.Find([]string{"pipeline"})
+	if err != nil || sub.Name() != "pipeline" {
+		t.Fatalf("Expected pipeline subcommand, got %v", err)
+	}
+	for _, name := range []string{"filename", "input-fields", "graph", "strict"} {
+		if sub.Flags().Lookup(name) == nil {
+			t.Errorf("Expected flag %s", name)
+		}
+	}
+}
+
+func parse(t *testing.T, name, src string) *pipelineDef {
+	t.Helper()
+	value, err := decode([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defs, err := parsePipelines(value, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defs[0].file = name + ".yaml"
+	return defs[0]
+}
+
+func messages(findings []finding) []string {
+	var result []string
+	for _, f := range findings {
+		result = append(result, f.Severity+" "+f.Location+": "+f.Message)
+	}
+	return result
+}
+
+func TestLintProcessors(t *testing.T) {
+	p := parse(t, "access", `
+processors:
+  - grok:
+      field: message
+      patterns: ["%{IP:client.ip} %{WORD:http.method}"]
+      tag: parse
+  - convert: {field: status, type: int}
+  - set: {field: env}
+  - rename: {field: http.method, target_field: verb, ignore_failure: true, on_failure: [{set: {field: error, value: x}}]}
+  - date: {field: ts, formats: [ISO8601], tag: parse}
+  - geoip: {field: client.ip, if: "ctx.client?.ip != null && ctx.verb == 'GET'"}
+  - drop: {if: "ctx.user_agnet?.name.contains('bot')"}
+  - frobnicate: {field: x}
+  - {}
+on_failure: []
+`)
+	got := messages(newLinter([]*pipelineDef{p}, nil).lint())
+	expected := []string{
+		`error processors[1].convert: convert type "int" is not one of integer, long, float, double, string, boolean, ip or auto`,
+		`error processors[2].set: set requires value or copy_from`,
+		`warning processors[3].rename: on_failure never runs because ignore_failure is set`,
+		`warning processors[4].date: tag "parse" is also used by processors[0].grok`,
+		`warning processors[6].drop: if condition uses user_agnet.name, which is not set by an earlier processor or read from the input (see --input-fields)`,
+		`warning processors[7].frobnicate: unknown processor type "frobnicate"; options are not checked`,
+		`error processors[8]: a processor must be an object with a single processor type`,
+		`warning on_failure: on_failure is empty, so failures are not handled`,
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+	}
+}
+
+func TestLintInputFieldsAndDynamic(t *testing.T) {
+	p := parse(t, "p", `
+processors:
+  - set: {field: a, value: 1, if: "ctx.event?.kind == 'alert'"}
+  - script: {source: "ctx.b = 1"}
+  - set: {field: c, value: 1, if: "ctx.anything != null"}
+`)
+	if got := messages(newLinter([]*pipelineDef{p}, nil).lint()); len(got) != 1 {
+		t.Errorf("Expected only the event field to be reported, got %v", got)
+	}
+	if got := newLinter([]*pipelineDef{p}, []string{"event.*"}).lint(); len(got) != 0 {
+		t.Errorf("Expected no findings with --input-fields, got %v", messages(got))
+	}
+}
+
+func TestLintPipelineReferences(t *testing.T) {
+	a := parse(t, "a", `{"processors":[{"pipeline":{"name":"b"}},{"pipeline":{"name":"missing"}},{"pipeline":{"name":"{{service}}"}}]}`)
+	b := parse(t, "b", `{"processors":[{"pipeline":{"name":"a"}}]}`)
+
+	got := messages(newLinter([]*pipelineDef{a, b}, nil).lint())
+	expected := []string{
+		`warning processors[1].pipeline: pipeline "missing" is not defined in the linted files`,
+		`error : pipeline processors form a cycle: a -> b -> a`,
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestConditionFields(t *testing.T) {
+	got := conditionFields(`ctx.event?.kind == 'alert' && ctx['host']['name'] != null && ctx.tags.contains('x') && myctx.y && ctx?.url?.path.startsWith('/')`)
+	expected := []string{"event.kind", "host.name", "tags", "url.path"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestOutputs(t *testing.T) {
+	tests := []struct {
+		typ      string
+		config   map[string]interface{}
+		expected []string
+		dynamic  bool
+	}{
+		{"grok", map[string]interface{}{"patterns": []interface{}{"%{IP:client.ip} %{NUMBER:bytes:int} (?<verb>\\w+)"}}, []string{"client.ip", "bytes", "verb"}, false},
+		{"dissect", map[string]interface{}{"pattern": "%{+ts} %{?skip} %{&skip} %{msg->}"}, []string{"ts", "skip", "skip", "msg"}, false},
+		{"geoip", map[string]interface{}{"field": "ip"}, []string{"geoip"}, false},
+		{"kv", map[string]interface{}{"field": "m"}, nil, true},
+		{"script", map[string]interface{}{"source": ""}, nil, true},
+	}
+	for _, tt := range tests {
+		fields, dynamic := outputs(tt.typ, tt.config)
+		if !reflect.DeepEqual(fields, tt.expected) || dynamic != tt.dynamic {
+			t.Errorf("%s: expected %v/%v, got %v/%v", tt.typ, tt.expected, tt.dynamic, fields, dynamic)
+		}
+	}
+}
+
+func TestParsePipelines(t *testing.T) {
+	resource := parse(t, "file", "kind: IngestPipeline\nmetadata:\n  name: logs\nspec:\n  processors: []\n")
+	if resource.name != "logs" {
+		t.Errorf("Expected name from metadata, got %s", resource.name)
+	}
+
+	value, _ := decode([]byte(`{"b":{"processors":[]},"a":{"processors":[]}}`))
+	defs, err := parsePipelines(value, "file")
+	if err != nil || len(defs) != 2 || defs[0].name != "a" {
+		t.Errorf("Expected pipelines keyed by name, got %v (%v)", defs, err)
+	}
+
+	value, _ = decode([]byte(`[{"name":"x","body":{"processors":[]}}]`))
+	if defs, err := parsePipelines(value, "file"); err != nil || defs[0].name != "x" {
+		t.Errorf("Expected get -o json output to parse, got %v (%v)", defs, err)
+	}
+
+	value, _ = decode([]byte("kind: IndexTemplate\nspec: {}\n"))
+	if _, err := parsePipelines(value, "file"); err == nil {
+		t.Error("Expected error for another resource kind")
+	}
+}
+
+func TestLoadPathDirectory(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "one.json"), []byte(`{"processors":[]}`), 0644)
+	os.WriteFile(filepath.Join(dir, "two.yml"), []byte("processors: []\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "README.md"), []byte("# notes"), 0644)
+
+	defs, err := loadPath(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(defs) != 2 || defs[0].name != "one" || defs[1].name != "two" {
+		t.Errorf("Expected pipelines one and two, got %v", defs)
+	}
+}
+
+func TestGraphs(t *testing.T) {
+	main := parse(t, "main", `
+processors:
+  - grok: {field: message, patterns: ["%{WORD:w}"], on_failure: [{set: {field: error, value: x}}]}
+  - pipeline: {name: geo, if: "ctx.w != null"}
+`)
+	geo := parse(t, "geo", `{"processors":[{"geoip":{"field":"ip"}}]}`)
+	l := newLinter([]*pipelineDef{main, geo}, nil)
+
+	var tree bytes.Buffer
+	printTree(&tree, l)
+	expected := `main (main.yaml)
+├── [0] grok field=message
+│   └── on_failure
+│       └── [0] set field=error
+└── [1] pipeline name=geo if ctx.w != null
+    └── geo
+        └── [0] geoip field=ip
+
+geo (geo.yaml)
+└── [0] geoip field=ip
+`
+	if tree.String() != expected {
+		t.Errorf("Expected tree:\n%s\ngot:\n%s", expected, tree.String())
+	}
+
+	var dot bytes.Buffer
+	printDot(&dot, l)
+	for _, want := range []string{
+		`subgraph "cluster/main" {`,
+		`"main/processors[0].grok" -> "main/processors[0].grok.on_failure[0].set" [style=dashed, color=red, label="on_failure"];`,
+		`"main/processors[1].pipeline" [label="pipeline\nif ctx.w != null"];`,
+		`"main/processors[1].pipeline" -> "geo/start" [style=dotted, label="calls"];`,
+	} {
+		if !strings.Contains(dot.String(), want) {
+			t.Errorf("Expected DOT output to contain %s, got:\n%s", want, dot.String())
+		}
+	}
+}
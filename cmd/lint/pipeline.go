@@ -0,0 +1,432 @@
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	severityError   = "error"
+	severityWarning = "warning"
+)
+
+type finding struct {
+	Severity string `json:"severity"`
+	Pipeline string `json:"pipeline"`
+	Location string `json:"location"`
+	Message  string `json:"message"`
+}
+
+type pipelineDef struct {
+	name string
+	file string
+	body map[string]interface{}
+}
+
+// processor is one entry of a processors or on_failure list
+type processor struct {
+	typ    string
+	config map[string]interface{}
+	path   string
+}
+
+// linter checks a set of pipelines that may call each other
+type linter struct {
+	pipelines   []*pipelineDef
+	byName      map[string]*pipelineDef
+	inputFields []string
+	findings    []finding
+}
+
+func newLinter(pipelines []*pipelineDef, inputFields []string) *linter {
+	l := &linter{pipelines: pipelines, byName: map[string]*pipelineDef{}, inputFields: inputFields}
+	for _, p := range pipelines {
+		l.byName[p.name] = p
+	}
+	return l
+}
+
+// fieldState tracks the fields known to exist at a point in a pipeline
+type fieldState struct {
+	fields  []string
+	dynamic bool
+}
+
+func (s *fieldState) add(fields ...string) {
+	for _, f := range fields {
+		if f != "" {
+			s.fields = append(s.fields, f)
+		}
+	}
+}
+
+func (s *fieldState) copy() *fieldState {
+	return &fieldState{fields: append([]string(nil), s.fields...), dynamic: s.dynamic}
+}
+
+// defines reports whether ref is a known field, a parent or child of one, or matches
+// a pattern ending in '*'
+func (s *fieldState) defines(ref string) bool {
+	if s.dynamic || strings.HasPrefix(ref, "_") {
+		return true
+	}
+	for _, f := range s.fields {
+		if strings.HasSuffix(f, "*") {
+			if strings.HasPrefix(ref, strings.TrimSuffix(f, "*")) {
+				return true
+			}
+			continue
+		}
+		if ref == f || strings.HasPrefix(ref, f+".") || strings.HasPrefix(f, ref+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *linter) lint() []finding {
+	seen := map[string]string{}
+	for _, p := range l.pipelines {
+		if other, ok := seen[p.name]; ok {
+			l.report(severityError, p.name, "", "pipeline %q is also defined in %s", p.name, other)
+		}
+		seen[p.name] = p.file
+		l.lintPipeline(p)
+	}
+	l.checkCycles()
+	return l.findings
+}
+
+func (l *linter) report(severity, pipeline, location, format string, args ...interface{}) {
+	l.findings = append(l.findings, finding{
+		Severity: severity,
+		Pipeline: pipeline,
+		Location: location,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (l *linter) lintPipeline(p *pipelineDef) {
+	list, ok := p.body["processors"].([]interface{})
+	if !ok {
+		l.report(severityError, p.name, "processors", "processors is missing or not a list")
+		return
+	}
+	if len(list) == 0 {
+		l.report(severityWarning, p.name, "processors", "pipeline has no processors")
+	}
+
+	state := &fieldState{}
+	state.add(l.inputFields...)
+	state.add(reads(p.body)...)
+	tags := map[string]string{}
+	l.lintList(p.name, list, "processors", state, tags)
+
+	if raw, ok := p.body["on_failure"]; ok {
+		l.lintOnFailure(p.name, raw, "on_failure", state, tags)
+	}
+}
+
+// lintList checks a processor list in order, adding the fields each processor sets
+func (l *linter) lintList(pipeline string, list []interface{}, path string, state *fieldState, tags map[string]string) {
+	for i, item := range list {
+		proc, ok := parseProcessor(item, fmt.Sprintf("%s[%d]", path, i))
+		if !ok {
+			l.report(severityError, pipeline, fmt.Sprintf("%s[%d]", path, i), "a processor must be an object with a single processor type")
+			continue
+		}
+		l.lintProcessor(pipeline, proc, state, tags)
+	}
+}
+
+func parseProcessor(item interface{}, path string) (processor, bool) {
+	m, ok := item.(map[string]interface{})
+	if !ok || len(m) != 1 {
+		return processor{}, false
+	}
+	for typ, v := range m {
+		config, ok := v.(map[string]interface{})
+		if !ok {
+			return processor{}, false
+		}
+		return processor{typ: typ, config: config, path: path + "." + typ}, true
+	}
+	return processor{}, false
+}
+
+func (l *linter) lintProcessor(pipeline string, p processor, state *fieldState, tags map[string]string) {
+	spec, known := processorSpecs[p.typ]
+	if !known {
+		l.report(severityWarning, pipeline, p.path, "unknown processor type %q; options are not checked", p.typ)
+	}
+	for _, req := range spec.required {
+		if !hasAny(p.config, strings.Split(req, "|")) {
+			l.report(severityError, pipeline, p.path, "%s requires %s", p.typ, strings.ReplaceAll(req, "|", " or "))
+		}
+	}
+
+	if tag, ok := p.config["tag"].(string); ok && tag != "" {
+		if other, dup := tags[tag]; dup {
+			l.report(severityWarning, pipeline, p.path, "tag %q is also used by %s", tag, other)
+		} else {
+			tags[tag] = p.path
+		}
+	}
+
+	switch p.typ {
+	case "convert":
+		if t, ok := p.config["type"].(string); ok && !convertTypes[t] {
+			l.report(severityError, pipeline, p.path, "convert type %q is not one of integer, long, float, double, string, boolean, ip or auto", t)
+		}
+	case "grok":
+		if v, ok := p.config["patterns"]; ok && len(stringList(v)) == 0 {
+			l.report(severityError, pipeline, p.path, "grok patterns must be a non-empty list")
+		}
+	case "pipeline":
+		if name, ok := p.config["name"].(string); ok && !strings.Contains(name, "{{") {
+			if _, found := l.byName[name]; !found {
+				l.report(severityWarning, pipeline, p.path, "pipeline %q is not defined in the linted files", name)
+			}
+		}
+	case "foreach":
+		if inner, ok := p.config["processor"]; ok {
+			innerProc, ok := parseProcessor(inner, p.path+".processor")
+			if !ok {
+				l.report(severityError, pipeline, p.path+".processor", "a processor must be an object with a single processor type")
+			} else {
+				l.lintProcessor(pipeline, innerProc, state.copy(), tags)
+			}
+		}
+	}
+
+	switch cond := p.config["if"].(type) {
+	case nil:
+	case string:
+		for _, ref := range conditionFields(cond) {
+			if !state.defines(ref) {
+				l.report(severityWarning, pipeline, p.path, "if condition uses %s, which is not set by an earlier processor or read from the input (see --input-fields)", ref)
+			}
+		}
+	case map[string]interface{}:
+		// A script object with source, lang and params
+		if src, ok := cond["source"].(string); ok {
+			for _, ref := range conditionFields(src) {
+				if !state.defines(ref) {
+					l.report(severityWarning, pipeline, p.path, "if condition uses %s, which is not set by an earlier processor or read from the input (see --input-fields)", ref)
+				}
+			}
+		}
+	default:
+		l.report(severityError, pipeline, p.path, "if must be a script string")
+	}
+
+	if raw, ok := p.config["on_failure"]; ok {
+		if p.config["ignore_failure"] == true {
+			l.report(severityWarning, pipeline, p.path, "on_failure never runs because ignore_failure is set")
+		}
+		l.lintOnFailure(pipeline, raw, p.path+".on_failure", state, tags)
+	}
+
+	fields, dynamic := outputs(p.typ, p.config)
+	if p.typ == "pipeline" {
+		name, _ := p.config["name"].(string)
+		fields, dynamic = l.pipelineOutputs(name, map[string]bool{})
+	}
+	state.add(fields...)
+	state.dynamic = state.dynamic || dynamic
+}
+
+func (l *linter) lintOnFailure(pipeline string, raw interface{}, path string, state *fieldState, tags map[string]string) {
+	list, ok := raw.([]interface{})
+	if !ok {
+		l.report(severityError, pipeline, path, "on_failure must be a list of processors")
+		return
+	}
+	if len(list) == 0 {
+		l.report(severityWarning, pipeline, path, "on_failure is empty, so failures are not handled")
+		return
+	}
+	// Failure handlers see the fields set so far and add their own only on their branch
+	handler := state.copy()
+	handler.add("_ingest")
+	l.lintList(pipeline, list, path, handler, tags)
+}
+
+// pipelineOutputs returns every field a called pipeline may set. Pipelines that are not
+// loaded are already reported, so they are assumed to set nothing rather than hide
+// mistakes in later conditions; templated names may call any pipeline.
+func (l *linter) pipelineOutputs(name string, visiting map[string]bool) ([]string, bool) {
+	if strings.Contains(name, "{{") {
+		return nil, true
+	}
+	p, ok := l.byName[name]
+	if !ok || visiting[name] {
+		return nil, false
+	}
+	visiting[name] = true
+	defer delete(visiting, name)
+
+	var fields []string
+	dynamic := false
+	for _, proc := range allProcessors(p.body) {
+		var f []string
+		var d bool
+		if proc.typ == "pipeline" {
+			f, d = l.pipelineOutputs(stringOption(proc.config, "name"), visiting)
+		} else {
+			f, d = outputs(proc.typ, proc.config)
+		}
+		fields = append(fields, f...)
+		dynamic = dynamic || d
+	}
+	return fields, dynamic
+}
+
+// checkCycles reports pipelines that call themselves through pipeline processors
+func (l *linter) checkCycles() {
+	reported := map[string]bool{}
+	var visit func(name string, stack []string)
+	visit = func(name string, stack []string) {
+		for i, s := range stack {
+			if s == name {
+				cycle := append(append([]string(nil), stack[i:]...), name)
+				key := canonicalCycle(cycle[:len(cycle)-1])
+				if !reported[key] {
+					reported[key] = true
+					l.report(severityError, cycle[0], "", "pipeline processors form a cycle: %s", strings.Join(cycle, " -> "))
+				}
+				return
+			}
+		}
+		p, ok := l.byName[name]
+		if !ok {
+			return
+		}
+		stack = append(stack, name)
+		for _, proc := range allProcessors(p.body) {
+			if proc.typ == "pipeline" {
+				visit(stringOption(proc.config, "name"), stack)
+			}
+		}
+	}
+	for _, p := range l.pipelines {
+		visit(p.name, nil)
+	}
+}
+
+// canonicalCycle identifies a cycle independently of where it was entered
+func canonicalCycle(names []string) string {
+	sorted := append([]string(nil), names...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
+
+// allProcessors returns every processor of a pipeline, including failure handlers and
+// the processors of foreach
+func allProcessors(body map[string]interface{}) []processor {
+	var result []processor
+	var walk func(list interface{}, path string)
+	var add func(p processor)
+	add = func(p processor) {
+		result = append(result, p)
+		if inner, ok := parseProcessor(p.config["processor"], p.path+".processor"); ok && p.typ == "foreach" {
+			add(inner)
+		}
+		walk(p.config["on_failure"], p.path+".on_failure")
+	}
+	walk = func(list interface{}, path string) {
+		items, _ := list.([]interface{})
+		for i, item := range items {
+			if p, ok := parseProcessor(item, fmt.Sprintf("%s[%d]", path, i)); ok {
+				add(p)
+			}
+		}
+	}
+	walk(body["processors"], "processors")
+	walk(body["on_failure"], "on_failure")
+	return result
+}
+
+// reads returns the fields processors read, which must come from the input or an
+// earlier processor
+func reads(body map[string]interface{}) []string {
+	var fields []string
+	for _, p := range allProcessors(body) {
+		switch p.typ {
+		case "set", "append":
+			// field is where these write
+		default:
+			fields = append(fields, stringOption(p.config, "field"))
+		}
+		fields = append(fields, stringOption(p.config, "source_field"))
+		if p.typ == "fingerprint" {
+			fields = append(fields, stringList(p.config["fields"])...)
+		}
+	}
+	return fields
+}
+
+func hasAny(config map[string]interface{}, keys []string) bool {
+	for _, k := range keys {
+		if v, ok := config[k]; ok && v != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// conditionFields returns the document fields a Painless condition reads through ctx,
+// such as ctx.event?.kind or ctx['host']['name']. Method calls end a path.
+func conditionFields(src string) []string {
+	var fields []string
+	seen := map[string]bool{}
+	for i := 0; i < len(src); i++ {
+		if !strings.HasPrefix(src[i:], "ctx") || (i > 0 && isIdent(src[i-1])) {
+			continue
+		}
+		pos := i + 3
+		var segments []string
+		for {
+			rest := src[pos:]
+			rest = strings.TrimPrefix(rest, "?")
+			skip := len(src[pos:]) - len(rest)
+			if strings.HasPrefix(rest, ".") {
+				j := 1
+				for j < len(rest) && isIdent(rest[j]) {
+					j++
+				}
+				name := rest[1:j]
+				if name == "" || strings.HasPrefix(strings.TrimLeft(rest[j:], " "), "(") {
+					break
+				}
+				segments = append(segments, name)
+				pos += skip + j
+				continue
+			}
+			if strings.HasPrefix(rest, "[") && len(rest) > 2 && (rest[1] == '\'' || rest[1] == '"') {
+				end := strings.IndexByte(rest[2:], rest[1])
+				if end < 0 || !strings.HasPrefix(rest[2+end+1:], "]") {
+					break
+				}
+				segments = append(segments, rest[2:2+end])
+				pos += skip + 2 + end + 2
+				continue
+			}
+			break
+		}
+		if len(segments) > 0 {
+			field := strings.Join(segments, ".")
+			if !seen[field] {
+				seen[field] = true
+				fields = append(fields, field)
+			}
+		}
+		i = pos - 1
+	}
+	return fields
+}
+
+func isIdent(c byte) bool {
+	return c == '_' || c == '@' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
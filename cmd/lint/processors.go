@@ -0,0 +1,143 @@
+package lint
+
+import (
+	"regexp"
+	"strings"
+)
+
+// processorSpec describes what the linter knows about a processor type
+type processorSpec struct {
+	// required lists the options that must be set. Alternatives are separated by '|'.
+	required []string
+	// target is the default output field for processors that write to a new field
+	target string
+	// dynamic processors may set fields that cannot be known offline
+	dynamic bool
+}
+
+var processorSpecs = map[string]processorSpec{
+	"append":            {required: []string{"field", "value|copy_from"}},
+	"attachment":        {required: []string{"field"}, target: "attachment"},
+	"bytes":             {required: []string{"field"}},
+	"circle":            {required: []string{"field", "error_distance", "shape_type"}},
+	"community_id":      {target: "network.community_id"},
+	"convert":           {required: []string{"field", "type"}},
+	"copy":              {required: []string{"source_field", "target_field"}},
+	"csv":               {required: []string{"field", "target_fields"}},
+	"date":              {required: []string{"field", "formats"}, target: "@timestamp"},
+	"date_index_name":   {required: []string{"field", "date_rounding"}},
+	"dissect":           {required: []string{"field", "pattern"}},
+	"dot_expander":      {required: []string{"field"}},
+	"drop":              {},
+	"enrich":            {required: []string{"policy_name", "field", "target_field"}},
+	"fail":              {required: []string{"message"}},
+	"fingerprint":       {required: []string{"fields"}, target: "fingerprint"},
+	"foreach":           {required: []string{"field", "processor"}},
+	"geoip":             {required: []string{"field"}, target: "geoip"},
+	"grok":              {required: []string{"field", "patterns"}},
+	"gsub":              {required: []string{"field", "pattern", "replacement"}},
+	"html_strip":        {required: []string{"field"}},
+	"inference":         {required: []string{"model_id"}, dynamic: true},
+	"join":              {required: []string{"field", "separator"}},
+	"json":              {required: []string{"field"}},
+	"kv":                {required: []string{"field", "field_split", "value_split"}},
+	"lowercase":         {required: []string{"field"}},
+	"network_direction": {target: "network.direction"},
+	"pipeline":          {required: []string{"name"}},
+	"redact":            {required: []string{"field", "patterns"}},
+	"registered_domain": {required: []string{"field", "target_field"}},
+	"remove":            {required: []string{"field|keep"}},
+	"rename":            {required: []string{"field", "target_field"}},
+	"reroute":           {},
+	"script":            {required: []string{"source|id"}, dynamic: true},
+	"set":               {required: []string{"field", "value|copy_from"}},
+	"set_security_user": {required: []string{"field"}},
+	"sort":              {required: []string{"field"}},
+	"split":             {required: []string{"field", "separator"}},
+	"text_embedding":    {required: []string{"model_id", "field_map"}, dynamic: true},
+	"trim":              {required: []string{"field"}},
+	"uppercase":         {required: []string{"field"}},
+	"uri_parts":         {required: []string{"field"}, target: "url"},
+	"urldecode":         {required: []string{"field"}},
+	"user_agent":        {required: []string{"field"}, target: "user_agent"},
+}
+
+var convertTypes = map[string]bool{
+	"integer": true, "long": true, "float": true, "double": true,
+	"string": true, "boolean": true, "ip": true, "auto": true,
+}
+
+var (
+	grokField     = regexp.MustCompile(`%\{[A-Za-z0-9_]+:([^:}]+)(?::[a-z]+)?\}`)
+	grokNamed     = regexp.MustCompile(`\(\?<([A-Za-z0-9_.@\[\]]+)>`)
+	dissectField  = regexp.MustCompile(`%\{([^}]*)\}`)
+	dissectPrefix = regexp.MustCompile(`^[+?*&]+`)
+)
+
+// outputs returns the fields a processor writes and whether it may write fields that
+// cannot be determined offline
+func outputs(typ string, config map[string]interface{}) ([]string, bool) {
+	spec := processorSpecs[typ]
+	var fields []string
+	if target, ok := config["target_field"].(string); ok && target != "" {
+		fields = append(fields, target)
+	} else if spec.target != "" {
+		fields = append(fields, spec.target)
+	}
+
+	switch typ {
+	case "set", "append":
+		fields = append(fields, stringOption(config, "field"))
+	case "csv":
+		fields = append(fields, stringList(config["target_fields"])...)
+	case "grok":
+		for _, p := range stringList(config["patterns"]) {
+			for _, m := range grokField.FindAllStringSubmatch(p, -1) {
+				fields = append(fields, m[1])
+			}
+			for _, m := range grokNamed.FindAllStringSubmatch(p, -1) {
+				fields = append(fields, m[1])
+			}
+		}
+	case "dissect":
+		for _, m := range dissectField.FindAllStringSubmatch(stringOption(config, "pattern"), -1) {
+			name := dissectPrefix.ReplaceAllString(m[1], "")
+			name = strings.SplitN(name, "->", 2)[0]
+			name = strings.SplitN(name, "/", 2)[0]
+			if name != "" {
+				fields = append(fields, name)
+			}
+		}
+	case "kv":
+		// Without a target field the keys are added at the root of the document
+		return fields, config["target_field"] == nil
+	case "json":
+		// Parsed objects replace the source field unless they are added to the root
+		fields = append(fields, stringOption(config, "field"))
+		return fields, config["add_to_root"] == true
+	case "date_index_name", "reroute":
+		fields = append(fields, "_index")
+	}
+	return fields, spec.dynamic
+}
+
+func stringOption(config map[string]interface{}, key string) string {
+	s, _ := config[key].(string)
+	return s
+}
+
+func stringList(v interface{}) []string {
+	switch list := v.(type) {
+	case string:
+		return []string{list}
+	case []interface{}:
+		var result []string
+		for _, item := range list {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	}
+	return nil
+}
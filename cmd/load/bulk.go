@@ -0,0 +1,180 @@
+package load
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+)
+
+const maxBackoff = 30 * time.Second
+
+// stats are updated concurrently by the workers and read by the progress printer
+type stats struct {
+	read    atomic.Int64
+	indexed atomic.Int64
+	failed  atomic.Int64
+	retries atomic.Int64
+	bytes   atomic.Int64
+}
+
+// loader sends batches of documents with the _bulk API
+type loader struct {
+	client     client.SearchClient
+	index      string
+	action     string
+	opts       types.DocumentOptions
+	maxRetries int
+	backoff    time.Duration
+	rejects    *rejectWriter
+	stats      *stats
+}
+
+// buildBulkBody renders documents as NDJSON action and source line pairs
+func buildBulkBody(action string, docs []*document) []byte {
+	var buf bytes.Buffer
+	for _, d := range docs {
+		meta := map[string]interface{}{}
+		if d.id != "" {
+			meta["_id"] = d.id
+		}
+		line, _ := json.Marshal(map[string]interface{}{action: meta})
+		buf.Write(line)
+		buf.WriteByte('\n')
+		buf.Write(d.source)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+// send delivers one batch. Whole requests and individual items rejected with 429
+// are retried with exponential backoff; other item failures go to the rejects file.
+func (l *loader) send(docs []*document) {
+	pending := docs
+	for attempt := 0; ; attempt++ {
+		resp, err := l.client.Bulk(l.index, buildBulkBody(l.action, pending), l.opts)
+		if errors.Is(err, client.ErrTooManyRequests) {
+			if attempt >= l.maxRetries {
+				l.rejectAll(pending, http.StatusTooManyRequests, fmt.Sprintf("still rejected after %d retries", l.maxRetries))
+				return
+			}
+			l.stats.retries.Add(1)
+			time.Sleep(backoffFor(l.backoff, attempt))
+			continue
+		}
+		if err != nil {
+			l.rejectAll(pending, 0, err.Error())
+			return
+		}
+		if len(resp.Items) != len(pending) {
+			l.rejectAll(pending, 0, fmt.Sprintf("bulk response has %d items for %d documents", len(resp.Items), len(pending)))
+			return
+		}
+
+		var retry []*document
+		var retryItems []types.BulkItem
+		for i, item := range resp.Items {
+			switch {
+			case item.Status == http.StatusTooManyRequests:
+				retry = append(retry, pending[i])
+				retryItems = append(retryItems, item)
+			case item.Status >= 300 || item.Error != nil:
+				l.reject(pending[i], item.Status, item.Error)
+			default:
+				l.stats.indexed.Add(1)
+			}
+		}
+		if len(retry) == 0 {
+			return
+		}
+		if attempt >= l.maxRetries {
+			for i, d := range retry {
+				l.reject(d, retryItems[i].Status, retryItems[i].Error)
+			}
+			return
+		}
+		l.stats.retries.Add(1)
+		pending = retry
+		time.Sleep(backoffFor(l.backoff, attempt))
+	}
+}
+
+func (l *loader) rejectAll(docs []*document, status int, reason string) {
+	for _, d := range docs {
+		l.reject(d, status, map[string]interface{}{"reason": reason})
+	}
+}
+
+func (l *loader) reject(d *document, status int, reason interface{}) {
+	l.stats.failed.Add(1)
+	l.rejects.write(d.record, status, reason, d.source)
+}
+
+// backoffFor doubles the initial delay on every attempt, capped at maxBackoff
+func backoffFor(initial time.Duration, attempt int) time.Duration {
+	d := initial
+	for i := 0; i < attempt && d < maxBackoff; i++ {
+		d *= 2
+	}
+	if d > maxBackoff {
+		d = maxBackoff
+	}
+	return d
+}
+
+// rejectWriter appends failed records to an NDJSON file, creating it on first use
+type rejectWriter struct {
+	path  string
+	mu    sync.Mutex
+	file  *os.File
+	count int
+	err   error
+}
+
+func (w *rejectWriter) write(record, status int, reason interface{}, source []byte) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.count++
+	if w.err != nil {
+		return
+	}
+	if w.file == nil {
+		w.file, w.err = os.Create(w.path)
+		if w.err != nil {
+			return
+		}
+	}
+
+	entry := map[string]interface{}{"record": record, "error": reason}
+	if status != 0 {
+		entry["status"] = status
+	}
+	if json.Valid(source) {
+		entry["document"] = json.RawMessage(source)
+	} else {
+		entry["document"] = string(source)
+	}
+	line, _ := json.Marshal(entry)
+	if _, err := w.file.Write(append(line, '\n')); err != nil {
+		w.err = err
+	}
+}
+
+func (w *rejectWriter) close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.file != nil {
+		if err := w.file.Close(); err != nil && w.err == nil {
+			w.err = err
+		}
+	}
+	return w.err
+}
@@ -0,0 +1,315 @@
+package load
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/output"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type options struct {
+	filename    string
+	format      string
+	batchSize   int
+	batchBytes  string
+	workers     int
+	idField     string
+	opType      string
+	pipeline    string
+	routing     string
+	csvFields   []string
+	maxRetries  int
+	backoff     time.Duration
+	rejectsPath string
+	refresh     bool
+	interval    time.Duration
+}
+
+func NewLoadCmd() *cobra.Command {
+	var opts options
+
+	cmd := &cobra.Command{
+		Use:   "load INDEX",
+		Short: "Bulk load documents from a file into an index or data stream",
+		Long: `Load documents from an NDJSON, JSON or CSV file into INDEX using the _bulk API.
+
+Documents are grouped into bulk requests of at most --batch-size documents and
+--batch-bytes bytes, sent by --workers parallel workers. When the cluster
+rejects a request or individual documents with 429 Too Many Requests, they are
+retried with exponential backoff. Documents that still fail, and input records
+that cannot be parsed, are written to the rejects file together with the error.
+
+The format is detected from the file extension (.ndjson, .jsonl, .json, .csv,
+.tsv) or, for stdin, from the first character. JSON input may be an array of
+objects or a stream of objects. CSV input needs a header row; every column is
+loaded as a string unless mapped with --csv-field COLUMN[=FIELD][:TYPE], where
+TYPE is string, int, float, bool, json or skip. Empty cells are left out.
+
+Data streams only accept the create action, which is used automatically.`,
+		Example: strings.TrimSpace(`
+# Load an NDJSON file with 4 workers
+searchctl load products -f products.ndjson --workers 4
+
+# Use a field as the document id and run documents through a pipeline
+searchctl load orders -f orders.json --id-field order_id --pipeline enrich-orders
+
+# Map CSV columns to typed fields, renaming one of them
+searchctl load sales -f sales.csv --csv-field qty:int --csv-field price=unit_price:float --csv-field notes:skip
+
+# Pipe generated data into a data stream
+./gen-events | searchctl load logs-app-default -f -`),
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			index := args[0]
+			if err := run(cmd, index, opts); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.filename, "filename", "f", "", "input file (- for stdin)")
+	cmd.Flags().StringVar(&opts.format, "format", "auto", "input format: auto, ndjson, json, csv or tsv")
+	cmd.Flags().IntVar(&opts.batchSize, "batch-size", 1000, "maximum documents per bulk request")
+	cmd.Flags().StringVar(&opts.batchBytes, "batch-bytes", "5mb", "maximum size of a bulk request")
+	cmd.Flags().IntVar(&opts.workers, "workers", 2, "number of bulk requests sent in parallel")
+	cmd.Flags().StringVar(&opts.idField, "id-field", "", "take the document id from this field (dotted paths allowed)")
+	cmd.Flags().StringVar(&opts.opType, "op-type", "", "bulk action: index (create or replace) or create (fail if the id exists)")
+	cmd.Flags().StringVar(&opts.pipeline, "pipeline", "", "ingest pipeline to run the documents through")
+	cmd.Flags().StringVar(&opts.routing, "routing", "", "custom routing value for all documents")
+	cmd.Flags().StringArrayVar(&opts.csvFields, "csv-field", nil, "map a CSV column: COLUMN[=FIELD][:TYPE] (repeatable)")
+	cmd.Flags().IntVar(&opts.maxRetries, "max-retries", 5, "retries for documents rejected with 429")
+	cmd.Flags().DurationVar(&opts.backoff, "retry-backoff", time.Second, "initial delay before retrying, doubled on every attempt")
+	cmd.Flags().StringVar(&opts.rejectsPath, "rejects", "", "file for failed documents (default INDEX.rejects.ndjson)")
+	cmd.Flags().BoolVar(&opts.refresh, "refresh", false, "refresh the index once loading completes")
+	cmd.Flags().DurationVar(&opts.interval, "interval", 2*time.Second, "progress reporting interval")
+	cmd.MarkFlagRequired("filename")
+
+	return cmd
+}
+
+func run(cmd *cobra.Command, index string, opts options) error {
+	if opts.batchSize <= 0 || opts.workers <= 0 {
+		return fmt.Errorf("--batch-size and --workers must be positive")
+	}
+	if opts.opType != "" && opts.opType != "index" && opts.opType != "create" {
+		return fmt.Errorf("invalid --op-type %q: must be index or create", opts.opType)
+	}
+	maxBytes, err := output.ParseBytes(opts.batchBytes)
+	if err != nil {
+		return fmt.Errorf("invalid --batch-bytes: %v", err)
+	}
+	fields, err := parseCSVFields(opts.csvFields)
+	if err != nil {
+		return err
+	}
+
+	var in io.Reader = os.Stdin
+	if opts.filename != "-" {
+		f, err := os.Open(opts.filename)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+	br := bufio.NewReaderSize(in, 1<<20)
+	format := opts.format
+	if format == "" || format == "auto" {
+		format = detectFormat(opts.filename, br)
+	}
+	r, err := newReader(format, br, opts.idField, fields)
+	if err != nil {
+		return err
+	}
+
+	if viper.GetBool("dry-run") {
+		return dryRun(cmd, index, r, opts.batchSize, maxBytes)
+	}
+
+	c, err := client.NewClient()
+	if err != nil {
+		return fmt.Errorf("error creating client: %v", err)
+	}
+
+	action := opts.opType
+	if _, err := c.GetDataStream(index); err == nil {
+		if action == "index" {
+			return fmt.Errorf("%q is a data stream and only accepts op_type=create", index)
+		}
+		action = "create"
+	}
+	if action == "" {
+		action = "index"
+	}
+
+	rejectsPath := opts.rejectsPath
+	if rejectsPath == "" {
+		rejectsPath = index + ".rejects.ndjson"
+	}
+	l := &loader{
+		client:     c,
+		index:      index,
+		action:     action,
+		opts:       types.DocumentOptions{Pipeline: opts.pipeline, Routing: opts.routing},
+		maxRetries: opts.maxRetries,
+		backoff:    opts.backoff,
+		rejects:    &rejectWriter{path: rejectsPath},
+		stats:      &stats{},
+	}
+
+	batches := make(chan []*document, opts.workers)
+	var wg sync.WaitGroup
+	for i := 0; i < opts.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for b := range batches {
+				l.send(b)
+			}
+		}()
+	}
+
+	start := time.Now()
+	stopProgress := output.StartProgress(cmd.OutOrStderr(), opts.interval, func() string {
+		return progressLine(l.stats, time.Since(start))
+	})
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	readErr := readBatches(r, l, opts.batchSize, maxBytes, batches, signals)
+	close(batches)
+	wg.Wait()
+	stopProgress()
+
+	printSummary(cmd, index, l, time.Since(start))
+	if err := l.rejects.close(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing rejects file: %v\n", err)
+	}
+
+	if opts.refresh && l.stats.indexed.Load() > 0 {
+		if _, err := c.RefreshIndex(index); err != nil {
+			fmt.Fprintf(os.Stderr, "Error refreshing %s: %v\n", index, err)
+		}
+	}
+
+	if readErr != nil {
+		return readErr
+	}
+	if failed := l.stats.failed.Load(); failed > 0 {
+		return fmt.Errorf("%d documents failed; details written to %s", failed, l.rejects.path)
+	}
+	return nil
+}
+
+// readBatches groups documents into batches until the input ends or an interrupt arrives.
+// Unparseable records are rejected immediately; any other read error stops loading.
+func readBatches(r reader, l *loader, batchSize int, maxBytes int64, batches chan<- []*document, signals <-chan os.Signal) error {
+	var batch []*document
+	var size int64
+	flush := func() {
+		if len(batch) > 0 {
+			batches <- batch
+			batch, size = nil, 0
+		}
+	}
+
+	for {
+		select {
+		case <-signals:
+			flush()
+			return fmt.Errorf("interrupted after reading %d documents", l.stats.read.Load())
+		default:
+		}
+
+		doc, err := r.next()
+		if err == io.EOF {
+			flush()
+			return nil
+		}
+		var recErr *recordError
+		if errors.As(err, &recErr) {
+			l.stats.failed.Add(1)
+			l.rejects.write(recErr.record, 0, map[string]interface{}{"reason": recErr.err.Error()}, recErr.raw)
+			continue
+		}
+		if err != nil {
+			flush()
+			return fmt.Errorf("error reading input: %v", err)
+		}
+
+		docSize := int64(len(doc.source) + len(doc.id) + 32)
+		if len(batch) > 0 && (len(batch) >= batchSize || size+docSize > maxBytes) {
+			flush()
+		}
+		batch = append(batch, doc)
+		size += docSize
+		l.stats.read.Add(1)
+		l.stats.bytes.Add(int64(len(doc.source)))
+	}
+}
+
+func dryRun(cmd *cobra.Command, index string, r reader, batchSize int, maxBytes int64) error {
+	var docs, invalid, requests int
+	var batch int
+	var size int64
+	for {
+		doc, err := r.next()
+		if err == io.EOF {
+			break
+		}
+		var recErr *recordError
+		if errors.As(err, &recErr) {
+			invalid++
+			cmd.Printf("Invalid %v\n", recErr)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("error reading input: %v", err)
+		}
+		docSize := int64(len(doc.source) + len(doc.id) + 32)
+		if batch == 0 || batch >= batchSize || size+docSize > maxBytes {
+			requests++
+			batch, size = 0, 0
+		}
+		batch++
+		size += docSize
+		docs++
+	}
+	cmd.Printf("Would load %d documents into %s in %d bulk requests (%d invalid records)\n", docs, index, requests, invalid)
+	return nil
+}
+
+func progressLine(st *stats, elapsed time.Duration) string {
+	secs := elapsed.Seconds()
+	if secs <= 0 {
+		secs = 1
+	}
+	return fmt.Sprintf("%d indexed, %d failed, %d retries | %.0f docs/s, %s/s",
+		st.indexed.Load(), st.failed.Load(), st.retries.Load(),
+		float64(st.indexed.Load())/secs, output.FormatBytes(int64(float64(st.bytes.Load())/secs)))
+}
+
+func printSummary(cmd *cobra.Command, index string, l *loader, elapsed time.Duration) {
+	secs := elapsed.Seconds()
+	if secs <= 0 {
+		secs = 1
+	}
+	cmd.Printf("Loaded %d documents into %s in %s (%.0f docs/s, %s/s, %d retries)\n",
+		l.stats.indexed.Load(), index, elapsed.Round(time.Millisecond),
+		float64(l.stats.indexed.Load())/secs, output.FormatBytes(int64(float64(l.stats.bytes.Load())/secs)), l.stats.retries.Load())
+}
@@ -0,0 +1,178 @@
+package load
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func readAll(t *testing.T, r reader) ([]*document, []*recordError) {
+	t.Helper()
+	var docs []*document
+	var rejects []*recordError
+	for {
+		doc, err := r.next()
+		if err == io.EOF {
+			return docs, rejects
+		}
+		var recErr *recordError
+		if errors.As(err, &recErr) {
+			rejects = append(rejects, recErr)
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		docs = append(docs, doc)
+	}
+}
+
+func TestNewLoadCmd(t *testing.T) {
+	cmd := NewLoadCmd()
+
+	if cmd.Use != "load INDEX" {
+		t.Errorf("Expected Use 'load INDEX', got %s", cmd.Use)
+	}
+	for _, name := range []string{"filename", "batch-size", "batch-bytes", "workers", "csv-field", "rejects", "max-retries"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("Expected flag %s", name)
+		}
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		filename string
+		input    string
+		expected string
+	}{
+		{"data.csv", "", "csv"},
+		{"data.JSONL", "", "ndjson"},
+		{"data.json", "{}", "json"},
+		{"-", "  \n[{\"a\":1}]", "json"},
+		{"-", "{\"a\":1}\n", "ndjson"},
+	}
+	for _, tt := range tests {
+		got := detectFormat(tt.filename, bufio.NewReader(strings.NewReader(tt.input)))
+		if got != tt.expected {
+			t.Errorf("detectFormat(%q, %q) = %s, expected %s", tt.filename, tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestNDJSONReader(t *testing.T) {
+	input := "{\"id\":\"a\",\"n\":1}\n\n{\"id\":\"b\"}\nnot json\n[1,2]\n{\"n\":2}"
+	r := &ndjsonReader{r: bufio.NewReader(strings.NewReader(input)), idField: "id"}
+
+	docs, rejects := readAll(t, r)
+	if len(docs) != 2 || docs[0].id != "a" || docs[1].id != "b" {
+		t.Fatalf("Unexpected documents: %+v", docs)
+	}
+	if len(rejects) != 3 {
+		t.Fatalf("Expected 3 rejected records, got %d", len(rejects))
+	}
+	if rejects[0].record != 4 || rejects[2].record != 6 {
+		t.Errorf("Expected rejects on lines 4 and 6, got %d and %d", rejects[0].record, rejects[2].record)
+	}
+}
+
+func TestJSONReader(t *testing.T) {
+	r, err := newJSONReader(bufio.NewReader(strings.NewReader("[\n  {\"a\": 1},\n  {\"b\": {\"c\": 2}}\n]")), "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	docs, _ := readAll(t, r)
+	if len(docs) != 2 || string(docs[1].source) != `{"b":{"c":2}}` {
+		t.Fatalf("Unexpected documents: %+v", docs)
+	}
+
+	r, err = newJSONReader(bufio.NewReader(strings.NewReader("{\"user\": {\"id\": 7}}\n{\"user\": {\"id\": 8}}")), "user.id")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	docs, _ = readAll(t, r)
+	if len(docs) != 2 || docs[0].id != "7" || docs[1].id != "8" {
+		t.Fatalf("Expected ids from nested field, got %+v", docs)
+	}
+}
+
+func TestParseCSVFields(t *testing.T) {
+	fields, err := parseCSVFields([]string{"qty:int", "price=unit_price:double", "notes:skip", "name"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []csvField{
+		{column: "qty", field: "qty", typ: "int"},
+		{column: "price", field: "unit_price", typ: "float"},
+		{column: "notes", field: "notes", typ: "skip"},
+		{column: "name", field: "name", typ: "string"},
+	}
+	for i, f := range expected {
+		if fields[i] != f {
+			t.Errorf("Field %d: expected %+v, got %+v", i, f, fields[i])
+		}
+	}
+
+	for _, bad := range []string{":int", "qty:decimal"} {
+		if _, err := parseCSVFields([]string{bad}); err == nil {
+			t.Errorf("Expected error for %q", bad)
+		}
+	}
+}
+
+func TestCSVReader(t *testing.T) {
+	input := "sku,qty,price,active,notes\nA1,3,9.5,true,fragile\nB2,x,1,false,\nC3,1,2\n"
+	fields, _ := parseCSVFields([]string{"qty:int", "price=unit_price:float", "active:bool", "notes:skip"})
+	r, err := newCSVReader(strings.NewReader(input), false, "sku", fields)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	docs, rejects := readAll(t, r)
+	if len(docs) != 1 {
+		t.Fatalf("Expected 1 document, got %d", len(docs))
+	}
+	if docs[0].id != "A1" {
+		t.Errorf("Expected id A1, got %s", docs[0].id)
+	}
+	var source map[string]interface{}
+	if err := json.Unmarshal(docs[0].source, &source); err != nil {
+		t.Fatalf("Invalid source: %v", err)
+	}
+	if source["qty"] != float64(3) || source["unit_price"] != 9.5 || source["active"] != true {
+		t.Errorf("Unexpected typed fields: %v", source)
+	}
+	if _, ok := source["notes"]; ok {
+		t.Errorf("Expected skipped column to be left out: %v", source)
+	}
+	if len(rejects) != 2 || rejects[0].record != 3 || rejects[1].record != 4 {
+		t.Errorf("Expected rejects on lines 3 and 4, got %+v", rejects)
+	}
+}
+
+func TestBuildBulkBody(t *testing.T) {
+	body := buildBulkBody("create", []*document{
+		{source: []byte(`{"a":1}`), id: "x"},
+		{source: []byte(`{"b":2}`)},
+	})
+	expected := "{\"create\":{\"_id\":\"x\"}}\n{\"a\":1}\n{\"create\":{}}\n{\"b\":2}\n"
+	if string(body) != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, body)
+	}
+}
+
+func TestBackoffFor(t *testing.T) {
+	if got := backoffFor(time.Second, 0); got != time.Second {
+		t.Errorf("Expected 1s for first retry, got %s", got)
+	}
+	if got := backoffFor(time.Second, 3); got != 8*time.Second {
+		t.Errorf("Expected 8s for fourth retry, got %s", got)
+	}
+	if got := backoffFor(time.Second, 10); got != maxBackoff {
+		t.Errorf("Expected backoff to be capped at %s, got %s", maxBackoff, got)
+	}
+}
@@ -0,0 +1,314 @@
+package load
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// document is one source document read from the input
+type document struct {
+	source []byte
+	id     string
+	record int
+}
+
+// recordError is an input record that could not be turned into a document.
+// It is written to the rejects file and loading continues.
+type recordError struct {
+	record int
+	raw    []byte
+	err    error
+}
+
+func (e *recordError) Error() string {
+	return fmt.Sprintf("record %d: %v", e.record, e.err)
+}
+
+// reader yields documents until io.EOF
+type reader interface {
+	next() (*document, error)
+}
+
+// detectFormat picks the input format from the file extension, sniffing the first byte otherwise
+func detectFormat(filename string, r *bufio.Reader) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return "csv"
+	case ".tsv":
+		return "tsv"
+	case ".json":
+		return "json"
+	case ".ndjson", ".jsonl":
+		return "ndjson"
+	}
+	for {
+		b, err := r.Peek(1)
+		if err != nil {
+			return "ndjson"
+		}
+		if b[0] == ' ' || b[0] == '\t' || b[0] == '\r' || b[0] == '\n' {
+			_, _ = r.ReadByte()
+			continue
+		}
+		if b[0] == '[' {
+			return "json"
+		}
+		return "ndjson"
+	}
+}
+
+func newReader(format string, r *bufio.Reader, idField string, fields []csvField) (reader, error) {
+	switch format {
+	case "ndjson":
+		return &ndjsonReader{r: r, idField: idField}, nil
+	case "json":
+		return newJSONReader(r, idField)
+	case "csv", "tsv":
+		return newCSVReader(r, format == "tsv", idField, fields)
+	default:
+		return nil, fmt.Errorf("unsupported format %q: must be ndjson, json or csv", format)
+	}
+}
+
+type ndjsonReader struct {
+	r       *bufio.Reader
+	idField string
+	line    int
+}
+
+func (n *ndjsonReader) next() (*document, error) {
+	for {
+		line, err := n.r.ReadBytes('\n')
+		if len(line) == 0 && err != nil {
+			return nil, err
+		}
+		n.line++
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		if !json.Valid(line) || line[0] != '{' {
+			return nil, &recordError{record: n.line, raw: line, err: fmt.Errorf("not a JSON object")}
+		}
+		return newDocument(line, n.idField, n.line)
+	}
+}
+
+// jsonReader reads a JSON array of objects or a stream of concatenated objects
+type jsonReader struct {
+	dec     *json.Decoder
+	array   bool
+	idField string
+	record  int
+}
+
+func newJSONReader(r *bufio.Reader, idField string) (*jsonReader, error) {
+	jr := &jsonReader{dec: json.NewDecoder(r), idField: idField}
+	if detectFormat("", r) == "json" {
+		if _, err := jr.dec.Token(); err != nil {
+			return nil, err
+		}
+		jr.array = true
+	}
+	return jr, nil
+}
+
+func (j *jsonReader) next() (*document, error) {
+	if !j.dec.More() {
+		if j.array {
+			if _, err := j.dec.Token(); err != nil {
+				return nil, err
+			}
+		}
+		return nil, io.EOF
+	}
+	var raw json.RawMessage
+	if err := j.dec.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("record %d: %w", j.record+1, err)
+	}
+	j.record++
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err != nil || buf.Len() == 0 || buf.Bytes()[0] != '{' {
+		return nil, &recordError{record: j.record, raw: raw, err: fmt.Errorf("not a JSON object")}
+	}
+	return newDocument(buf.Bytes(), j.idField, j.record)
+}
+
+// newDocument wraps a JSON object, taking its id from idField when set
+func newDocument(source []byte, idField string, record int) (*document, error) {
+	doc := &document{source: source, record: record}
+	if idField == "" {
+		return doc, nil
+	}
+	var obj map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(source))
+	dec.UseNumber()
+	if err := dec.Decode(&obj); err != nil {
+		return nil, &recordError{record: record, raw: source, err: err}
+	}
+	id, ok := lookup(obj, idField)
+	if !ok {
+		return nil, &recordError{record: record, raw: source, err: fmt.Errorf("id field %q is missing", idField)}
+	}
+	doc.id = id
+	return doc, nil
+}
+
+// lookup resolves a dotted field path in a decoded document
+func lookup(obj map[string]interface{}, field string) (string, bool) {
+	if v, ok := obj[field]; ok && v != nil {
+		return fmt.Sprint(v), true
+	}
+	head, rest, found := strings.Cut(field, ".")
+	if !found {
+		return "", false
+	}
+	if inner, ok := obj[head].(map[string]interface{}); ok {
+		return lookup(inner, rest)
+	}
+	return "", false
+}
+
+// csvField maps a CSV column to a document field and type
+type csvField struct {
+	column string
+	field  string
+	typ    string
+}
+
+// parseCSVFields parses --csv-field values of the form COLUMN[=FIELD][:TYPE]
+func parseCSVFields(specs []string) ([]csvField, error) {
+	fields := make([]csvField, 0, len(specs))
+	for _, spec := range specs {
+		f := csvField{typ: "string"}
+		rest := spec
+		if i := strings.LastIndex(rest, ":"); i >= 0 {
+			f.typ = strings.ToLower(rest[i+1:])
+			rest = rest[:i]
+		}
+		f.column, f.field, _ = strings.Cut(rest, "=")
+		if f.field == "" {
+			f.field = f.column
+		}
+		if f.column == "" {
+			return nil, fmt.Errorf("invalid --csv-field %q: expected COLUMN[=FIELD][:TYPE]", spec)
+		}
+		switch f.typ {
+		case "string", "keyword", "text", "date":
+			f.typ = "string"
+		case "int", "integer", "long", "short", "byte":
+			f.typ = "int"
+		case "float", "double", "half_float":
+			f.typ = "float"
+		case "bool", "boolean":
+			f.typ = "bool"
+		case "json", "object":
+			f.typ = "json"
+		case "skip":
+		default:
+			return nil, fmt.Errorf("invalid type %q in --csv-field %q: must be string, int, float, bool, json or skip", f.typ, spec)
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+func convertCSVValue(value, typ string) (interface{}, error) {
+	switch typ {
+	case "int":
+		return strconv.ParseInt(value, 10, 64)
+	case "float":
+		return strconv.ParseFloat(value, 64)
+	case "bool":
+		return strconv.ParseBool(value)
+	case "json":
+		var v interface{}
+		if err := json.Unmarshal([]byte(value), &v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	default:
+		return value, nil
+	}
+}
+
+type csvReader struct {
+	r       *csv.Reader
+	header  []string
+	fields  map[string]csvField
+	idField string
+	line    int
+}
+
+func newCSVReader(r io.Reader, tabs bool, idField string, fields []csvField) (*csvReader, error) {
+	cr := csv.NewReader(r)
+	if tabs {
+		cr.Comma = '\t'
+	}
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("error reading CSV header: %w", err)
+	}
+	mapped := map[string]csvField{}
+	for _, f := range fields {
+		mapped[f.column] = f
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(strings.TrimPrefix(header[i], "\ufeff"))
+	}
+	return &csvReader{r: cr, header: header, fields: mapped, idField: idField, line: 1}, nil
+}
+
+func (c *csvReader) next() (*document, error) {
+	row, err := c.r.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, err
+		}
+		c.line++
+		return nil, &recordError{record: c.line, err: err}
+	}
+	c.line++
+	raw := []byte(strings.Join(row, ","))
+	if len(row) != len(c.header) {
+		return nil, &recordError{record: c.line, raw: raw, err: fmt.Errorf("expected %d columns, got %d", len(c.header), len(row))}
+	}
+
+	obj := map[string]interface{}{}
+	id := ""
+	for i, column := range c.header {
+		value := row[i]
+		if column == c.idField {
+			id = value
+		}
+		f, ok := c.fields[column]
+		if !ok {
+			f = csvField{column: column, field: column, typ: "string"}
+		}
+		if f.typ == "skip" || value == "" {
+			continue
+		}
+		v, err := convertCSVValue(value, f.typ)
+		if err != nil {
+			return nil, &recordError{record: c.line, raw: raw, err: fmt.Errorf("column %s: %v", column, err)}
+		}
+		obj[f.field] = v
+	}
+	if c.idField != "" && id == "" {
+		return nil, &recordError{record: c.line, raw: raw, err: fmt.Errorf("id column %q is empty", c.idField)}
+	}
+
+	source, err := json.Marshal(obj)
+	if err != nil {
+		return nil, &recordError{record: c.line, raw: raw, err: err}
+	}
+	return &document{source: source, id: id, record: c.line}, nil
+}
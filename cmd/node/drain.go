@@ -0,0 +1,188 @@
+package node
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewDrainCmd() *cobra.Command {
+	var (
+		wait     bool
+		timeout  time.Duration
+		interval time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "drain NODE",
+		Short: "Move all shards off a node",
+		Long: `Exclude a node from shard allocation so the cluster moves its shards to other nodes.
+
+The node name is added to cluster.routing.allocation.exclude._name. Nodes that are
+already excluded stay excluded, and the setting is updated in the section
+(persistent or transient) where it is already set.
+
+With --wait the command follows _cat/shards until the node holds no shards and
+exits non-zero when the timeout expires. Use 'searchctl node uncordon' to allow
+shards back onto the node.`,
+		Example: strings.TrimSpace(`
+# Drain a node and wait until it is empty
+searchctl node drain es-data-3 --wait --timeout 2h
+
+# Show the settings update without applying it
+searchctl node drain es-data-3 --dry-run`),
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			name := args[0]
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+			if err := findNode(c, name); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			settings, err := c.GetClusterSettings()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting cluster settings: %v\n", err)
+				os.Exit(1)
+			}
+			names, section := exclusions(settings)
+			names, changed := addName(names, name)
+
+			if viper.GetBool("dry-run") {
+				if changed {
+					cmd.Printf("Would set %s %s=%s\n", section, excludeSetting, strings.Join(names, ","))
+				} else {
+					cmd.Printf("Node %s is already excluded from allocation\n", name)
+				}
+				if wait {
+					cmd.Printf("Would wait up to %s for node %s to hold no shards\n", timeout, name)
+				}
+				return
+			}
+
+			if changed {
+				if err := c.UpdateClusterSettings(exclusionBody(section, names)); err != nil {
+					fmt.Fprintf(os.Stderr, "Error updating cluster settings: %v\n", err)
+					os.Exit(1)
+				}
+				cmd.Printf("Node %s excluded from allocation (%s %s=%s)\n", name, section, excludeSetting, strings.Join(names, ","))
+			} else {
+				cmd.Printf("Node %s is already excluded from allocation\n", name)
+			}
+
+			if wait {
+				waitForDrain(cmd, c, name, timeout, interval)
+			}
+		},
+	}
+
+	cmd.Flags().BoolVar(&wait, "wait", false, "wait until the node holds no shards")
+	cmd.Flags().DurationVar(&timeout, "timeout", 30*time.Minute, "maximum time to wait with --wait")
+	cmd.Flags().DurationVar(&interval, "interval", 5*time.Second, "polling interval with --wait")
+
+	return cmd
+}
+
+func NewUncordonCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "uncordon NODE",
+		Short: "Allow shards to be allocated to a drained node again",
+		Long: `Remove a node from cluster.routing.allocation.exclude._name so the cluster can
+allocate shards to it again. Other excluded nodes stay excluded; the setting is
+reset when no excluded node is left.`,
+		Example: strings.TrimSpace(`
+# Allow shards back onto a node after maintenance
+searchctl node uncordon es-data-3`),
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			name := args[0]
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			settings, err := c.GetClusterSettings()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting cluster settings: %v\n", err)
+				os.Exit(1)
+			}
+			names, section := exclusions(settings)
+			names, changed := removeName(names, name)
+			if !changed {
+				cmd.Printf("Node %s is not excluded from allocation\n", name)
+				return
+			}
+
+			if viper.GetBool("dry-run") {
+				if len(names) == 0 {
+					cmd.Printf("Would reset %s %s\n", section, excludeSetting)
+				} else {
+					cmd.Printf("Would set %s %s=%s\n", section, excludeSetting, strings.Join(names, ","))
+				}
+				return
+			}
+
+			if err := c.UpdateClusterSettings(exclusionBody(section, names)); err != nil {
+				fmt.Fprintf(os.Stderr, "Error updating cluster settings: %v\n", err)
+				os.Exit(1)
+			}
+			cmd.Printf("Node %s can receive shards again\n", name)
+		},
+	}
+
+	return cmd
+}
+
+// waitForDrain polls _cat/shards until the node holds no shards
+func waitForDrain(cmd *cobra.Command, c client.SearchClient, name string, timeout, interval time.Duration) {
+	deadline := time.Now().Add(timeout)
+	last := -1
+	for {
+		shards, err := c.GetShards("")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting shards: %v\n", err)
+			os.Exit(1)
+		}
+
+		remaining := shardsOnNode(shards, name)
+		if remaining == 0 {
+			cmd.Printf("Node %s holds no shards\n", name)
+			return
+		}
+		if remaining != last {
+			cmd.Printf("Node %s: %d shard(s) remaining\n", name, remaining)
+			last = remaining
+		}
+		if time.Now().After(deadline) {
+			fmt.Fprintf(os.Stderr, "Error: timed out after %s with %d shard(s) remaining on node %s\n", timeout, remaining, name)
+			os.Exit(1)
+		}
+		time.Sleep(interval)
+	}
+}
+
+// shardsOnNode counts the shard copies on a node. Relocating shards are listed as
+// "source -> ip id target" and still count for the source node.
+func shardsOnNode(shards []types.CatShardRow, name string) int {
+	count := 0
+	for _, s := range shards {
+		fields := strings.Fields(s.Node)
+		if len(fields) > 0 && fields[0] == name {
+			count++
+		}
+	}
+	return count
+}
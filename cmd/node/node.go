@@ -0,0 +1,94 @@
+package node
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// excludeSetting is the allocation filter used to move shards off nodes by name
+const excludeSetting = "cluster.routing.allocation.exclude._name"
+
+func NewNodeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "node",
+		Short:   "Manage nodes",
+		Long:    "Move shards off nodes before maintenance and allow them back afterwards.",
+		Aliases: []string{"nodes", "no"},
+	}
+
+	cmd.AddCommand(NewDrainCmd())
+	cmd.AddCommand(NewUncordonCmd())
+
+	return cmd
+}
+
+// findNode checks that a node with the given name is part of the cluster
+func findNode(c client.SearchClient, name string) error {
+	nodes, err := c.GetNodes()
+	if err != nil {
+		return fmt.Errorf("error getting nodes: %v", err)
+	}
+	for _, n := range nodes {
+		if n.Name == name {
+			return nil
+		}
+	}
+	return fmt.Errorf("node %q not found", name)
+}
+
+// exclusions returns the node names excluded from allocation and the settings section
+// holding them. The section defaults to persistent when nothing is excluded yet.
+func exclusions(settings *types.ClusterSettings) ([]string, string) {
+	value, section := settings.Value(excludeSetting)
+	if section == "" {
+		section = "persistent"
+	}
+	return splitList(value), section
+}
+
+func splitList(value string) []string {
+	var names []string
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// addName appends name to the list unless it is already there
+func addName(names []string, name string) ([]string, bool) {
+	for _, n := range names {
+		if n == name {
+			return names, false
+		}
+	}
+	return append(names, name), true
+}
+
+// removeName removes every occurrence of name from the list
+func removeName(names []string, name string) ([]string, bool) {
+	var result []string
+	for _, n := range names {
+		if n != name {
+			result = append(result, n)
+		}
+	}
+	return result, len(result) != len(names)
+}
+
+// exclusionBody builds the cluster settings update for the list. An empty list resets
+// the setting.
+func exclusionBody(section string, names []string) map[string]interface{} {
+	var value interface{}
+	if len(names) > 0 {
+		value = strings.Join(names, ",")
+	}
+	return map[string]interface{}{
+		section: map[string]interface{}{excludeSetting: value},
+	}
+}
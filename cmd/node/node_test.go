@@ -0,0 +1,220 @@
+package node
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+func TestNewNodeCmd(t *testing.T) {
+	cmd := NewNodeCmd()
+	if cmd.Use != "node" {
+		t.Errorf("Expected Use 'node', got %s", cmd.Use)
+	}
+
+	drain := NewDrainCmd()
+	for _, name := range []string{"wait", "timeout", "interval"} {
+		if drain.Flags().Lookup(name) == nil {
+			t.Errorf("Expected --%s flag on drain", name)
+		}
+	}
+}
+
+func TestExclusions(t *testing.T) {
+	settings := &types.ClusterSettings{
+		Persistent: map[string]interface{}{
+			"cluster": map[string]interface{}{
+				"routing": map[string]interface{}{
+					"allocation": map[string]interface{}{
+						"exclude": map[string]interface{}{"_name": "es-1, es-2"},
+					},
+				},
+			},
+		},
+	}
+	names, section := exclusions(settings)
+	if section != "persistent" || !reflect.DeepEqual(names, []string{"es-1", "es-2"}) {
+		t.Errorf("Unexpected exclusions %v in %s", names, section)
+	}
+
+	settings.Transient = map[string]interface{}{excludeSetting: "es-3"}
+	names, section = exclusions(settings)
+	if section != "transient" || !reflect.DeepEqual(names, []string{"es-3"}) {
+		t.Errorf("Expected transient exclusions to win, got %v in %s", names, section)
+	}
+
+	names, section = exclusions(&types.ClusterSettings{})
+	if section != "persistent" || len(names) != 0 {
+		t.Errorf("Expected no exclusions in persistent, got %v in %s", names, section)
+	}
+}
+
+func TestAddRemoveName(t *testing.T) {
+	names, changed := addName([]string{"es-1"}, "es-2")
+	if !changed || !reflect.DeepEqual(names, []string{"es-1", "es-2"}) {
+		t.Errorf("Unexpected add result %v", names)
+	}
+	if _, changed := addName(names, "es-1"); changed {
+		t.Error("Expected adding an excluded node to be a no-op")
+	}
+
+	names, changed = removeName(names, "es-1")
+	if !changed || !reflect.DeepEqual(names, []string{"es-2"}) {
+		t.Errorf("Unexpected remove result %v", names)
+	}
+	names, _ = removeName(names, "es-2")
+	body := exclusionBody("persistent", names)
+	if v := body["persistent"].(map[string]interface{})[excludeSetting]; v != nil {
+		t.Errorf("Expected the setting to be reset, got %v", v)
+	}
+}
+
+func TestShardsOnNode(t *testing.T) {
+	shards := []types.CatShardRow{
+		{Index: "logs", Shard: "0", Node: "es-1"},
+		{Index: "logs", Shard: "1", Node: "es-1 -> 10.0.0.2 abc es-2"},
+		{Index: "logs", Shard: "2", Node: "es-2"},
+		{Index: "logs", Shard: "3", Node: "es-10"},
+		{Index: "logs", Shard: "4", State: "UNASSIGNED"},
+	}
+	if got := shardsOnNode(shards, "es-1"); got != 2 {
+		t.Errorf("Expected 2 shards on es-1, got %d", got)
+	}
+}
+
+func TestRestartedSince(t *testing.T) {
+	if !restartedSince("20s", 10*time.Second) {
+		t.Error("Expected a fresh uptime to count as restarted")
+	}
+	if restartedSince("3.2d", time.Minute) {
+		t.Error("Expected the old process not to count as restarted")
+	}
+	if restartedSince("", time.Second) || restartedSince("soon", time.Second) {
+		t.Error("Expected an unknown uptime not to count as restarted")
+	}
+}
+
+func TestRestartState(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "state.json")
+	nodes := []string{"es-1", "es-2", "es-3"}
+
+	state, resumed, err := loadState(filename, nodes)
+	if err != nil || resumed {
+		t.Fatalf("Expected a new state, got resumed=%v err=%v", resumed, err)
+	}
+	state.Completed = []string{"es-1"}
+	state.Current = "es-2"
+	r := &restarter{opts: restartOptions{stateFile: filename}, state: state}
+	if err := r.save(stepRestarted); err != nil {
+		t.Fatal(err)
+	}
+
+	state, resumed, err = loadState(filename, nodes)
+	if err != nil || !resumed {
+		t.Fatalf("Expected to resume, got resumed=%v err=%v", resumed, err)
+	}
+	if state.Step != stepRestarted || state.Current != "es-2" {
+		t.Errorf("Unexpected state %+v", state)
+	}
+	if got := pendingNodes(state); !reflect.DeepEqual(got, []string{"es-2", "es-3"}) {
+		t.Errorf("Unexpected pending nodes %v", got)
+	}
+
+	if _, _, err := loadState(filename, []string{"es-3"}); err == nil {
+		t.Error("Expected an error for a state file of other nodes")
+	}
+}
+
+// restartClient is a cluster whose node es-1 always reports a fresh uptime
+type restartClient struct {
+	client.SearchClient
+	health []string
+}
+
+func (c *restartClient) ClusterHealth() (*types.ClusterHealth, error) {
+	status := c.health[0]
+	if len(c.health) > 1 {
+		c.health = c.health[1:]
+	}
+	return &types.ClusterHealth{Status: status}, nil
+}
+
+func (c *restartClient) GetNodes() ([]types.Node, error) {
+	return []types.Node{{Name: "es-1", Host: "10.0.0.1", Uptime: "1s"}}, nil
+}
+
+func (c *restartClient) ClusterCoordinationState() (*types.ClusterState, error) {
+	return nil, fmt.Errorf("not available")
+}
+
+func (c *restartClient) GetClusterSettings() (*types.ClusterSettings, error) {
+	return &types.ClusterSettings{}, nil
+}
+
+func (c *restartClient) UpdateClusterSettings(body map[string]interface{}) error {
+	return nil
+}
+
+func (c *restartClient) FlushIndex(name string) (*types.IndexOperationResponse, error) {
+	return &types.IndexOperationResponse{}, nil
+}
+
+func newTestRestarter(t *testing.T, c client.SearchClient, exec string) (*restarter, string) {
+	dir := t.TempDir()
+	cmd := &cobra.Command{}
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	marker := filepath.Join(dir, "ran")
+	hook := template.Must(template.New("exec").Parse(exec + " && touch " + marker))
+	return &restarter{
+		cmd:   cmd,
+		c:     c,
+		opts:  restartOptions{stateFile: filepath.Join(dir, "state.json"), nodeTimeout: time.Minute, interval: time.Millisecond},
+		hook:  hook,
+		state: &restartState{Nodes: []string{"es-1"}},
+	}, marker
+}
+
+func TestRestartNodeHook(t *testing.T) {
+	// A hook that fails by itself is run again on resume
+	r, marker := newTestRestarter(t, &restartClient{health: []string{"green"}}, "false")
+	if err := r.restartNode("es-1"); err == nil {
+		t.Fatal("Expected the failing hook to abort the node")
+	}
+	if r.state.Step != stepDisabled {
+		t.Errorf("Expected step %s after a failed hook, got %s", stepDisabled, r.state.Step)
+	}
+
+	// A hook that was started before an interruption is not run again
+	r, marker = newTestRestarter(t, &restartClient{health: []string{"green"}}, "true")
+	r.state.Current, r.state.Step, r.state.RestartedAt = "es-1", stepHookStarted, time.Now()
+	if err := r.restartNode("es-1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := os.Stat(marker); err == nil {
+		t.Error("Expected the started hook not to run again")
+	}
+	if !reflect.DeepEqual(r.state.Completed, []string{"es-1"}) {
+		t.Errorf("Expected es-1 to complete, got %v", r.state.Completed)
+	}
+}
+
+func TestRestartNodeAbortsOnRed(t *testing.T) {
+	c := &restartClient{health: []string{"green", "green", "red"}}
+	r, _ := newTestRestarter(t, c, "true")
+	if err := r.restartNode("es-1"); err == nil {
+		t.Fatal("Expected a red cluster after the restart to abort")
+	}
+	if len(r.state.Completed) != 0 {
+		t.Errorf("Expected es-1 not to complete, got %v", r.state.Completed)
+	}
+}
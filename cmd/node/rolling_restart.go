@@ -0,0 +1,468 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/output"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const allocationSetting = "cluster.routing.allocation.enable"
+
+// Steps of a node restart recorded in the state file. A resumed run continues after
+// the last recorded step.
+const (
+	stepDisabled    = "allocation-disabled"
+	stepHookStarted = "hook-started"
+	stepRestarted   = "restarted"
+	stepRejoined    = "rejoined"
+)
+
+type restartOptions struct {
+	nodes       []string
+	exec        string
+	stateFile   string
+	nodeTimeout time.Duration
+	interval    time.Duration
+}
+
+// restartState is written to the state file after every step so that an interrupted
+// or aborted rolling restart can be resumed
+type restartState struct {
+	Nodes       []string         `json:"nodes"`
+	Completed   []string         `json:"completed"`
+	Current     string           `json:"current,omitempty"`
+	Step        string           `json:"step,omitempty"`
+	RestartedAt time.Time        `json:"restarted_at,omitempty"`
+	Allocation  *originalSetting `json:"allocation,omitempty"`
+}
+
+// originalSetting is the allocation setting before the rolling restart changed it. An
+// empty value means the setting was not set.
+type originalSetting struct {
+	Section string `json:"section"`
+	Value   string `json:"value,omitempty"`
+}
+
+func NewRollingRestartCmd() *cobra.Command {
+	var opts restartOptions
+
+	cmd := &cobra.Command{
+		Use:   "rolling-restart --nodes NODE,... --exec COMMAND",
+		Short: "Restart nodes one at a time without losing availability",
+		Long: `Restart nodes one at a time following the documented rolling restart procedure.
+
+For every node the command:
+  1. refuses to continue while the cluster is red
+  2. sets cluster.routing.allocation.enable to primaries so replicas are not
+     rebuilt elsewhere while the node is down
+  3. flushes all indices
+  4. runs the --exec hook, a local shell command that restarts the node
+  5. waits for the node to rejoin the cluster with a fresh uptime
+  6. restores the allocation setting and waits for the cluster to turn green,
+     aborting if it is red with no shard recovering
+
+The hook is a Go template executed with the node, so {{.Name}}, {{.Host}} and
+{{.IP}} can be used. Every node must complete within --node-timeout.
+
+Progress is written to the --state file after every step. When the command is
+interrupted or aborts, running it again with the same nodes resumes where it
+stopped. A hook that was started is not run a second time, unless it exited with
+an error before --node-timeout. The file is removed when all nodes are done.`,
+		Example: strings.TrimSpace(`
+# Restart three nodes over SSH
+searchctl rolling-restart --nodes es-1,es-2,es-3 --exec 'ssh {{.Host}} sudo systemctl restart opensearch'
+
+# Show the plan without changing anything
+searchctl rolling-restart --nodes es-1,es-2 --exec './restart.sh {{.Name}}' --dry-run`),
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			if err := rollingRestart(cmd, c, opts); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				if _, statErr := os.Stat(opts.stateFile); statErr == nil {
+					fmt.Fprintf(os.Stderr, "Progress saved in %s; run the command again to resume\n", opts.stateFile)
+				}
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&opts.nodes, "nodes", nil, "nodes to restart, in order (comma-separated)")
+	cmd.Flags().StringVar(&opts.exec, "exec", "", "shell command that restarts a node, as a Go template")
+	cmd.Flags().StringVar(&opts.stateFile, "state", "searchctl-rolling-restart.json", "file recording progress for resuming")
+	cmd.Flags().DurationVar(&opts.nodeTimeout, "node-timeout", 30*time.Minute, "maximum time to restart one node and get back to green")
+	cmd.Flags().DurationVar(&opts.interval, "interval", 5*time.Second, "polling interval")
+	cmd.MarkFlagRequired("nodes")
+	cmd.MarkFlagRequired("exec")
+
+	return cmd
+}
+
+func rollingRestart(cmd *cobra.Command, c client.SearchClient, opts restartOptions) error {
+	hook, err := template.New("exec").Option("missingkey=error").Parse(opts.exec)
+	if err != nil {
+		return fmt.Errorf("invalid --exec template: %v", err)
+	}
+	if _, err := renderHook(hook, types.Node{}); err != nil {
+		return fmt.Errorf("invalid --exec template: %v", err)
+	}
+
+	state, resumed, err := loadState(opts.stateFile, opts.nodes)
+	if err != nil {
+		return err
+	}
+	if resumed {
+		cmd.Printf("Resuming rolling restart from %s: %d of %d node(s) done\n", opts.stateFile, len(state.Completed), len(state.Nodes))
+	} else {
+		for _, name := range opts.nodes {
+			if err := findNode(c, name); err != nil {
+				return err
+			}
+		}
+	}
+
+	pending := pendingNodes(state)
+	if viper.GetBool("dry-run") {
+		for _, name := range pending {
+			cmd.Printf("Would restart node %s: set %s=primaries, flush, run hook, wait for rejoin, restore allocation, wait for green\n", name, allocationSetting)
+		}
+		return nil
+	}
+
+	r := &restarter{cmd: cmd, c: c, opts: opts, hook: hook, state: state}
+	for _, name := range pending {
+		if err := r.restartNode(name); err != nil {
+			return fmt.Errorf("node %s: %v", name, err)
+		}
+	}
+
+	if err := os.Remove(opts.stateFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	cmd.Printf("Rolling restart complete: %d node(s) restarted\n", len(pending))
+	return nil
+}
+
+type restarter struct {
+	cmd   *cobra.Command
+	c     client.SearchClient
+	opts  restartOptions
+	hook  *template.Template
+	state *restartState
+}
+
+// restartNode runs the remaining steps for a node, saving the state after each one
+func (r *restarter) restartNode(name string) error {
+	deadline := time.Now().Add(r.opts.nodeTimeout)
+	if r.state.Current != name {
+		r.state.Current, r.state.Step = name, ""
+	}
+
+	if r.state.Step == "" {
+		if err := r.guardRed(); err != nil {
+			return err
+		}
+		if err := r.disableAllocation(); err != nil {
+			return err
+		}
+		if err := r.save(stepDisabled); err != nil {
+			return err
+		}
+		if _, err := r.c.FlushIndex("_all"); err != nil {
+			r.cmd.Printf("Warning: flush failed: %v\n", err)
+		}
+	}
+
+	if r.state.Step == stepDisabled {
+		if err := r.guardRed(); err != nil {
+			return err
+		}
+		if err := r.runHook(name, deadline); err != nil {
+			return err
+		}
+		if err := r.save(stepRestarted); err != nil {
+			return err
+		}
+	}
+
+	if r.state.Step == stepHookStarted {
+		r.cmd.Printf("The restart hook for %s already started; not running it again\n", name)
+	}
+
+	if r.state.Step == stepRestarted || r.state.Step == stepHookStarted {
+		if err := r.waitForRejoin(name, deadline); err != nil {
+			return err
+		}
+		if err := r.save(stepRejoined); err != nil {
+			return err
+		}
+	}
+
+	if err := r.restoreAllocation(); err != nil {
+		return err
+	}
+	if err := r.waitForGreen(deadline); err != nil {
+		return err
+	}
+	r.state.Completed = append(r.state.Completed, name)
+	r.state.Current = ""
+	return r.save("")
+}
+
+func (r *restarter) guardRed() error {
+	health, err := r.c.ClusterHealth()
+	if err != nil {
+		return fmt.Errorf("error getting cluster health: %v", err)
+	}
+	if health.Status == "red" {
+		return fmt.Errorf("cluster is red (%d unassigned shard(s)); aborting", health.UnassignedShards)
+	}
+	return nil
+}
+
+func (r *restarter) disableAllocation() error {
+	settings, err := r.c.GetClusterSettings()
+	if err != nil {
+		return fmt.Errorf("error getting cluster settings: %v", err)
+	}
+	value, section := settings.Value(allocationSetting)
+	if section == "" {
+		section = "persistent"
+	}
+	// Keep the setting from before the first node; later nodes see it disabled
+	if r.state.Allocation == nil {
+		r.state.Allocation = &originalSetting{Section: section, Value: value}
+	}
+
+	r.cmd.Printf("Setting %s %s=primaries\n", section, allocationSetting)
+	body := map[string]interface{}{section: map[string]interface{}{allocationSetting: "primaries"}}
+	if err := r.c.UpdateClusterSettings(body); err != nil {
+		return fmt.Errorf("error disabling replica allocation: %v", err)
+	}
+	return nil
+}
+
+func (r *restarter) restoreAllocation() error {
+	original := r.state.Allocation
+	if original == nil {
+		return nil
+	}
+	var value interface{}
+	if original.Value != "" {
+		value = original.Value
+		r.cmd.Printf("Restoring %s %s=%s\n", original.Section, allocationSetting, original.Value)
+	} else {
+		r.cmd.Printf("Resetting %s %s\n", original.Section, allocationSetting)
+	}
+	body := map[string]interface{}{original.Section: map[string]interface{}{allocationSetting: value}}
+	if err := r.c.UpdateClusterSettings(body); err != nil {
+		return fmt.Errorf("error restoring allocation: %v", err)
+	}
+	return nil
+}
+
+func (r *restarter) runHook(name string, deadline time.Time) error {
+	nodes, err := r.c.GetNodes()
+	if err != nil {
+		return fmt.Errorf("error getting nodes: %v", err)
+	}
+	var node *types.Node
+	for i := range nodes {
+		if nodes[i].Name == name {
+			node = &nodes[i]
+		}
+	}
+	if node == nil {
+		return fmt.Errorf("node is not part of the cluster")
+	}
+	command, err := renderHook(r.hook, *node)
+	if err != nil {
+		return err
+	}
+
+	r.warnQuorum(name)
+
+	// Taken before the hook, which may keep waiting after the node restarted. Once the
+	// hook has started, a resumed run waits for the node instead of running it again.
+	r.state.RestartedAt = time.Now()
+	if err := r.save(stepHookStarted); err != nil {
+		return err
+	}
+
+	r.cmd.Printf("Running: %s\n", command)
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+	hook := exec.CommandContext(ctx, "sh", "-c", command)
+	hook.Stdout = r.cmd.OutOrStdout()
+	hook.Stderr = r.cmd.ErrOrStderr()
+	if err := hook.Run(); err != nil {
+		// A hook that failed by itself did not restart the node, so it is run again on resume
+		if ctx.Err() == nil {
+			if saveErr := r.save(stepDisabled); saveErr != nil {
+				return saveErr
+			}
+		}
+		return fmt.Errorf("restart hook failed: %v", err)
+	}
+	return nil
+}
+
+// warnQuorum warns when restarting a node of the voting configuration leaves fewer
+// voting nodes than the quorum, so that the cluster has no master until it rejoins
+func (r *restarter) warnQuorum(name string) {
+	state, err := r.c.ClusterCoordinationState()
+	if err != nil {
+		r.cmd.Printf("Warning: cannot check the voting quorum: %v\n", err)
+		return
+	}
+	coord, err := state.Coordination()
+	if err != nil || coord == nil {
+		return
+	}
+	id, ok := state.NodeID(name)
+	if !ok || state.RemainingVoters(coord, id) == state.RemainingVoters(coord) {
+		return
+	}
+	if remaining := state.RemainingVoters(coord, id); remaining < coord.Quorum() {
+		r.cmd.Printf("Warning: restarting %s leaves %d of %d voting node(s), below the quorum of %d; the cluster has no master until it rejoins\n",
+			name, remaining, len(coord.LastCommittedConfig), coord.Quorum())
+	}
+}
+
+func renderHook(hook *template.Template, node types.Node) (string, error) {
+	var buf bytes.Buffer
+	if err := hook.Execute(&buf, node); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// waitForRejoin waits until the node is back in the cluster. The node must report an
+// uptime that fits in the time since the hook ran, so that the old process still
+// being listed does not count.
+func (r *restarter) waitForRejoin(name string, deadline time.Time) error {
+	r.cmd.Printf("Waiting for node %s to rejoin\n", name)
+	for {
+		nodes, err := r.c.GetNodes()
+		if err != nil {
+			// The elected master may be the node that restarts
+			r.cmd.Printf("Warning: error getting nodes: %v\n", err)
+		}
+		for _, n := range nodes {
+			if n.Name == name && restartedSince(n.Uptime, time.Since(r.state.RestartedAt)) {
+				r.cmd.Printf("Node %s rejoined (uptime %s)\n", name, n.Uptime)
+				return nil
+			}
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %s waiting for the node to rejoin", r.opts.nodeTimeout)
+		}
+		time.Sleep(r.opts.interval)
+	}
+}
+
+func (r *restarter) waitForGreen(deadline time.Time) error {
+	last := ""
+	for {
+		health, err := r.c.ClusterHealth()
+		if err != nil {
+			return fmt.Errorf("error getting cluster health: %v", err)
+		}
+		if health.Status == "green" {
+			r.cmd.Printf("Cluster is green\n")
+			return nil
+		}
+		// Primaries recovering on the restarted node keep the cluster red for a while;
+		// red with nothing initializing will not turn green by waiting
+		if health.Status == "red" && health.InitializingShards == 0 {
+			return fmt.Errorf("cluster is red (%d unassigned shard(s)) and no shard is recovering; aborting", health.UnassignedShards)
+		}
+		line := fmt.Sprintf("Cluster is %s: %d initializing, %d relocating, %d unassigned shard(s)",
+			health.Status, health.InitializingShards, health.RelocatingShards, health.UnassignedShards)
+		if line != last {
+			r.cmd.Println(line)
+			last = line
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %s waiting for green; cluster is %s", r.opts.nodeTimeout, health.Status)
+		}
+		time.Sleep(r.opts.interval)
+	}
+}
+
+func (r *restarter) save(step string) error {
+	r.state.Step = step
+	data, err := json.MarshalIndent(r.state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(r.opts.stateFile, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("error saving state: %v", err)
+	}
+	return nil
+}
+
+// loadState reads the state file. It reports whether an earlier run is resumed, which
+// requires the same node list.
+func loadState(filename string, nodes []string) (*restartState, bool, error) {
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return &restartState{Nodes: nodes}, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	var state restartState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, false, fmt.Errorf("failed to parse state file %s: %v", filename, err)
+	}
+	if strings.Join(state.Nodes, ",") != strings.Join(nodes, ",") {
+		return nil, false, fmt.Errorf("state file %s belongs to a rolling restart of %s; remove it to start over",
+			filename, strings.Join(state.Nodes, ","))
+	}
+	return &state, true, nil
+}
+
+func pendingNodes(state *restartState) []string {
+	done := make(map[string]bool, len(state.Completed))
+	for _, name := range state.Completed {
+		done[name] = true
+	}
+	var pending []string
+	for _, name := range state.Nodes {
+		if !done[name] {
+			pending = append(pending, name)
+		}
+	}
+	return pending
+}
+
+// restartedSince reports whether a node with the _cat/nodes uptime started within
+// elapsed. Uptimes are rounded, so some slack is allowed. An unknown uptime does not
+// count as restarted, since it may belong to the old process.
+func restartedSince(uptime string, elapsed time.Duration) bool {
+	d, err := output.ParseDuration(uptime)
+	if err != nil {
+		return false
+	}
+	return d <= elapsed+elapsed/10+30*time.Second
+}
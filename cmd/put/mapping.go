@@ -0,0 +1,90 @@
+package put
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+func NewPutMappingCmd() *cobra.Command {
+	var filename string
+
+	cmd := &cobra.Command{
+		Use:     "mapping INDEX_PATTERN",
+		Short:   "Add fields to an index mapping",
+		Long:    "Update the mapping of an index or indices matching a pattern from a JSON or YAML file. The file may contain the mapping body, a 'mappings' object, or a resource with a 'spec'.",
+		Aliases: []string{"mappings", "map"},
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			indexPattern := args[0]
+
+			if filename == "" {
+				fmt.Fprintf(os.Stderr, "Error: must specify filename with -f flag\n")
+				os.Exit(1)
+			}
+
+			body, err := readMappingFromFile(filename)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error reading mapping file: %v\n", err)
+				os.Exit(1)
+			}
+
+			if viper.GetBool("dry-run") {
+				bodyJSON, _ := json.MarshalIndent(body, "", "  ")
+				cmd.Printf("Would update mapping on: %s\n%s\n", indexPattern, string(bodyJSON))
+				return
+			}
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			if err := c.PutIndexMapping(indexPattern, body); err != nil {
+				fmt.Fprintf(os.Stderr, "Error updating index mapping: %v\n", err)
+				os.Exit(1)
+			}
+
+			cmd.Printf("Mapping updated on %s\n", indexPattern)
+		},
+	}
+
+	cmd.Flags().StringVarP(&filename, "filename", "f", "", "mapping definition file (JSON or YAML)")
+
+	return cmd
+}
+
+func readMappingFromFile(filename string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var body map[string]interface{}
+	if filepath.Ext(filename) == ".json" {
+		if err := json.Unmarshal(data, &body); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON: %w", err)
+		}
+	} else if err := yaml.Unmarshal(data, &body); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+
+	// Unwrap kind/spec resources and full index bodies down to the mapping itself
+	if spec, ok := body["spec"].(map[string]interface{}); ok {
+		body = spec
+	}
+	if mappings, ok := body["mappings"].(map[string]interface{}); ok {
+		body = mappings
+	}
+	if len(body) == 0 {
+		return nil, fmt.Errorf("mapping file %s is empty", filename)
+	}
+	return body, nil
+}
@@ -0,0 +1,17 @@
+package put
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewPutCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "put",
+		Short: "Replace or extend a resource definition",
+		Long:  "Send a resource definition from a file to an existing resource in the search cluster.",
+	}
+
+	cmd.AddCommand(NewPutMappingCmd())
+
+	return cmd
+}
@@ -0,0 +1,35 @@
+package put
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewPutMappingCmd(t *testing.T) {
+	cmd := NewPutMappingCmd()
+
+	if cmd.Use != "mapping INDEX_PATTERN" {
+		t.Errorf("Expected Use to be 'mapping INDEX_PATTERN', got %s", cmd.Use)
+	}
+	if cmd.Flags().Lookup("filename") == nil {
+		t.Error("Expected -f/--filename flag to be defined")
+	}
+}
+
+func TestReadMappingFromFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "mapping.yaml")
+	content := "mappings:\n  properties:\n    status:\n      type: keyword\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write mapping file: %v", err)
+	}
+
+	body, err := readMappingFromFile(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, ok := body["properties"]; !ok {
+		t.Errorf("Expected 'mappings' wrapper to be removed, got %v", body)
+	}
+}
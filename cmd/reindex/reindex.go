@@ -0,0 +1,344 @@
+package reindex
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/config"
+	"github.com/chronicblondiee/searchctl/pkg/query"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// options controls how the _reindex request body is built
+type options struct {
+	query         string
+	script        string
+	scriptLang    string
+	size          int
+	maxDocs       int
+	opType        string
+	conflicts     string
+	pipeline      string
+	remoteContext string
+}
+
+func NewReindexCmd() *cobra.Command {
+	var opts options
+	var slices string
+	var requestsPerSecond float64
+	var refresh bool
+	var detach bool
+	var interval time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "reindex SOURCE DEST",
+		Short: "Copy documents from one index to another",
+		Long: `Copy documents from SOURCE into DEST using the _reindex API.
+
+The reindex runs as a background task on the cluster (wait_for_completion=false)
+and searchctl follows its progress until it completes. Pressing Ctrl-C detaches
+from the task without cancelling it; use 'searchctl reindex status TASK_ID' to
+resume watching and '--rethrottle' there to change its speed.
+
+SOURCE may be a comma-separated list of indices, aliases or data streams.
+--query accepts either a JSON query DSL object or a query_string expression.
+--remote-context reads documents from the cluster of another searchctl context;
+the destination cluster must list that host in reindex.remote.whitelist.`,
+		Example: strings.TrimSpace(`
+# Reindex everything and follow progress
+searchctl reindex logs-old logs-new
+
+# Only copy matching documents, transforming them with a script
+searchctl reindex orders orders-v2 --query 'status:shipped' --script 'ctx._source.remove("tmp")'
+
+# Throttle and slice a large reindex
+searchctl reindex big-index big-index-v2 --slices auto --requests-per-second 500
+
+# Pull documents from the cluster of another context
+searchctl reindex logs-2024 logs-2024 --remote-context legacy
+
+# Start the reindex and return immediately
+searchctl reindex logs-old logs-new --detach`),
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			source, dest := args[0], args[1]
+
+			var remote map[string]interface{}
+			if opts.remoteContext != "" {
+				var err error
+				remote, err = remoteSource(opts.remoteContext)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error resolving remote context: %v\n", err)
+					os.Exit(1)
+				}
+			}
+
+			body, err := buildReindexBody(source, dest, opts, remote)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error building reindex request: %v\n", err)
+				os.Exit(1)
+			}
+
+			reindexOpts := types.ReindexOptions{
+				Slices:            slices,
+				RequestsPerSecond: requestsPerSecond,
+				Refresh:           refresh,
+			}
+
+			if viper.GetBool("dry-run") {
+				cmd.Printf("Would reindex %s into %s\n", source, dest)
+				printRequest(cmd, body, reindexOpts)
+				return
+			}
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			taskID, err := c.Reindex(body, reindexOpts)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error starting reindex: %v\n", err)
+				os.Exit(1)
+			}
+			cmd.Printf("Reindex task %s started\n", taskID)
+
+			if detach {
+				cmd.Printf("Follow progress with: searchctl reindex status %s\n", taskID)
+				return
+			}
+
+			watch(cmd, c, taskID, interval)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.query, "query", "q", "", "only copy documents matching this query (JSON query DSL or query_string syntax)")
+	cmd.Flags().StringVar(&opts.script, "script", "", "script applied to each document before it is indexed")
+	cmd.Flags().StringVar(&opts.scriptLang, "script-lang", "painless", "language of --script")
+	cmd.Flags().IntVar(&opts.size, "batch-size", 0, "number of documents per scroll batch (default 1000)")
+	cmd.Flags().IntVar(&opts.maxDocs, "max-docs", 0, "maximum number of documents to copy (0 copies all)")
+	cmd.Flags().StringVar(&opts.opType, "op-type", "", "op_type for the destination (index or create)")
+	cmd.Flags().StringVar(&opts.conflicts, "conflicts", "", "what to do on version conflicts (abort or proceed)")
+	cmd.Flags().StringVar(&opts.pipeline, "pipeline", "", "ingest pipeline applied in the destination")
+	cmd.Flags().StringVar(&opts.remoteContext, "remote-context", "", "read the source from the cluster of this searchctl context")
+	cmd.Flags().StringVar(&slices, "slices", "", "number of slices to parallelize with (a number or 'auto')")
+	cmd.Flags().Float64Var(&requestsPerSecond, "requests-per-second", 0, "throttle the reindex to this many requests per second (-1 for unlimited)")
+	cmd.Flags().BoolVar(&refresh, "refresh", false, "refresh the destination when the reindex completes")
+	cmd.Flags().BoolVar(&detach, "detach", false, "start the reindex and return without watching it")
+	cmd.Flags().DurationVar(&interval, "interval", 2*time.Second, "polling interval while watching")
+
+	cmd.AddCommand(NewStatusCmd())
+
+	return cmd
+}
+
+func NewStatusCmd() *cobra.Command {
+	var rethrottle float64
+	var once bool
+	var interval time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "status TASK_ID",
+		Short: "Show or resume watching a reindex task",
+		Long: `Show the progress of a reindex task started with 'searchctl reindex'.
+
+By default the task is followed until it completes, like the original command.
+With -o json or -o yaml the raw task status is printed once.`,
+		Example: strings.TrimSpace(`
+# Resume watching a detached reindex
+searchctl reindex status oTUltX4IQMOUUVeiohTt8A:12345
+
+# Remove the throttle from a running reindex
+searchctl reindex status oTUltX4IQMOUUVeiohTt8A:12345 --rethrottle -1`),
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			taskID := args[0]
+			doRethrottle := cmd.Flags().Changed("rethrottle")
+
+			if viper.GetBool("dry-run") {
+				if doRethrottle {
+					cmd.Printf("Would rethrottle reindex task %s to %s requests per second\n", taskID, formatRate(rethrottle))
+				} else {
+					cmd.Printf("Would show status of reindex task %s\n", taskID)
+				}
+				return
+			}
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			if doRethrottle {
+				if err := c.RethrottleReindex(taskID, rethrottle); err != nil {
+					fmt.Fprintf(os.Stderr, "Error rethrottling reindex: %v\n", err)
+					os.Exit(1)
+				}
+				cmd.Printf("Reindex task %s rethrottled to %s requests per second\n", taskID, formatRate(rethrottle))
+			}
+
+			format := viper.GetString("output")
+			if format == "json" || format == "yaml" || once {
+				printOnce(cmd, c, taskID, format)
+				return
+			}
+
+			watch(cmd, c, taskID, interval)
+		},
+	}
+
+	cmd.Flags().Float64Var(&rethrottle, "rethrottle", 0, "change requests per second of the running task (-1 for unlimited)")
+	cmd.Flags().BoolVar(&once, "once", false, "print the current progress once instead of watching")
+	cmd.Flags().DurationVar(&interval, "interval", 2*time.Second, "polling interval while watching")
+
+	return cmd
+}
+
+// buildReindexBody assembles the _reindex request; remote is the source.remote object or nil
+func buildReindexBody(source, dest string, opts options, remote map[string]interface{}) (map[string]interface{}, error) {
+	src := map[string]interface{}{}
+	indices := splitList(source)
+	if len(indices) == 0 {
+		return nil, fmt.Errorf("source index is required")
+	}
+	if len(indices) == 1 {
+		src["index"] = indices[0]
+	} else {
+		src["index"] = indices
+	}
+
+	if opts.query != "" {
+		q, err := query.Parse(opts.query)
+		if err != nil {
+			return nil, err
+		}
+		src["query"] = q
+	}
+	if opts.size > 0 {
+		src["size"] = opts.size
+	}
+	if remote != nil {
+		src["remote"] = remote
+	}
+
+	dst := map[string]interface{}{"index": dest}
+	if opts.opType != "" {
+		if opts.opType != "index" && opts.opType != "create" {
+			return nil, fmt.Errorf("invalid --op-type %q: must be index or create", opts.opType)
+		}
+		dst["op_type"] = opts.opType
+	}
+	if opts.pipeline != "" {
+		dst["pipeline"] = opts.pipeline
+	}
+
+	body := map[string]interface{}{
+		"source": src,
+		"dest":   dst,
+	}
+	if opts.script != "" {
+		body["script"] = map[string]interface{}{
+			"source": opts.script,
+			"lang":   opts.scriptLang,
+		}
+	}
+	if opts.maxDocs > 0 {
+		body["max_docs"] = opts.maxDocs
+	}
+	if opts.conflicts != "" {
+		if opts.conflicts != "abort" && opts.conflicts != "proceed" {
+			return nil, fmt.Errorf("invalid --conflicts %q: must be abort or proceed", opts.conflicts)
+		}
+		body["conflicts"] = opts.conflicts
+	}
+	return body, nil
+}
+
+// remoteSource builds a source.remote object from the cluster and user of another context
+func remoteSource(contextName string) (map[string]interface{}, error) {
+	ctx, err := config.GetContext(contextName)
+	if err != nil {
+		return nil, err
+	}
+	cluster, err := config.GetCluster(ctx.Context.Cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	remote := map[string]interface{}{"host": cluster.Cluster.Server}
+	if ctx.Context.User == "" {
+		return remote, nil
+	}
+	user, err := config.GetUser(ctx.Context.User)
+	if err != nil {
+		return nil, err
+	}
+	if user.User.APIKey != "" {
+		remote["headers"] = map[string]interface{}{"Authorization": "ApiKey " + user.User.APIKey}
+	} else if user.User.Username != "" {
+		remote["username"] = user.User.Username
+		remote["password"] = user.User.Password
+	}
+	return remote, nil
+}
+
+func printRequest(cmd *cobra.Command, body map[string]interface{}, opts types.ReindexOptions) {
+	if opts.Slices != "" {
+		cmd.Printf("Slices: %s\n", opts.Slices)
+	}
+	if opts.RequestsPerSecond != 0 {
+		cmd.Printf("Requests per second: %s\n", formatRate(opts.RequestsPerSecond))
+	}
+
+	// Never echo remote credentials
+	shown := body
+	if src, ok := body["source"].(map[string]interface{}); ok {
+		if remote, ok := src["remote"].(map[string]interface{}); ok {
+			masked := map[string]interface{}{}
+			for k, v := range remote {
+				if k == "password" || k == "headers" {
+					v = "********"
+				}
+				masked[k] = v
+			}
+			srcCopy := map[string]interface{}{}
+			for k, v := range src {
+				srcCopy[k] = v
+			}
+			srcCopy["remote"] = masked
+			shown = map[string]interface{}{}
+			for k, v := range body {
+				shown[k] = v
+			}
+			shown["source"] = srcCopy
+		}
+	}
+	data, _ := json.MarshalIndent(shown, "", "  ")
+	cmd.Printf("Request body:\n%s\n", string(data))
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func formatRate(rps float64) string {
+	if rps < 0 {
+		return "unlimited"
+	}
+	return strconv.FormatFloat(rps, 'f', -1, 64)
+}
@@ -0,0 +1,85 @@
+package reindex
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chronicblondiee/searchctl/pkg/types"
+)
+
+func TestNewReindexCmd(t *testing.T) {
+	cmd := NewReindexCmd()
+
+	if cmd.Use != "reindex SOURCE DEST" {
+		t.Errorf("Expected Use 'reindex SOURCE DEST', got %s", cmd.Use)
+	}
+	for _, name := range []string{"query", "script", "slices", "requests-per-second", "remote-context", "detach"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("Expected flag %s to be defined", name)
+		}
+	}
+
+	found := false
+	for _, sub := range cmd.Commands() {
+		if sub.Name() == "status" {
+			found = true
+			if sub.Flags().Lookup("rethrottle") == nil {
+				t.Error("Expected --rethrottle flag on status")
+			}
+		}
+	}
+	if !found {
+		t.Error("Expected status subcommand")
+	}
+}
+
+func TestBuildReindexBody(t *testing.T) {
+	opts := options{query: "status:shipped", script: "ctx._source.x = 1", scriptLang: "painless", opType: "create", maxDocs: 10}
+	remote := map[string]interface{}{"host": "https://old:9200"}
+
+	body, err := buildReindexBody("a, b", "dest", opts, remote)
+	if err != nil {
+		t.Fatalf("buildReindexBody failed: %v", err)
+	}
+
+	src := body["source"].(map[string]interface{})
+	if idx, ok := src["index"].([]string); !ok || len(idx) != 2 || idx[1] != "b" {
+		t.Errorf("Expected source index list [a b], got %v", src["index"])
+	}
+	query := src["query"].(map[string]interface{})
+	if _, ok := query["query_string"]; !ok {
+		t.Errorf("Expected query_string query, got %v", query)
+	}
+	if src["remote"] == nil {
+		t.Error("Expected remote in source")
+	}
+	if body["dest"].(map[string]interface{})["op_type"] != "create" {
+		t.Error("Expected dest op_type create")
+	}
+	if body["script"].(map[string]interface{})["source"] != "ctx._source.x = 1" {
+		t.Error("Expected script source")
+	}
+	if body["max_docs"] != 10 {
+		t.Errorf("Expected max_docs 10, got %v", body["max_docs"])
+	}
+
+	if _, err := buildReindexBody("a", "b", options{conflicts: "ignore"}, nil); err == nil {
+		t.Error("Expected error for invalid --conflicts")
+	}
+}
+
+func TestProgress(t *testing.T) {
+	st := types.BulkByScrollStatus{Total: 1000, Created: 400, Updated: 100}
+	line := progressLine(st, 10*time.Second)
+	if !strings.Contains(line, "50.0%") || !strings.Contains(line, "500/1000") {
+		t.Errorf("Unexpected progress line: %s", line)
+	}
+	if !strings.Contains(line, "ETA 10s") {
+		t.Errorf("Expected ETA 10s in: %s", line)
+	}
+
+	if _, ok := estimateETA(0, 1000, time.Second); ok {
+		t.Error("Expected no ETA before any progress")
+	}
+}
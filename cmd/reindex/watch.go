@@ -0,0 +1,180 @@
+package reindex
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/output"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+const barWidth = 30
+
+// watch polls the task until it completes. Ctrl-C only detaches; the task keeps running.
+func watch(cmd *cobra.Command, c client.SearchClient, taskID string, interval time.Duration) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	tty := output.IsTerminal(os.Stderr)
+	last := ""
+	for {
+		result, err := c.GetTask(taskID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "\nError getting task: %v\n", err)
+			os.Exit(1)
+		}
+
+		line := progressLine(statusFromTask(result.Task), time.Duration(result.Task.RunningTimeInNanos))
+		if tty {
+			cmd.Printf("\r%s\033[K", line)
+		} else if line != last {
+			cmd.Println(line)
+		}
+		last = line
+
+		if result.Completed {
+			if tty {
+				cmd.Println()
+			}
+			printSummary(cmd, taskID, result)
+			return
+		}
+
+		select {
+		case <-signals:
+			if tty {
+				cmd.Println()
+			}
+			cmd.Printf("Detached from reindex task %s; it keeps running on the cluster.\n", taskID)
+			cmd.Printf("Resume watching with: searchctl reindex status %s\n", taskID)
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+func printOnce(cmd *cobra.Command, c client.SearchClient, taskID, format string) {
+	result, err := c.GetTask(taskID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error getting task: %v\n", err)
+		os.Exit(1)
+	}
+	if format == "json" || format == "yaml" {
+		if err := output.NewFormatter(format).Format(result, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	cmd.Println(progressLine(statusFromTask(result.Task), time.Duration(result.Task.RunningTimeInNanos)))
+	if result.Completed {
+		printSummary(cmd, taskID, result)
+	}
+}
+
+// statusFromTask decodes the loosely typed task status into reindex counters
+func statusFromTask(task types.TaskInfo) types.BulkByScrollStatus {
+	var st types.BulkByScrollStatus
+	if task.Status == nil {
+		return st
+	}
+	data, err := json.Marshal(task.Status)
+	if err != nil {
+		return st
+	}
+	_ = json.Unmarshal(data, &st)
+	return st
+}
+
+func processed(st types.BulkByScrollStatus) int64 {
+	return st.Created + st.Updated + st.Deleted + st.VersionConflicts + st.Noops
+}
+
+// progressLine renders a one-line progress bar with counters and an ETA
+func progressLine(st types.BulkByScrollStatus, elapsed time.Duration) string {
+	done := processed(st)
+	pct := 0.0
+	if st.Total > 0 {
+		pct = float64(done) / float64(st.Total) * 100
+		if pct > 100 {
+			pct = 100
+		}
+	}
+	filled := int(pct / 100 * barWidth)
+	bar := strings.Repeat("#", filled) + strings.Repeat("-", barWidth-filled)
+
+	eta := "-"
+	if d, ok := estimateETA(done, st.Total, elapsed); ok {
+		eta = d.String()
+	}
+
+	line := fmt.Sprintf("[%s] %5.1f%% %d/%d created=%d updated=%d", bar, pct, done, st.Total, st.Created, st.Updated)
+	if st.VersionConflicts > 0 {
+		line += fmt.Sprintf(" conflicts=%d", st.VersionConflicts)
+	}
+	if st.RequestsPerSecond > 0 {
+		line += fmt.Sprintf(" throttle=%s/s", formatRate(st.RequestsPerSecond))
+	}
+	return line + " ETA " + eta
+}
+
+// estimateETA extrapolates the remaining time from the average rate so far
+func estimateETA(done, total int64, elapsed time.Duration) (time.Duration, bool) {
+	if total <= 0 || done <= 0 || elapsed <= 0 {
+		return 0, false
+	}
+	if done >= total {
+		return 0, true
+	}
+	rate := float64(done) / elapsed.Seconds()
+	remaining := time.Duration(float64(total-done) / rate * float64(time.Second))
+	return remaining.Round(time.Second), true
+}
+
+func printSummary(cmd *cobra.Command, taskID string, result *types.TaskResult) {
+	if result.Error != nil {
+		fmt.Fprintf(os.Stderr, "Reindex task %s failed: %v\n", taskID, errorReason(result.Error))
+		os.Exit(1)
+	}
+
+	var resp struct {
+		types.BulkByScrollStatus
+		Took     int64                    `json:"took"`
+		TimedOut bool                     `json:"timed_out"`
+		Failures []map[string]interface{} `json:"failures"`
+	}
+	data, _ := json.Marshal(result.Response)
+	_ = json.Unmarshal(data, &resp)
+
+	cmd.Printf("Reindex task %s completed in %s: %d created, %d updated, %d deleted, %d version conflicts, %d noops, %d batches\n",
+		taskID, (time.Duration(resp.Took) * time.Millisecond).Round(time.Millisecond),
+		resp.Created, resp.Updated, resp.Deleted, resp.VersionConflicts, resp.Noops, resp.Batches)
+
+	if resp.TimedOut {
+		fmt.Fprintln(os.Stderr, "Warning: some requests timed out during the reindex")
+	}
+	if len(resp.Failures) > 0 {
+		fmt.Fprintf(os.Stderr, "%d failures:\n", len(resp.Failures))
+		for _, f := range resp.Failures {
+			fmt.Fprintf(os.Stderr, "  %v/%v: %v\n", f["index"], f["id"], errorReason(f["cause"]))
+		}
+		os.Exit(1)
+	}
+}
+
+func errorReason(v interface{}) interface{} {
+	if m, ok := v.(map[string]interface{}); ok {
+		if r, ok := m["reason"]; ok {
+			return r
+		}
+	}
+	return v
+}
@@ -0,0 +1,33 @@
+package resize
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCloneIndexCmd() *cobra.Command {
+	var opts options
+
+	cmd := &cobra.Command{
+		Use:   "index SOURCE_INDEX TARGET_INDEX",
+		Short: "Clone an index into a new index",
+		Long: `Clone an existing index into a new index with the same primary shard count.
+
+The workflow sets a write block on the source, issues _clone, and waits for the
+target to become green.`,
+		Example: strings.TrimSpace(`
+  # Copy an index before a risky mapping experiment
+  searchctl clone index logs-v1 logs-v1-backup
+        `),
+		Aliases: []string{"idx"},
+		Args:    cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			run(cmd, "clone", args[0], args[1], opts)
+		},
+	}
+
+	addWorkflowFlags(cmd, &opts)
+
+	return cmd
+}
@@ -0,0 +1,403 @@
+package resize
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chronicblondiee/searchctl/pkg/alias"
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/prompt"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	requireNameSetting = "index.routing.allocation.require._name"
+	writeBlockSetting  = "index.blocks.write"
+)
+
+// options shared by the shrink, split and clone workflows
+type options struct {
+	shards       int
+	replicas     int
+	node         string
+	swapAliases  bool
+	deleteSource bool
+	timeout      time.Duration
+	interval     time.Duration
+}
+
+func addWorkflowFlags(cmd *cobra.Command, opts *options) {
+	cmd.Flags().IntVar(&opts.replicas, "replicas", -1, "number of replicas for the target index (-1 keeps the source count)")
+	cmd.Flags().BoolVar(&opts.swapAliases, "swap-aliases", false, "move aliases from the source to the target index when done")
+	cmd.Flags().BoolVar(&opts.deleteSource, "delete-source", false, "delete the source index when done")
+	cmd.Flags().DurationVar(&opts.timeout, "timeout", 30*time.Minute, "maximum time to wait for each waiting phase")
+	cmd.Flags().DurationVar(&opts.interval, "interval", 5*time.Second, "polling interval while waiting")
+	cmd.Flags().BoolP("yes", "y", false, "automatically confirm deleting the source index")
+}
+
+// workflow runs a resize operation as a sequence of reported phases
+type workflow struct {
+	cmd    *cobra.Command
+	c      client.SearchClient
+	kind   string
+	source string
+	target string
+	opts   options
+	phase  int
+	total  int
+
+	// applied holds the settings set on the source that are still to be cleared
+	applied map[string]bool
+}
+
+func (w *workflow) step(format string, args ...interface{}) {
+	w.phase++
+	w.cmd.Printf("[%d/%d] %s\n", w.phase, w.total, fmt.Sprintf(format, args...))
+}
+
+func run(cmd *cobra.Command, kind, source, target string, opts options) {
+	// validate, prepare, resize, wait for green, finish source (+ relocation wait for shrink)
+	total := 5
+	if kind == "shrink" {
+		total++
+	}
+	if opts.swapAliases {
+		total++
+	}
+
+	if viper.GetBool("dry-run") {
+		printPlan(cmd, kind, source, target, opts)
+		return
+	}
+
+	// Ask before anything changes, so that declining never leaves the source pinned or read-only
+	if opts.deleteSource && !prompt.Confirm(cmd, fmt.Sprintf("delete source index '%s' after the %s", source, kind)) {
+		fmt.Println("Operation cancelled")
+		return
+	}
+
+	c, err := client.NewClient()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+		os.Exit(1)
+	}
+
+	w := &workflow{cmd: cmd, c: c, kind: kind, source: source, target: target, opts: opts, total: total, applied: map[string]bool{}}
+	if err := w.run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		w.rollback()
+		os.Exit(1)
+	}
+}
+
+func (w *workflow) run() error {
+	w.step("Validating source index %s", w.source)
+	idx, err := w.c.GetIndex(w.source)
+	if err != nil {
+		return fmt.Errorf("getting source index: %v", err)
+	}
+	sourceShards, err := strconv.Atoi(idx.Primary)
+	if err != nil {
+		return fmt.Errorf("unexpected primary shard count %q for %s", idx.Primary, w.source)
+	}
+	if err := validateShardCount(w.kind, sourceShards, w.opts.shards); err != nil {
+		return err
+	}
+	w.cmd.Printf("      %s has %d primary shards, status %s, health %s\n", w.source, sourceShards, idx.Status, idx.Health)
+
+	if w.kind == "shrink" {
+		if err := w.prepareShrink(); err != nil {
+			return err
+		}
+	} else {
+		w.step("Setting write block on %s", w.source)
+		if err := w.c.UpdateIndexSettings(w.source, map[string]interface{}{writeBlockSetting: true}); err != nil {
+			return fmt.Errorf("setting write block: %v", err)
+		}
+		w.applied[writeBlockSetting] = true
+	}
+
+	w.step("Issuing %s of %s into %s", w.kind, w.source, w.target)
+	body := map[string]interface{}{"settings": w.targetSettings()}
+	var resp *types.ResizeResponse
+	switch w.kind {
+	case "shrink":
+		resp, err = w.c.ShrinkIndex(w.source, w.target, body)
+	case "split":
+		resp, err = w.c.SplitIndex(w.source, w.target, body)
+	default:
+		resp, err = w.c.CloneIndex(w.source, w.target, body)
+	}
+	if err != nil {
+		return err
+	}
+	w.cmd.Printf("      acknowledged=%t shards_acknowledged=%t\n", resp.Acknowledged, resp.ShardsAcknowledged)
+
+	w.step("Waiting for %s to become green", w.target)
+	if err := w.waitForGreen(); err != nil {
+		return err
+	}
+
+	if w.opts.swapAliases {
+		w.step("Moving aliases from %s to %s", w.source, w.target)
+		if err := w.swapAliases(); err != nil {
+			return err
+		}
+	}
+
+	if err := w.finishSource(); err != nil {
+		return err
+	}
+	w.cmd.Printf("Index %s created from %s by %s\n", w.target, w.source, w.kind)
+	return nil
+}
+
+// rollback clears the settings still applied to the source after a failed phase, or
+// prints the command that clears them when that fails too
+func (w *workflow) rollback() {
+	if len(w.applied) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(w.applied))
+	settings := map[string]interface{}{}
+	for k := range w.applied {
+		keys = append(keys, k)
+		settings[k] = nil
+	}
+	sort.Strings(keys)
+
+	fmt.Fprintf(os.Stderr, "Clearing %s on source index %s\n", strings.Join(keys, ", "), w.source)
+	if err := w.c.UpdateIndexSettings(w.source, settings); err != nil {
+		args := make([]string, len(keys))
+		for i, k := range keys {
+			args[i] = k + "=null"
+		}
+		fmt.Fprintf(os.Stderr, "Error clearing source settings: %v\nClear them with: searchctl set settings %s %s\n",
+			err, w.source, strings.Join(args, " "))
+	}
+}
+
+// prepareShrink pins a copy of every shard to one node and blocks writes, then waits for relocation
+func (w *workflow) prepareShrink() error {
+	rows, err := w.c.GetShards(w.source)
+	if err != nil {
+		return fmt.Errorf("getting shards: %v", err)
+	}
+	node := w.opts.node
+	if node == "" {
+		node = pickNode(rows)
+		if node == "" {
+			return fmt.Errorf("no started shards found for %s; use --node to pick a node", w.source)
+		}
+	}
+
+	w.step("Pinning %s to node %s and setting write block", w.source, node)
+	settings := map[string]interface{}{
+		requireNameSetting: node,
+		writeBlockSetting:  true,
+	}
+	if err := w.c.UpdateIndexSettings(w.source, settings); err != nil {
+		return fmt.Errorf("updating source settings: %v", err)
+	}
+	w.applied[requireNameSetting] = true
+	w.applied[writeBlockSetting] = true
+
+	w.step("Waiting for a copy of every shard to relocate to %s", node)
+	deadline := time.Now().Add(w.opts.timeout)
+	for {
+		rows, err := w.c.GetShards(w.source)
+		if err != nil {
+			return fmt.Errorf("getting shards: %v", err)
+		}
+		ready, onNode, total, moving := relocationStatus(rows, node)
+		w.cmd.Printf("      %d/%d shards on %s, %d relocating or initializing\n", onNode, total, node, moving)
+		if ready {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %s waiting for shards to relocate to %s", w.opts.timeout, node)
+		}
+		time.Sleep(w.opts.interval)
+	}
+}
+
+// targetSettings clears the settings copied from the source and applies the requested shard counts
+func (w *workflow) targetSettings() map[string]interface{} {
+	settings := map[string]interface{}{
+		writeBlockSetting: nil,
+	}
+	if w.kind == "shrink" {
+		settings[requireNameSetting] = nil
+	}
+	if w.kind != "clone" {
+		settings["index.number_of_shards"] = w.opts.shards
+	}
+	if w.opts.replicas >= 0 {
+		settings["index.number_of_replicas"] = w.opts.replicas
+	}
+	return settings
+}
+
+func (w *workflow) waitForGreen() error {
+	deadline := time.Now().Add(w.opts.timeout)
+	for {
+		health, err := w.c.WaitForHealth(w.target, "green", fmt.Sprintf("%ds", int(w.opts.interval.Seconds())+1))
+		if err != nil {
+			return fmt.Errorf("getting health of %s: %v", w.target, err)
+		}
+		if health.Status == "green" && !health.TimedOut {
+			w.cmd.Printf("      %s is green (%d active shards)\n", w.target, health.ActiveShards)
+			return nil
+		}
+		w.cmd.Printf("      %s is %s: %d initializing, %d relocating, %d unassigned\n",
+			w.target, health.Status, health.InitializingShards, health.RelocatingShards, health.UnassignedShards)
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %s waiting for %s to become green", w.opts.timeout, w.target)
+		}
+	}
+}
+
+func (w *workflow) swapAliases() error {
+	defs, err := w.c.GetAlias("*")
+	if err != nil {
+		w.cmd.Printf("      no aliases to move: %v\n", err)
+		return nil
+	}
+	actions, moved, err := alias.SwapActions(defs, w.source, w.target, nil)
+	if err != nil {
+		w.cmd.Printf("      no aliases to move: %v\n", err)
+		return nil
+	}
+	if err := w.c.UpdateAliases(actions); err != nil {
+		return fmt.Errorf("swapping aliases: %v", err)
+	}
+	// Writes now go to the target, so the source keeps its write block from here on
+	delete(w.applied, writeBlockSetting)
+	w.cmd.Printf("      moved %s\n", strings.Join(moved, ","))
+	return nil
+}
+
+// finishSource deletes the source or undoes the settings applied to it
+func (w *workflow) finishSource() error {
+	if w.opts.deleteSource {
+		w.step("Deleting source index %s", w.source)
+		if err := w.c.DeleteIndex(w.source); err != nil {
+			return fmt.Errorf("deleting source index: %v", err)
+		}
+		w.applied = map[string]bool{}
+		return nil
+	}
+
+	w.step("Restoring settings on source index %s", w.source)
+	settings := map[string]interface{}{}
+	if w.kind == "shrink" {
+		settings[requireNameSetting] = nil
+	}
+	// Keep the source read-only once its aliases point at the target
+	if !w.opts.swapAliases {
+		settings[writeBlockSetting] = nil
+	} else {
+		w.cmd.Printf("      %s stays write-blocked because its aliases moved\n", w.source)
+	}
+	if len(settings) == 0 {
+		return nil
+	}
+	if err := w.c.UpdateIndexSettings(w.source, settings); err != nil {
+		return fmt.Errorf("restoring source settings: %v", err)
+	}
+	w.applied = map[string]bool{}
+	return nil
+}
+
+func printPlan(cmd *cobra.Command, kind, source, target string, opts options) {
+	cmd.Printf("Would %s index %s into %s\n", kind, source, target)
+	if kind != "clone" {
+		cmd.Printf("  target primary shards: %d\n", opts.shards)
+	}
+	if kind == "shrink" {
+		node := opts.node
+		if node == "" {
+			node = "<node holding most shards>"
+		}
+		cmd.Printf("  pin a copy of every shard to node: %s\n", node)
+	}
+	cmd.Printf("  set %s=true on %s\n", writeBlockSetting, source)
+	cmd.Printf("  wait for %s to become green\n", target)
+	if opts.swapAliases {
+		cmd.Printf("  move aliases from %s to %s\n", source, target)
+	}
+	if opts.deleteSource {
+		cmd.Printf("  delete %s\n", source)
+	}
+}
+
+// validateShardCount checks the target primary count against the resize rules for kind
+func validateShardCount(kind string, source, target int) error {
+	if source < 1 {
+		return fmt.Errorf("source index has %d primary shards", source)
+	}
+	switch kind {
+	case "shrink":
+		if target < 1 || target >= source || source%target != 0 {
+			return fmt.Errorf("cannot shrink %d shards to %d: target must be a factor of the source shard count and smaller than it", source, target)
+		}
+	case "split":
+		if target <= source || target%source != 0 {
+			return fmt.Errorf("cannot split %d shards into %d: target must be a multiple of the source shard count and larger than it", source, target)
+		}
+	}
+	return nil
+}
+
+// pickNode returns the node already holding the most started copies of the index
+func pickNode(rows []types.CatShardRow) string {
+	counts := map[string]int{}
+	for _, r := range rows {
+		if r.State == "STARTED" && r.Node != "" {
+			counts[r.Node]++
+		}
+	}
+	nodes := make([]string, 0, len(counts))
+	for n := range counts {
+		nodes = append(nodes, n)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		if counts[nodes[i]] == counts[nodes[j]] {
+			return nodes[i] < nodes[j]
+		}
+		return counts[nodes[i]] > counts[nodes[j]]
+	})
+	if len(nodes) == 0 {
+		return ""
+	}
+	return nodes[0]
+}
+
+// relocationStatus reports whether every shard has a started copy on node and nothing is still moving
+func relocationStatus(rows []types.CatShardRow, node string) (ready bool, onNode, total, moving int) {
+	shards := map[string]bool{}
+	for _, r := range rows {
+		if _, seen := shards[r.Shard]; !seen {
+			shards[r.Shard] = false
+		}
+		if r.Node == node && r.State == "STARTED" {
+			shards[r.Shard] = true
+		}
+		if r.State == "RELOCATING" || r.State == "INITIALIZING" {
+			moving++
+		}
+	}
+	for _, ok := range shards {
+		if ok {
+			onNode++
+		}
+	}
+	total = len(shards)
+	return total > 0 && onNode == total && moving == 0, onNode, total, moving
+}
@@ -0,0 +1,116 @@
+package resize
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+func TestNewShrinkCmd(t *testing.T) {
+	cmd := NewShrinkCmd()
+
+	if cmd.Use != "shrink SOURCE_INDEX [TARGET_INDEX]" {
+		t.Errorf("Unexpected Use: %s", cmd.Use)
+	}
+	for _, name := range []string{"to", "node", "replicas", "swap-aliases", "delete-source", "timeout", "yes"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("Expected flag %s to be defined", name)
+		}
+	}
+}
+
+func TestValidateShardCount(t *testing.T) {
+	tests := []struct {
+		kind    string
+		source  int
+		target  int
+		wantErr bool
+	}{
+		{"shrink", 4, 1, false},
+		{"shrink", 4, 2, false},
+		{"shrink", 4, 3, true},
+		{"shrink", 4, 4, true},
+		{"split", 2, 8, false},
+		{"split", 2, 3, true},
+		{"split", 2, 2, true},
+		{"clone", 3, 0, false},
+		{"split", 0, 2, true},
+		{"shrink", 0, 1, true},
+	}
+	for _, tt := range tests {
+		err := validateShardCount(tt.kind, tt.source, tt.target)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("validateShardCount(%s, %d, %d) error = %v, wantErr %v", tt.kind, tt.source, tt.target, err, tt.wantErr)
+		}
+	}
+}
+
+func TestRelocationStatus(t *testing.T) {
+	rows := []types.CatShardRow{
+		{Shard: "0", State: "STARTED", Node: "n1"},
+		{Shard: "0", State: "STARTED", Node: "n2"},
+		{Shard: "1", State: "RELOCATING", Node: "n2"},
+		{Shard: "1", State: "UNASSIGNED"},
+	}
+
+	if node := pickNode(rows); node != "n1" {
+		t.Errorf("Expected tie to resolve to n1, got %s", node)
+	}
+
+	ready, onNode, total, moving := relocationStatus(rows, "n1")
+	if ready || onNode != 1 || total != 2 || moving != 1 {
+		t.Errorf("Unexpected status: ready=%t onNode=%d total=%d moving=%d", ready, onNode, total, moving)
+	}
+
+	rows[2] = types.CatShardRow{Shard: "1", State: "STARTED", Node: "n1"}
+	if ready, _, _, _ := relocationStatus(rows, "n1"); !ready {
+		t.Error("Expected shards to be ready once every shard has a started copy on n1")
+	}
+}
+
+// resizeClient records settings updates and fails the resize request
+type resizeClient struct {
+	client.SearchClient
+	updates []map[string]interface{}
+}
+
+func (c *resizeClient) GetIndex(name string) (*types.Index, error) {
+	return &types.Index{Name: name, Primary: "4", Status: "open", Health: "green"}, nil
+}
+
+func (c *resizeClient) GetShards(pattern string) ([]types.CatShardRow, error) {
+	return []types.CatShardRow{{Shard: "0", State: "STARTED", Node: "n1"}}, nil
+}
+
+func (c *resizeClient) UpdateIndexSettings(name string, body map[string]interface{}) error {
+	c.updates = append(c.updates, body)
+	return nil
+}
+
+func (c *resizeClient) ShrinkIndex(source, target string, body map[string]interface{}) (*types.ResizeResponse, error) {
+	return nil, fmt.Errorf("error shrinking index: target exists")
+}
+
+func TestWorkflowRollback(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.SetOut(io.Discard)
+	c := &resizeClient{}
+	w := &workflow{cmd: cmd, c: c, kind: "shrink", source: "logs", target: "logs-shrunk",
+		opts: options{shards: 1}, total: 6, applied: map[string]bool{}}
+
+	if err := w.run(); err == nil {
+		t.Fatal("Expected the failed shrink to return an error")
+	}
+	w.rollback()
+
+	last := c.updates[len(c.updates)-1]
+	expected := map[string]interface{}{requireNameSetting: nil, writeBlockSetting: nil}
+	if !reflect.DeepEqual(last, expected) {
+		t.Errorf("Expected rollback to clear %v, got %v", expected, last)
+	}
+}
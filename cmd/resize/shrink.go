@@ -0,0 +1,44 @@
+package resize
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func NewShrinkCmd() *cobra.Command {
+	var opts options
+
+	cmd := &cobra.Command{
+		Use:   "shrink SOURCE_INDEX [TARGET_INDEX]",
+		Short: "Shrink an index into fewer primary shards",
+		Long: `Shrink an index into a new index with fewer primary shards.
+
+The workflow pins a copy of every shard to a single node and sets a write block,
+waits on _cat/shards until relocation completes, issues _shrink, and waits for the
+target to become green. Afterwards the source settings are restored, or the source
+is deleted with --delete-source. If a phase fails, the settings applied to the
+source are cleared again. TARGET_INDEX defaults to SOURCE_INDEX-shrunk.`,
+		Example: strings.TrimSpace(`
+  # Shrink to a single shard on the node already holding most shards
+  searchctl shrink logs-2024.01 --to 1
+
+  # Pick the node, move aliases and drop the source
+  searchctl shrink logs-2024.01 logs-2024.01-s1 --to 1 --node data-3 --swap-aliases --delete-source -y
+        `),
+		Args: cobra.RangeArgs(1, 2),
+		Run: func(cmd *cobra.Command, args []string) {
+			target := args[0] + "-shrunk"
+			if len(args) > 1 {
+				target = args[1]
+			}
+			run(cmd, "shrink", args[0], target, opts)
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.shards, "to", 1, "number of primary shards for the target index")
+	cmd.Flags().StringVar(&opts.node, "node", "", "node to gather shards on (default: node holding most shards)")
+	addWorkflowFlags(cmd, &opts)
+
+	return cmd
+}
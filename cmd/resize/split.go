@@ -0,0 +1,38 @@
+package resize
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func NewSplitCmd() *cobra.Command {
+	var opts options
+
+	cmd := &cobra.Command{
+		Use:   "split SOURCE_INDEX [TARGET_INDEX] --to N",
+		Short: "Split an index into more primary shards",
+		Long: `Split an index into a new index with a multiple of its primary shards.
+
+The workflow sets a write block on the source, issues _split, and waits for the
+target to become green. TARGET_INDEX defaults to SOURCE_INDEX-split.`,
+		Example: strings.TrimSpace(`
+  # Split a 2-shard index into 8 shards
+  searchctl split metrics-v1 metrics-v2 --to 8 --swap-aliases
+        `),
+		Args: cobra.RangeArgs(1, 2),
+		Run: func(cmd *cobra.Command, args []string) {
+			target := args[0] + "-split"
+			if len(args) > 1 {
+				target = args[1]
+			}
+			run(cmd, "split", args[0], target, opts)
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.shards, "to", 0, "number of primary shards for the target index (required)")
+	cmd.MarkFlagRequired("to")
+	addWorkflowFlags(cmd, &opts)
+
+	return cmd
+}
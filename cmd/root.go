@@ -4,12 +4,32 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/chronicblondiee/searchctl/cmd/alias"
+	"github.com/chronicblondiee/searchctl/cmd/analyze"
+	"github.com/chronicblondiee/searchctl/cmd/cancel"
 	"github.com/chronicblondiee/searchctl/cmd/clone"
 	"github.com/chronicblondiee/searchctl/cmd/create"
 	"github.com/chronicblondiee/searchctl/cmd/delete"
 	"github.com/chronicblondiee/searchctl/cmd/describe"
+	"github.com/chronicblondiee/searchctl/cmd/doc"
+	"github.com/chronicblondiee/searchctl/cmd/doctor"
+	"github.com/chronicblondiee/searchctl/cmd/dump"
+	"github.com/chronicblondiee/searchctl/cmd/fields"
 	"github.com/chronicblondiee/searchctl/cmd/get"
+	"github.com/chronicblondiee/searchctl/cmd/index"
+	"github.com/chronicblondiee/searchctl/cmd/lint"
+	"github.com/chronicblondiee/searchctl/cmd/load"
+	"github.com/chronicblondiee/searchctl/cmd/node"
+	"github.com/chronicblondiee/searchctl/cmd/put"
+	"github.com/chronicblondiee/searchctl/cmd/reindex"
+	"github.com/chronicblondiee/searchctl/cmd/resize"
 	"github.com/chronicblondiee/searchctl/cmd/rollover"
+	"github.com/chronicblondiee/searchctl/cmd/search"
+	"github.com/chronicblondiee/searchctl/cmd/set"
+	"github.com/chronicblondiee/searchctl/cmd/simulate"
+	"github.com/chronicblondiee/searchctl/cmd/snapshot"
+	"github.com/chronicblondiee/searchctl/cmd/sql"
+	"github.com/chronicblondiee/searchctl/cmd/wait"
 	"github.com/chronicblondiee/searchctl/pkg/config"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -49,7 +69,7 @@ func NewRootCmd() *cobra.Command {
 func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.searchctl/config.yaml)")
 	rootCmd.PersistentFlags().StringVar(&context, "context", "", "override current context")
-	rootCmd.PersistentFlags().StringVarP(&outputFlag, "output", "o", "table", "output format (table|json|yaml|wide)")
+	rootCmd.PersistentFlags().StringVarP(&outputFlag, "output", "o", "table", "output format (table|json|yaml|wide|csv)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
 	rootCmd.PersistentFlags().Bool("dry-run", false, "show what would be done without executing")
 
@@ -65,6 +85,32 @@ func init() {
 	rootCmd.AddCommand(delete.NewDeleteCmd())
 	rootCmd.AddCommand(rollover.NewRolloverCmd())
 	rootCmd.AddCommand(clone.NewCloneCmd())
+	rootCmd.AddCommand(alias.NewAliasCmd())
+	rootCmd.AddCommand(set.NewSetCmd())
+	rootCmd.AddCommand(put.NewPutCmd())
+	rootCmd.AddCommand(index.NewIndexCmd())
+	rootCmd.AddCommand(resize.NewShrinkCmd())
+	rootCmd.AddCommand(resize.NewSplitCmd())
+	rootCmd.AddCommand(reindex.NewReindexCmd())
+	rootCmd.AddCommand(cancel.NewCancelCmd())
+	rootCmd.AddCommand(wait.NewWaitCmd())
+	rootCmd.AddCommand(snapshot.NewSnapshotCmd())
+	rootCmd.AddCommand(snapshot.NewRestoreCmd())
+	rootCmd.AddCommand(doc.NewDocCmd())
+	rootCmd.AddCommand(load.NewLoadCmd())
+	rootCmd.AddCommand(dump.NewDumpCmd())
+	rootCmd.AddCommand(search.NewSearchCmd())
+	rootCmd.AddCommand(search.NewCountCmd())
+	rootCmd.AddCommand(search.NewTailCmd())
+	rootCmd.AddCommand(sql.NewSQLCmd())
+	rootCmd.AddCommand(sql.NewPPLCmd())
+	rootCmd.AddCommand(analyze.NewAnalyzeCmd())
+	rootCmd.AddCommand(fields.NewFieldsCmd())
+	rootCmd.AddCommand(simulate.NewSimulateCmd())
+	rootCmd.AddCommand(lint.NewLintCmd())
+	rootCmd.AddCommand(node.NewNodeCmd())
+	rootCmd.AddCommand(node.NewRollingRestartCmd())
+	rootCmd.AddCommand(doctor.NewDoctorCmd())
 	rootCmd.AddCommand(NewApplyCmd())
 	rootCmd.AddCommand(NewConfigCmd())
 	rootCmd.AddCommand(NewClusterCmd())
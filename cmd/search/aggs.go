@@ -0,0 +1,167 @@
+package search
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// aggTable is one rendered aggregation, titled with its path through the parent buckets
+type aggTable struct {
+	title string
+	rows  []interface{}
+}
+
+// Bucket keys that describe the bucket itself rather than a sub-aggregation
+var bucketFields = map[string]bool{
+	"key": true, "key_as_string": true, "doc_count": true, "from": true, "from_as_string": true,
+	"to": true, "to_as_string": true, "meta": true, "doc_count_error_upper_bound": true,
+	"sum_other_doc_count": true, "buckets": true, "bg_count": true, "score": true,
+}
+
+// aggTables renders bucket aggregations as tables with sub-aggregation metrics as columns.
+// Bucket aggregations nested inside buckets become their own tables, and top level metric
+// aggregations are collected into a single NAME/VALUE table.
+func aggTables(aggs map[string]interface{}) []aggTable {
+	var tables []aggTable
+	var metrics []interface{}
+	for _, name := range sortedKeys(aggs) {
+		agg, ok := aggs[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if isBucketAgg(agg) {
+			tables = append(tables, bucketTables(name, agg)...)
+			continue
+		}
+		values := metricValues(name, agg)
+		for _, col := range sortedKeys(values) {
+			metrics = append(metrics, map[string]interface{}{"__columns": "NAME,VALUE", "NAME": col, "VALUE": values[col]})
+		}
+	}
+	if len(metrics) > 0 {
+		tables = append([]aggTable{{title: "metrics", rows: metrics}}, tables...)
+	}
+	return tables
+}
+
+func isBucketAgg(agg map[string]interface{}) bool {
+	_, buckets := agg["buckets"]
+	_, docCount := agg["doc_count"]
+	return buckets || docCount
+}
+
+// bucketTables renders a multi-bucket aggregation, or a single-bucket one such as filter
+// or nested as a table with a single row
+func bucketTables(path string, agg map[string]interface{}) []aggTable {
+	var buckets []map[string]interface{}
+	switch b := agg["buckets"].(type) {
+	case []interface{}:
+		for _, x := range b {
+			if bucket, ok := x.(map[string]interface{}); ok {
+				buckets = append(buckets, bucket)
+			}
+		}
+	case map[string]interface{}:
+		// Keyed buckets, as returned by filters and keyed range aggregations
+		for _, key := range sortedKeys(b) {
+			if bucket, ok := b[key].(map[string]interface{}); ok {
+				keyed := map[string]interface{}{"key": key}
+				for k, v := range bucket {
+					keyed[k] = v
+				}
+				buckets = append(buckets, keyed)
+			}
+		}
+	default:
+		buckets = []map[string]interface{}{agg}
+	}
+
+	var rows []interface{}
+	var nested []aggTable
+	columns := map[string]bool{}
+	for _, bucket := range buckets {
+		key := bucketKey(bucket)
+		row := map[string]interface{}{"KEY": key, "DOC_COUNT": bucket["doc_count"]}
+		for _, name := range sortedKeys(bucket) {
+			sub, ok := bucket[name].(map[string]interface{})
+			if bucketFields[name] || !ok {
+				continue
+			}
+			if isBucketAgg(sub) {
+				subPath := path + " > " + name
+				if _, multi := agg["buckets"]; multi {
+					subPath = path + " > " + key + " > " + name
+				}
+				nested = append(nested, bucketTables(subPath, sub)...)
+				continue
+			}
+			for col, v := range metricValues(name, sub) {
+				row[strings.ToUpper(col)] = v
+				columns[strings.ToUpper(col)] = true
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	pref := strings.Join(append([]string{"KEY", "DOC_COUNT"}, sortedKeys(columns)...), ",")
+	for _, r := range rows {
+		row := r.(map[string]interface{})
+		row["__columns"] = pref
+		for col := range columns {
+			if _, ok := row[col]; !ok {
+				row[col] = ""
+			}
+		}
+	}
+	return append([]aggTable{{title: path, rows: rows}}, nested...)
+}
+
+func bucketKey(bucket map[string]interface{}) string {
+	if s, ok := bucket["key_as_string"].(string); ok {
+		return s
+	}
+	if key, ok := bucket["key"]; ok {
+		return formatValue(key)
+	}
+	return "-"
+}
+
+// metricValues flattens a metric aggregation into columns: NAME for single value metrics,
+// NAME.STAT for stats and NAME.PERCENT for percentiles
+func metricValues(name string, agg map[string]interface{}) map[string]interface{} {
+	if s, ok := agg["value_as_string"].(string); ok {
+		return map[string]interface{}{name: s}
+	}
+	if v, ok := agg["value"]; ok {
+		return map[string]interface{}{name: formatValue(v)}
+	}
+	out := map[string]interface{}{}
+	if values, ok := agg["values"].(map[string]interface{}); ok {
+		for k, v := range values {
+			out[name+"."+k] = formatValue(v)
+		}
+		return out
+	}
+	for k, v := range agg {
+		switch v.(type) {
+		case json.Number, float64, int, int64:
+			out[name+"."+k] = formatValue(v)
+		}
+	}
+	if len(out) == 0 {
+		data, _ := json.Marshal(agg)
+		out[name] = fmt.Sprintf("%.80s", data)
+	}
+	return out
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,73 @@
+package search
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/output"
+	"github.com/chronicblondiee/searchctl/pkg/query"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewCountCmd() *cobra.Command {
+	var q, from, to, timeField string
+
+	cmd := &cobra.Command{
+		Use:   "count INDEX",
+		Short: "Count the documents matching a query",
+		Long: `Count the documents in INDEX that match a query string or JSON query.
+
+--from and --to restrict the count to a time range on --time-field, in the same
+way as for search. The table output is just the number, so it can be used in
+scripts; -o json and -o yaml print the index and count.`,
+		Example: strings.TrimSpace(`
+# All documents
+searchctl count logs-app
+
+# Errors from the last hour
+searchctl count logs-app -q 'level:ERROR' --from 1h`),
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			index := args[0]
+			parsed, err := query.Parse(q)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			parsed = query.WithTimeRange(parsed, timeField, from, to)
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			count, err := c.Count(index, parsed)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error counting %s: %v\n", index, err)
+				os.Exit(1)
+			}
+
+			outFmt := viper.GetString("output")
+			if outFmt == "json" || outFmt == "yaml" {
+				result := map[string]interface{}{"index": index, "count": count}
+				if err := output.NewFormatter(outFmt).Format(result, cmd.OutOrStdout()); err != nil {
+					fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+					os.Exit(1)
+				}
+				return
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), count)
+		},
+	}
+
+	cmd.Flags().StringVarP(&q, "query", "q", "", "query string or JSON query DSL (default match_all)")
+	cmd.Flags().StringVar(&from, "from", "", "only documents at or after this time (date, date math or duration such as 15m)")
+	cmd.Flags().StringVar(&to, "to", "", "only documents at or before this time (date, date math or duration)")
+	cmd.Flags().StringVar(&timeField, "time-field", "@timestamp", "field used by --from and --to")
+
+	return cmd
+}
@@ -0,0 +1,323 @@
+package search
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/output"
+	"github.com/chronicblondiee/searchctl/pkg/query"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+type options struct {
+	query     string
+	filename  string
+	size      int
+	sort      []string
+	fields    []string
+	from      string
+	to        string
+	timeField string
+}
+
+// Top level keys of a search request body. A file without any of them is taken to be a query.
+var bodyKeys = []string{"query", "aggs", "aggregations", "size", "from", "sort", "_source", "fields", "highlight", "post_filter", "track_total_hits", "collapse", "runtime_mappings"}
+
+func NewSearchCmd() *cobra.Command {
+	var opts options
+
+	cmd := &cobra.Command{
+		Use:   "search INDEX",
+		Short: "Search an index, data stream or alias",
+		Long: `Search INDEX with a Lucene query string (-q) or a query DSL file (-f).
+
+-q accepts a query string such as 'status:500 AND service:api', or a JSON query.
+-f accepts a JSON or YAML search request body, including aggregations; a file
+containing only a query clause is used as the query. --size, --sort and --fields
+override the corresponding parts of the body.
+
+--from and --to restrict the results to a time range on --time-field
+(@timestamp by default). They accept dates, date math such as now-1d/d, or a
+duration such as 15m or 7d, meaning that long ago.
+
+The table shows one row per hit with the requested --fields, or the compacted
+_source when no fields are given. Aggregations are rendered as one table per
+bucket aggregation, with sub-aggregation metrics as columns and nested bucket
+aggregations as further tables. -o json and -o yaml print the raw response.`,
+		Example: strings.TrimSpace(`
+# Lucene query string
+searchctl search logs-app -q 'status:500 AND service:api'
+
+# Last 15 minutes, newest first, selected fields
+searchctl search logs-app -q 'level:ERROR' --from 15m --sort @timestamp:desc --fields @timestamp,host.name,message
+
+# Query DSL with aggregations from a file, without hits
+searchctl search orders -f top-customers.json --size 0
+
+# Read the body from stdin
+echo '{"query":{"term":{"status":"shipped"}}}' | searchctl search orders -f -`),
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			index := args[0]
+			body, err := buildBody(cmd, opts)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			resp, err := c.Search(index, body, types.SearchOptions{})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error searching %s: %v\n", index, err)
+				os.Exit(1)
+			}
+
+			if err := printResponse(cmd, resp, opts.fields); err != nil {
+				fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.query, "query", "q", "", "query string or JSON query DSL (default match_all)")
+	cmd.Flags().StringVarP(&opts.filename, "filename", "f", "", "JSON or YAML search body or query (- for stdin)")
+	cmd.Flags().IntVar(&opts.size, "size", 10, "number of hits to return")
+	cmd.Flags().StringSliceVar(&opts.sort, "sort", nil, "sort by FIELD[:asc|desc] (repeatable)")
+	cmd.Flags().StringSliceVar(&opts.fields, "fields", nil, "source fields to return and show as columns")
+	cmd.Flags().StringVar(&opts.from, "from", "", "only hits at or after this time (date, date math or duration such as 15m)")
+	cmd.Flags().StringVar(&opts.to, "to", "", "only hits at or before this time (date, date math or duration)")
+	cmd.Flags().StringVar(&opts.timeField, "time-field", "@timestamp", "field used by --from and --to")
+	cmd.MarkFlagsMutuallyExclusive("query", "filename")
+
+	return cmd
+}
+
+// buildBody assembles the search request from the query or file and the flags
+func buildBody(cmd *cobra.Command, opts options) (map[string]interface{}, error) {
+	body := map[string]interface{}{}
+	if opts.filename != "" {
+		data, err := readFile(opts.filename)
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := parseBody(data)
+		if err != nil {
+			return nil, err
+		}
+		if isRequestBody(parsed) {
+			body = parsed
+		} else {
+			body["query"] = parsed
+		}
+	} else {
+		q, err := query.Parse(opts.query)
+		if err != nil {
+			return nil, err
+		}
+		body["query"] = q
+	}
+
+	q, _ := body["query"].(map[string]interface{})
+	if opts.from != "" || opts.to != "" {
+		body["query"] = query.WithTimeRange(q, opts.timeField, opts.from, opts.to)
+	}
+
+	if _, ok := body["size"]; !ok || cmd.Flags().Changed("size") {
+		body["size"] = opts.size
+	}
+	if len(opts.sort) > 0 {
+		sort, err := query.ParseSort(opts.sort)
+		if err != nil {
+			return nil, err
+		}
+		body["sort"] = sort
+	}
+	if len(opts.fields) > 0 {
+		body["_source"] = opts.fields
+	}
+	return body, nil
+}
+
+func isRequestBody(body map[string]interface{}) bool {
+	for _, k := range bodyKeys {
+		if _, ok := body[k]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func readFile(filename string) ([]byte, error) {
+	if filename == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(filename)
+}
+
+func parseBody(data []byte) (map[string]interface{}, error) {
+	var body map[string]interface{}
+	trimmed := bytes.TrimSpace(data)
+	if bytes.HasPrefix(trimmed, []byte("{")) {
+		dec := json.NewDecoder(bytes.NewReader(trimmed))
+		dec.UseNumber()
+		if err := dec.Decode(&body); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON: %w", err)
+		}
+	} else if err := yaml.Unmarshal(trimmed, &body); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	if body == nil {
+		return nil, fmt.Errorf("search body is empty")
+	}
+	return body, nil
+}
+
+func printResponse(cmd *cobra.Command, resp *types.SearchResponse, fields []string) error {
+	outFmt := viper.GetString("output")
+	w := cmd.OutOrStdout()
+	if outFmt == "json" || outFmt == "yaml" {
+		return output.NewFormatter(outFmt).Format(resp, w)
+	}
+
+	formatter := output.NewFormatter(outFmt)
+	printed := false
+	if len(resp.Hits.Hits) > 0 || len(resp.Aggregations) == 0 {
+		if err := formatter.Format(hitRows(resp.Hits.Hits, fields, outFmt == "wide"), w); err != nil {
+			return err
+		}
+		printed = true
+	}
+	for _, t := range aggTables(resp.Aggregations) {
+		if printed {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%s:\n", t.title)
+		if err := formatter.Format(t.rows, w); err != nil {
+			return err
+		}
+		printed = true
+	}
+
+	total := fmt.Sprintf("%d", resp.Hits.Total.Value)
+	if resp.Hits.Total.Relation == "gte" {
+		total += "+"
+	}
+	cmd.Printf("Showing %d of %s hits (took %dms)\n", len(resp.Hits.Hits), total, resp.Took)
+	return nil
+}
+
+// hitRows renders hits as table rows with the metadata columns followed by either the
+// requested fields or the compacted _source, which is truncated unless wide is set
+func hitRows(hits []types.SearchHit, fields []string, wide bool) []interface{} {
+	columns := []string{"INDEX", "ID", "SCORE"}
+	if len(fields) > 0 {
+		for _, f := range fields {
+			columns = append(columns, strings.ToUpper(f))
+		}
+	} else {
+		columns = append(columns, "SOURCE")
+	}
+	pref := strings.Join(columns, ",")
+
+	rows := make([]interface{}, len(hits))
+	for i, h := range hits {
+		row := map[string]interface{}{
+			"__columns": pref,
+			"INDEX":     h.Index,
+			"ID":        h.ID,
+			"SCORE":     "-",
+		}
+		if h.Score != nil {
+			row["SCORE"] = fmt.Sprintf("%.3f", *h.Score)
+		}
+
+		if len(fields) == 0 {
+			var buf bytes.Buffer
+			source := string(h.Source)
+			if err := json.Compact(&buf, h.Source); err == nil {
+				source = buf.String()
+			}
+			if !wide {
+				source = truncate(source, 100)
+			}
+			row["SOURCE"] = source
+		} else {
+			var source map[string]interface{}
+			dec := json.NewDecoder(bytes.NewReader(h.Source))
+			dec.UseNumber()
+			_ = dec.Decode(&source)
+			for _, f := range fields {
+				row[strings.ToUpper(f)] = formatValue(lookup(source, f))
+			}
+		}
+		rows[i] = row
+	}
+	return rows
+}
+
+// lookup finds a field by its dotted path, accepting both nested objects and
+// keys that contain dots
+func lookup(source map[string]interface{}, path string) interface{} {
+	if v, ok := source[path]; ok {
+		return v
+	}
+	for i := strings.Index(path, "."); i > 0; i = nextDot(path, i) {
+		if obj, ok := source[path[:i]].(map[string]interface{}); ok {
+			if v := lookup(obj, path[i+1:]); v != nil {
+				return v
+			}
+		}
+	}
+	return nil
+}
+
+func nextDot(path string, i int) int {
+	j := strings.Index(path[i+1:], ".")
+	if j < 0 {
+		return -1
+	}
+	return i + 1 + j
+}
+
+func formatValue(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case json.Number:
+		return val.String()
+	case []interface{}:
+		parts := make([]string, len(val))
+		for i, x := range val {
+			parts[i] = formatValue(x)
+		}
+		return strings.Join(parts, ",")
+	case map[string]interface{}:
+		data, _ := json.Marshal(val)
+		return string(data)
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
+
+func truncate(s string, max int) string {
+	r := []rune(s)
+	if len(r) <= max {
+		return s
+	}
+	return string(r[:max-3]) + "..."
+}
@@ -0,0 +1,287 @@
+package search
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+)
+
+func TestNewSearchCmd(t *testing.T) {
+	cmd := NewSearchCmd()
+
+	if cmd.Use != "search INDEX" {
+		t.Errorf("Expected Use 'search INDEX', got %s", cmd.Use)
+	}
+	for _, name := range []string{"query", "filename", "size", "sort", "fields", "from", "to", "time-field"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("Expected flag %s", name)
+		}
+	}
+}
+
+func TestBuildBody(t *testing.T) {
+	cmd := NewSearchCmd()
+	body, err := buildBody(cmd, options{query: "status:500", size: 10, sort: []string{"@timestamp:desc"}, fields: []string{"message"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := map[string]interface{}{
+		"query":   map[string]interface{}{"query_string": map[string]interface{}{"query": "status:500"}},
+		"size":    10,
+		"sort":    []interface{}{map[string]interface{}{"@timestamp": "desc"}},
+		"_source": []string{"message"},
+	}
+	if !reflect.DeepEqual(body, expected) {
+		t.Errorf("Expected %v, got %v", expected, body)
+	}
+}
+
+func TestBuildBodyFromFile(t *testing.T) {
+	dir := t.TempDir()
+	full := filepath.Join(dir, "body.json")
+	os.WriteFile(full, []byte(`{"size": 0, "aggs": {"by_status": {"terms": {"field": "status"}}}}`), 0644)
+	queryOnly := filepath.Join(dir, "query.yaml")
+	os.WriteFile(queryOnly, []byte("term:\n  status: shipped\n"), 0644)
+
+	cmd := NewSearchCmd()
+	body, err := buildBody(cmd, options{filename: full, size: 10})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if body["size"] != json.Number("0") {
+		t.Errorf("Expected size from the file to be kept, got %v", body["size"])
+	}
+	if _, ok := body["aggs"]; !ok {
+		t.Error("Expected aggs to be kept")
+	}
+
+	body, err = buildBody(cmd, options{filename: queryOnly, size: 10, from: "1h", timeField: "@timestamp"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	q := body["query"].(map[string]interface{})["bool"].(map[string]interface{})
+	must := q["must"].([]interface{})[0].(map[string]interface{})
+	if _, ok := must["term"]; !ok {
+		t.Errorf("Expected the file to be used as the query, got %v", must)
+	}
+}
+
+func TestHitRows(t *testing.T) {
+	score := 1.5
+	hits := []types.SearchHit{{
+		Index:  "logs",
+		ID:     "1",
+		Score:  &score,
+		Source: json.RawMessage(`{"host": {"name": "web-1"}, "service.name": "api", "tags": ["a", "b"], "bytes": 12345678901234567890}`),
+	}}
+
+	row := hitRows(hits, []string{"host.name", "service.name", "tags", "bytes", "missing"}, false)[0].(map[string]interface{})
+	expected := map[string]interface{}{
+		"__columns":    "INDEX,ID,SCORE,HOST.NAME,SERVICE.NAME,TAGS,BYTES,MISSING",
+		"INDEX":        "logs",
+		"ID":           "1",
+		"SCORE":        "1.500",
+		"HOST.NAME":    "web-1",
+		"SERVICE.NAME": "api",
+		"TAGS":         "a,b",
+		"BYTES":        "12345678901234567890",
+		"MISSING":      "",
+	}
+	if !reflect.DeepEqual(row, expected) {
+		t.Errorf("Expected %v, got %v", expected, row)
+	}
+
+	row = hitRows(hits, nil, false)[0].(map[string]interface{})
+	if row["SOURCE"] != `{"host":{"name":"web-1"},"service.name":"api","tags":["a","b"],"bytes":12345678901234567890}` {
+		t.Errorf("Unexpected SOURCE %v", row["SOURCE"])
+	}
+}
+
+func TestAggTables(t *testing.T) {
+	var aggs map[string]interface{}
+	json.Unmarshal([]byte(`{
+		"avg_price": {"value": 12.5},
+		"by_status": {"buckets": [
+			{"key": "shipped", "doc_count": 3, "revenue": {"value": 30},
+			 "by_day": {"buckets": [{"key": 1, "key_as_string": "2024-01-01", "doc_count": 3}]}},
+			{"key": "pending", "doc_count": 1, "revenue": {"value": 5}}
+		]}
+	}`), &aggs)
+
+	tables := aggTables(aggs)
+	var titles []string
+	for _, tbl := range tables {
+		titles = append(titles, tbl.title)
+	}
+	if !reflect.DeepEqual(titles, []string{"metrics", "by_status", "by_status > shipped > by_day"}) {
+		t.Fatalf("Unexpected tables %v", titles)
+	}
+
+	metric := tables[0].rows[0].(map[string]interface{})
+	if metric["NAME"] != "avg_price" || metric["VALUE"] != "12.5" {
+		t.Errorf("Unexpected metric row %v", metric)
+	}
+	bucket := tables[1].rows[0].(map[string]interface{})
+	if bucket["KEY"] != "shipped" || bucket["REVENUE"] != "30" || bucket["__columns"] != "KEY,DOC_COUNT,REVENUE" {
+		t.Errorf("Unexpected bucket row %v", bucket)
+	}
+	nested := tables[2].rows[0].(map[string]interface{})
+	if nested["KEY"] != "2024-01-01" {
+		t.Errorf("Expected key_as_string, got %v", nested["KEY"])
+	}
+}
+
+func TestNewCountCmd(t *testing.T) {
+	cmd := NewCountCmd()
+
+	if cmd.Use != "count INDEX" {
+		t.Errorf("Expected Use 'count INDEX', got %s", cmd.Use)
+	}
+	for _, name := range []string{"query", "from", "to", "time-field"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("Expected flag %s", name)
+		}
+	}
+}
+
+func TestNewTailCmd(t *testing.T) {
+	cmd := NewTailCmd()
+
+	if cmd.Use != "tail INDEX" {
+		t.Errorf("Expected Use 'tail INDEX', got %s", cmd.Use)
+	}
+	if f := cmd.Flags().ShorthandLookup("f"); f == nil || f.Name != "follow" {
+		t.Error("Expected -f to be --follow")
+	}
+	for _, name := range []string{"query", "lines", "interval", "template", "time-field", "tiebreaker"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("Expected flag %s", name)
+		}
+	}
+}
+
+func TestTailerCursor(t *testing.T) {
+	tl := &tailer{timeField: "@timestamp"}
+	hit := func(id string, ts string) types.SearchHit {
+		return types.SearchHit{Index: "logs", ID: id, Sort: []interface{}{json.Number(ts)}}
+	}
+
+	tl.advance(hit("1", "1000"))
+	tl.advance(hit("2", "1000"))
+	if len(tl.seen) != 2 {
+		t.Errorf("Expected both documents at the cursor timestamp to be seen, got %v", tl.seen)
+	}
+	after, err := tl.searchAfter()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(after, []interface{}{int64(999)}) {
+		t.Errorf("Expected search_after just before the cursor, got %v", after)
+	}
+
+	tl.advance(hit("3", "2000"))
+	if len(tl.seen) != 1 || !tl.seen["logs/3"] {
+		t.Errorf("Expected seen to reset on a newer timestamp, got %v", tl.seen)
+	}
+
+	tb := &tailer{timeField: "@timestamp", tiebreaker: "seq"}
+	tb.advance(types.SearchHit{Sort: []interface{}{json.Number("2000"), json.Number("7")}})
+	if after, _ := tb.searchAfter(); !reflect.DeepEqual(after, []interface{}{json.Number("2000"), json.Number("7")}) {
+		t.Errorf("Expected the exact cursor with a tiebreaker, got %v", after)
+	}
+}
+
+// tailClient serves tail searches sorted by timestamp only. Documents sharing a
+// timestamp come back in a different order on every request, as they may across shards.
+type tailClient struct {
+	client.SearchClient
+	docs     map[string]int64
+	requests int
+}
+
+func (c *tailClient) Search(index string, body map[string]interface{}, opts types.SearchOptions) (*types.SearchResponse, error) {
+	c.requests++
+	order := body["sort"].([]interface{})[0].(map[string]interface{})["@timestamp"].(map[string]interface{})["order"]
+	var hits []types.SearchHit
+	for id, ts := range c.docs {
+		if after, ok := body["search_after"].([]interface{}); ok && ts <= after[0].(int64) {
+			continue
+		}
+		hits = append(hits, types.SearchHit{Index: "logs", ID: id, Sort: []interface{}{json.Number(strconv.FormatInt(ts, 10))}})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		ti, tj := c.docs[hits[i].ID], c.docs[hits[j].ID]
+		if ti != tj {
+			return (ti < tj) == (order == "asc")
+		}
+		return (hits[i].ID < hits[j].ID) == (c.requests%2 == 0)
+	})
+	if size := body["size"].(int); len(hits) > size {
+		hits = hits[:size]
+	}
+	return &types.SearchResponse{Hits: types.SearchHits{Hits: hits}}, nil
+}
+
+func TestTailerPoll(t *testing.T) {
+	for _, lines := range []int{0, 3} {
+		c := &tailClient{docs: map[string]int64{"a": 1000, "b": 1000, "c": 1001, "d": 1001, "e": 1001}}
+		var out bytes.Buffer
+		tl := &tailer{
+			client:    c,
+			timeField: "@timestamp",
+			batchSize: 2,
+			render:    func(h types.SearchHit) (string, error) { return h.ID, nil },
+			out:       &out,
+		}
+		if err := tl.initial(lines); err != nil {
+			t.Fatalf("initial failed: %v", err)
+		}
+		printed := strings.Fields(out.String())
+		if len(printed) != lines {
+			t.Fatalf("Expected %d initial documents, got %v", lines, printed)
+		}
+
+		// New documents share the boundary timestamp and follow it
+		c.docs["f"] = 1001
+		c.docs["g"] = 1002
+		out.Reset()
+		for i := 0; i < 3; i++ {
+			if err := tl.poll(); err != nil {
+				t.Fatalf("poll failed: %v", err)
+			}
+		}
+		if polled := strings.Fields(out.String()); !reflect.DeepEqual(polled, []string{"f", "g"}) {
+			t.Errorf("lines=%d: expected only the new documents f and g, got %v", lines, polled)
+		}
+	}
+}
+
+func TestNewRenderer(t *testing.T) {
+	hit := types.SearchHit{Index: "logs", ID: "1", Source: json.RawMessage(`{"@timestamp": "2024-01-01T00:00:00Z", "message": "started", "host": {"name": "web-1"}}`)}
+
+	render, _ := newRenderer("", "@timestamp", false)
+	if line, _ := render(hit); line != "2024-01-01T00:00:00Z started" {
+		t.Errorf("Unexpected default line %q", line)
+	}
+
+	render, err := newRenderer(`{{._id}} {{get . "host.name"}}: {{.message}}`, "@timestamp", false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if line, _ := render(hit); line != "1 web-1: started" {
+		t.Errorf("Unexpected template line %q", line)
+	}
+
+	if _, err := newRenderer("{{.message", "@timestamp", false); err == nil {
+		t.Error("Expected error for an invalid template")
+	}
+}
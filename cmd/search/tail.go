@@ -0,0 +1,332 @@
+package search
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/query"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type tailOptions struct {
+	query      string
+	follow     bool
+	lines      int
+	interval   time.Duration
+	template   string
+	timeField  string
+	tiebreaker string
+	fields     []string
+	batchSize  int
+}
+
+func NewTailCmd() *cobra.Command {
+	var opts tailOptions
+
+	cmd := &cobra.Command{
+		Use:   "tail INDEX",
+		Short: "Print the newest documents of a data stream or index and follow new ones",
+		Long: `Print the last --lines documents of INDEX in time order and, with -f, keep
+polling for newer documents every --interval.
+
+Documents are ordered by --time-field. Polls continue after the newest printed
+document; documents sharing its timestamp are remembered so none is printed
+twice. With --tiebreaker, a field that orders documents with equal timestamps
+(such as a sequence number), the exact sort position is used instead. Each poll
+searches INDEX itself, so backing indices created by a rollover are picked up
+automatically. Documents that arrive with a timestamp older than the newest
+printed document are not shown.
+
+Lines show the timestamp followed by the message field, or the compacted
+_source when there is no message. --template formats each document with a Go
+template; the data is the _source plus _index and _id, and the functions
+get (dotted field lookup) and json are available. -o json prints one JSON object
+per document.`,
+		Example: strings.TrimSpace(`
+# Follow errors of a data stream
+searchctl tail logs-app-default -q 'level:ERROR' -f
+
+# Custom line format
+searchctl tail logs-app-default -f --template '{{get . "@timestamp"}} {{get . "host.name"}} {{.message}}'
+
+# Last 50 documents as JSON for jq
+searchctl tail logs-app-default -n 50 -o json | jq .`),
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			index := args[0]
+			if err := runTail(cmd, index, opts); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.query, "query", "q", "", "query string or JSON query DSL (default match_all)")
+	cmd.Flags().BoolVarP(&opts.follow, "follow", "f", false, "keep polling for new documents")
+	cmd.Flags().IntVarP(&opts.lines, "lines", "n", 10, "number of recent documents to print first")
+	cmd.Flags().DurationVar(&opts.interval, "interval", 2*time.Second, "polling interval with --follow")
+	cmd.Flags().StringVar(&opts.template, "template", "", "Go template used to format each document")
+	cmd.Flags().StringVar(&opts.timeField, "time-field", "@timestamp", "date field documents are ordered by")
+	cmd.Flags().StringVar(&opts.tiebreaker, "tiebreaker", "", "field ordering documents with equal timestamps")
+	cmd.Flags().StringSliceVar(&opts.fields, "fields", nil, "source fields to fetch (default all)")
+	cmd.Flags().IntVar(&opts.batchSize, "batch-size", 500, "maximum documents fetched per poll request")
+
+	return cmd
+}
+
+func runTail(cmd *cobra.Command, index string, opts tailOptions) error {
+	if opts.lines < 0 || opts.batchSize <= 0 || opts.interval <= 0 {
+		return fmt.Errorf("--lines, --batch-size and --interval must be positive")
+	}
+	q, err := query.Parse(opts.query)
+	if err != nil {
+		return err
+	}
+	render, err := newRenderer(opts.template, opts.timeField, viper.GetString("output") == "json")
+	if err != nil {
+		return err
+	}
+
+	c, err := client.NewClient()
+	if err != nil {
+		return fmt.Errorf("error creating client: %v", err)
+	}
+
+	t := &tailer{
+		client:     c,
+		index:      index,
+		query:      q,
+		timeField:  opts.timeField,
+		tiebreaker: opts.tiebreaker,
+		fields:     opts.fields,
+		batchSize:  opts.batchSize,
+		render:     render,
+		out:        cmd.OutOrStdout(),
+	}
+	if err := t.initial(opts.lines); err != nil {
+		return err
+	}
+	if !opts.follow {
+		return nil
+	}
+
+	ticker := time.NewTicker(opts.interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		// Keep following through transient failures such as a restarting node
+		if err := t.poll(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error polling %s: %v\n", index, err)
+		}
+	}
+	return nil
+}
+
+// tailer remembers the sort position of the newest printed document. Without a
+// tiebreaker it also remembers the documents printed at that timestamp, because
+// polls restart at the timestamp itself.
+type tailer struct {
+	client     client.SearchClient
+	index      string
+	query      map[string]interface{}
+	timeField  string
+	tiebreaker string
+	fields     []string
+	batchSize  int
+	render     func(types.SearchHit) (string, error)
+	out        io.Writer
+
+	cursor []interface{}
+	seen   map[string]bool
+}
+
+func (t *tailer) body(order string, size int) map[string]interface{} {
+	sort := []interface{}{map[string]interface{}{t.timeField: map[string]interface{}{"order": order, "unmapped_type": "date"}}}
+	if t.tiebreaker != "" {
+		sort = append(sort, map[string]interface{}{t.tiebreaker: map[string]interface{}{"order": order}})
+	}
+	body := map[string]interface{}{"query": t.query, "size": size, "sort": sort}
+	if len(t.fields) > 0 {
+		body["_source"] = t.fields
+	}
+	return body
+}
+
+// initial prints the newest n documents oldest first
+func (t *tailer) initial(n int) error {
+	size := n
+	if size == 0 {
+		// Nothing is printed, but the newest document still sets where following starts
+		size = 1
+	}
+	resp, err := t.client.Search(t.index, t.body("desc", size), types.SearchOptions{})
+	if err != nil {
+		return err
+	}
+	hits := resp.Hits.Hits
+	for i := len(hits) - 1; i >= 0; i-- {
+		if n > 0 {
+			if err := t.print(hits[i]); err != nil {
+				return err
+			}
+		}
+		t.advance(hits[i])
+	}
+	if n == 0 && t.tiebreaker == "" && t.cursor != nil {
+		// Polls restart at the newest timestamp, so every document sharing it must be seen
+		return t.read(false)
+	}
+	return nil
+}
+
+// poll prints every document after the cursor
+func (t *tailer) poll() error {
+	return t.read(true)
+}
+
+// read walks every document after the cursor, requesting more until a page comes back
+// short, and prints them unless show is false
+func (t *tailer) read(show bool) error {
+	for {
+		size := t.batchSize + len(t.seen)
+		body := t.body("asc", size)
+		if t.cursor != nil {
+			after, err := t.searchAfter()
+			if err != nil {
+				return err
+			}
+			body["search_after"] = after
+		}
+		resp, err := t.client.Search(t.index, body, types.SearchOptions{})
+		if err != nil {
+			return err
+		}
+
+		hits := resp.Hits.Hits
+		for _, h := range hits {
+			if t.tiebreaker == "" && t.seen[hitKey(h)] {
+				continue
+			}
+			if show {
+				if err := t.print(h); err != nil {
+					return err
+				}
+			}
+			t.advance(h)
+		}
+		if len(hits) < size {
+			return nil
+		}
+	}
+}
+
+// searchAfter returns the cursor, or without a tiebreaker the position just before the
+// cursor timestamp, so documents sharing it are returned again and filtered by seen
+func (t *tailer) searchAfter() ([]interface{}, error) {
+	if t.tiebreaker != "" {
+		return t.cursor, nil
+	}
+	n, ok := t.cursor[0].(json.Number)
+	if !ok {
+		return nil, fmt.Errorf("unexpected sort value %v for %s", t.cursor[0], t.timeField)
+	}
+	v, err := n.Int64()
+	if err != nil {
+		return nil, fmt.Errorf("sort value %v for %s is not a timestamp; is it a date field?", n, t.timeField)
+	}
+	return []interface{}{v - 1}, nil
+}
+
+func (t *tailer) advance(h types.SearchHit) {
+	if len(h.Sort) == 0 {
+		return
+	}
+	if t.tiebreaker != "" {
+		t.cursor = h.Sort
+		return
+	}
+	if t.cursor == nil || fmt.Sprint(t.cursor[0]) != fmt.Sprint(h.Sort[0]) {
+		t.cursor = h.Sort[:1]
+		t.seen = map[string]bool{}
+	}
+	t.seen[hitKey(h)] = true
+}
+
+func (t *tailer) print(h types.SearchHit) error {
+	line, err := t.render(h)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(t.out, line)
+	return err
+}
+
+func hitKey(h types.SearchHit) string {
+	return h.Index + "/" + h.ID
+}
+
+// newRenderer returns the function formatting one document as a line
+func newRenderer(tmpl, timeField string, asJSON bool) (func(types.SearchHit) (string, error), error) {
+	if asJSON {
+		return func(h types.SearchHit) (string, error) {
+			data, err := json.Marshal(map[string]interface{}{"_index": h.Index, "_id": h.ID, "_source": h.Source})
+			return string(data), err
+		}, nil
+	}
+
+	if tmpl == "" {
+		return func(h types.SearchHit) (string, error) {
+			doc := hitData(h)
+			message := formatValue(lookup(doc, "message"))
+			if message == "" {
+				var buf bytes.Buffer
+				if err := json.Compact(&buf, h.Source); err == nil {
+					message = buf.String()
+				}
+			}
+			return strings.TrimSpace(formatValue(lookup(doc, timeField)) + " " + message), nil
+		}, nil
+	}
+
+	parsed, err := template.New("tail").Funcs(template.FuncMap{
+		"get": func(doc map[string]interface{}, path string) string {
+			return formatValue(lookup(doc, path))
+		},
+		"json": func(v interface{}) (string, error) {
+			data, err := json.Marshal(v)
+			return string(data), err
+		},
+	}).Parse(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --template: %v", err)
+	}
+	return func(h types.SearchHit) (string, error) {
+		var buf bytes.Buffer
+		if err := parsed.Execute(&buf, hitData(h)); err != nil {
+			return "", err
+		}
+		return strings.TrimRight(buf.String(), "\n"), nil
+	}, nil
+}
+
+// hitData is the _source with the _index and _id metadata added
+func hitData(h types.SearchHit) map[string]interface{} {
+	doc := map[string]interface{}{}
+	dec := json.NewDecoder(bytes.NewReader(h.Source))
+	dec.UseNumber()
+	_ = dec.Decode(&doc)
+	if doc == nil {
+		doc = map[string]interface{}{}
+	}
+	doc["_index"] = h.Index
+	doc["_id"] = h.ID
+	return doc
+}
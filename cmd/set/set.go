@@ -0,0 +1,17 @@
+package set
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewSetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set",
+		Short: "Update settings of a resource",
+		Long:  "Update settings of an existing resource in the search cluster.",
+	}
+
+	cmd.AddCommand(NewSetSettingsCmd())
+
+	return cmd
+}
@@ -0,0 +1,49 @@
+package set
+
+import (
+	"testing"
+)
+
+func TestNewSetSettingsCmd(t *testing.T) {
+	cmd := NewSetSettingsCmd()
+
+	if cmd.Use != "settings INDEX_PATTERN KEY=VALUE [KEY=VALUE...]" {
+		t.Errorf("Unexpected Use: %s", cmd.Use)
+	}
+	if cmd.Flags().Lookup("force") == nil {
+		t.Error("Expected --force flag to be defined")
+	}
+}
+
+func TestParseSettingArgs(t *testing.T) {
+	settings, err := parseSettingArgs([]string{"index.number_of_replicas=2", "refresh_interval=null"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if settings["number_of_replicas"] != "2" {
+		t.Errorf("Expected number_of_replicas=2, got %v", settings["number_of_replicas"])
+	}
+	if v, ok := settings["refresh_interval"]; !ok || v != nil {
+		t.Errorf("Expected refresh_interval to reset to null, got %v", v)
+	}
+
+	if _, err := parseSettingArgs([]string{"novalue"}); err == nil {
+		t.Error("Expected error for argument without '='")
+	}
+}
+
+func TestClassifySettings(t *testing.T) {
+	static, immutable := classifySettings(map[string]interface{}{
+		"number_of_replicas":        "1",
+		"codec":                     "best_compression",
+		"analysis.analyzer.my.type": "custom",
+		"number_of_shards":          "3",
+	})
+
+	if len(static) != 2 || static[0] != "index.analysis.analyzer.my.type" || static[1] != "index.codec" {
+		t.Errorf("Unexpected static settings: %v", static)
+	}
+	if len(immutable) != 1 || immutable[0] != "index.number_of_shards" {
+		t.Errorf("Unexpected immutable settings: %v", immutable)
+	}
+}
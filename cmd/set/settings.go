@@ -0,0 +1,155 @@
+package set
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// immutableSettings can only be set at index creation time
+var immutableSettings = map[string]bool{
+	"index.number_of_shards":         true,
+	"index.number_of_routing_shards": true,
+	"index.routing_partition_size":   true,
+	"index.soft_deletes.enabled":     true,
+	"index.mode":                     true,
+}
+
+// staticSettingPrefixes are settings that may only be changed while the index is closed
+var staticSettingPrefixes = []string{
+	"index.codec",
+	"index.shard.check_on_startup",
+	"index.load_fixed_bitset_filters_eagerly",
+	"index.store.type",
+	"index.sort.",
+	"index.analysis.",
+	"index.similarity.",
+	"index.mapping.source.mode",
+}
+
+func NewSetSettingsCmd() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:     "settings INDEX_PATTERN KEY=VALUE [KEY=VALUE...]",
+		Short:   "Update index settings",
+		Long:    "Update dynamic settings of an index or indices matching a pattern. Keys may omit the 'index.' prefix; use 'null' as the value to reset a setting to its default.",
+		Aliases: []string{"setting", "index-settings"},
+		Args:    cobra.MinimumNArgs(2),
+		Example: strings.TrimSpace(`
+  # Drop replicas and slow down refresh during a bulk load
+  searchctl set settings logs-v1 number_of_replicas=0 refresh_interval=30s
+
+  # Reset a setting to its default
+  searchctl set settings 'logs-*' index.refresh_interval=null
+
+  # Static settings need the index closed first
+  searchctl set settings logs-v1 index.codec=best_compression
+        `),
+		Run: func(cmd *cobra.Command, args []string) {
+			indexPattern := args[0]
+
+			settings, err := parseSettingArgs(args[1:])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			static, immutable := classifySettings(settings)
+			if len(immutable) > 0 {
+				fmt.Fprintf(os.Stderr, "Error: settings %s can only be set when the index is created\n", strings.Join(immutable, ", "))
+				os.Exit(1)
+			}
+
+			if viper.GetBool("dry-run") {
+				settingsJSON, _ := json.MarshalIndent(settings, "", "  ")
+				cmd.Printf("Would update settings on: %s\n%s\n", indexPattern, string(settingsJSON))
+				if len(static) > 0 {
+					cmd.Printf("Note: %s are static settings and require the index to be closed\n", strings.Join(static, ", "))
+				}
+				return
+			}
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			if len(static) > 0 && !force {
+				indices, err := c.GetIndices(indexPattern)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error getting indices: %v\n", err)
+					os.Exit(1)
+				}
+				var open []string
+				for _, idx := range indices {
+					if idx.Status == "open" {
+						open = append(open, idx.Name)
+					}
+				}
+				if len(open) > 0 {
+					fmt.Fprintf(os.Stderr, "Error: %s are static settings and the following indices are open: %s\n", strings.Join(static, ", "), strings.Join(open, ", "))
+					fmt.Fprintln(os.Stderr, "Close the indices first, or pass --force to send the request anyway")
+					os.Exit(1)
+				}
+			}
+
+			if err := c.UpdateIndexSettings(indexPattern, map[string]interface{}{"index": settings}); err != nil {
+				fmt.Fprintf(os.Stderr, "Error updating index settings: %v\n", err)
+				os.Exit(1)
+			}
+
+			cmd.Printf("Settings updated on %s\n", indexPattern)
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "skip the closed-index check for static settings")
+
+	return cmd
+}
+
+// parseSettingArgs turns key=value pairs into a settings map keyed without the "index." prefix
+func parseSettingArgs(pairs []string) (map[string]interface{}, error) {
+	settings := make(map[string]interface{}, len(pairs))
+	for _, pair := range pairs {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return nil, fmt.Errorf("invalid setting %q, expected KEY=VALUE", pair)
+		}
+		key := strings.TrimPrefix(strings.TrimSpace(kv[0]), "index.")
+		value := strings.TrimSpace(kv[1])
+		if value == "null" {
+			settings[key] = nil
+		} else {
+			settings[key] = value
+		}
+	}
+	return settings, nil
+}
+
+// classifySettings returns the fully-qualified keys that are static or immutable
+func classifySettings(settings map[string]interface{}) (static []string, immutable []string) {
+	for key := range settings {
+		full := "index." + key
+		if immutableSettings[full] {
+			immutable = append(immutable, full)
+			continue
+		}
+		for _, prefix := range staticSettingPrefixes {
+			if full == prefix || (strings.HasSuffix(prefix, ".") && strings.HasPrefix(full, prefix)) {
+				static = append(static, full)
+				break
+			}
+		}
+	}
+	sort.Strings(static)
+	sort.Strings(immutable)
+	return static, immutable
+}
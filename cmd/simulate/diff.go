@@ -0,0 +1,226 @@
+package simulate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/chronicblondiee/searchctl/pkg/types"
+)
+
+// change is a field that was added (+), removed (-) or modified (~) by a processor
+type change struct {
+	op     string
+	field  string
+	before string
+	after  string
+}
+
+func (c change) String() string {
+	switch c.op {
+	case "+":
+		return fmt.Sprintf("+ %s: %s", c.field, c.after)
+	case "-":
+		return fmt.Sprintf("- %s: %s", c.field, c.before)
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", c.field, c.before, c.after)
+	}
+}
+
+// printResults shows the changes to every document, per processor when the
+// response is verbose
+func printResults(w io.Writer, docs []interface{}, result *types.SimulateResponse) {
+	for i, r := range result.Docs {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		var input map[string]interface{}
+		if i < len(docs) {
+			input, _ = docs[i].(map[string]interface{})
+		}
+		before := flattenInput(input)
+		fmt.Fprintf(w, "Document %d%s:\n", i+1, docLabel(input))
+
+		if r.ProcessorResults == nil {
+			if r.Error != nil {
+				fmt.Fprintf(w, "  error: %s\n", reason(r.Error))
+				continue
+			}
+			printChanges(w, "  ", diff(before, flattenDoc(r.Doc)))
+			continue
+		}
+
+		for j, p := range r.ProcessorResults {
+			status := p.Status
+			if status == "" {
+				status = "success"
+				if p.Error != nil {
+					status = "error"
+				}
+			}
+			line := fmt.Sprintf("  [%d] %s: %s", j+1, processorName(p), status)
+			if p.Error != nil {
+				line += ": " + reason(p.Error)
+			} else if p.IgnoredError != nil {
+				line += ": " + reason(p.IgnoredError)
+			}
+			fmt.Fprintln(w, line)
+
+			if p.Doc == nil {
+				continue
+			}
+			after := flattenDoc(p.Doc)
+			printChanges(w, "      ", diff(before, after))
+			before = after
+		}
+	}
+}
+
+func printChanges(w io.Writer, indent string, changes []change) {
+	if len(changes) == 0 {
+		fmt.Fprintf(w, "%s(no changes)\n", indent)
+		return
+	}
+	for _, c := range changes {
+		fmt.Fprintf(w, "%s%s\n", indent, c)
+	}
+}
+
+// failedDocs counts the documents that failed, ignoring failures of processors with
+// ignore_failure set
+func failedDocs(result *types.SimulateResponse) int {
+	n := 0
+	for _, r := range result.Docs {
+		failed := r.Error != nil
+		for _, p := range r.ProcessorResults {
+			if p.Status == "error" || (p.Status == "" && p.Error != nil) {
+				failed = true
+			}
+		}
+		if failed {
+			n++
+		}
+	}
+	return n
+}
+
+func processorName(p types.SimulateProcessorResult) string {
+	name := p.ProcessorType
+	if name == "" {
+		name = "processor"
+	}
+	if p.Tag != "" {
+		name += " (" + p.Tag + ")"
+	}
+	return name
+}
+
+func docLabel(doc map[string]interface{}) string {
+	index, _ := doc["_index"].(string)
+	id, _ := doc["_id"].(string)
+	switch {
+	case index != "" && id != "":
+		return " (" + index + "/" + id + ")"
+	case id != "":
+		return " (" + id + ")"
+	default:
+		return ""
+	}
+}
+
+// reason renders an error object as "type: reason", using the root cause when the
+// error only wraps it
+func reason(err map[string]interface{}) string {
+	if causes, ok := err["root_cause"].([]interface{}); ok && len(causes) > 0 {
+		if cause, ok := causes[0].(map[string]interface{}); ok {
+			err = cause
+		}
+	}
+	typ, _ := err["type"].(string)
+	msg, _ := err["reason"].(string)
+	if typ == "" {
+		return msg
+	}
+	if msg == "" {
+		return typ
+	}
+	return typ + ": " + msg
+}
+
+// flattenInput flattens a document as sent to the simulate API. The API fills in
+// _index and _id when they are missing, so the same placeholders are used here.
+func flattenInput(doc map[string]interface{}) map[string]string {
+	source, _ := doc["_source"].(map[string]interface{})
+	fields := map[string]string{}
+	flatten("", source, fields)
+	fields["_index"] = metaValue(doc["_index"], "_index")
+	fields["_id"] = metaValue(doc["_id"], "_id")
+	return fields
+}
+
+func flattenDoc(doc *types.SimulateDocument) map[string]string {
+	fields := map[string]string{}
+	if doc == nil {
+		return fields
+	}
+	flatten("", doc.Source, fields)
+	fields["_index"] = metaValue(doc.Index, "_index")
+	fields["_id"] = metaValue(doc.ID, "_id")
+	return fields
+}
+
+func metaValue(v interface{}, placeholder string) string {
+	if s, ok := v.(string); ok && s != "" {
+		return render(s)
+	}
+	return render(placeholder)
+}
+
+// flatten stores every leaf of an object under its dotted path. Arrays are leaves.
+func flatten(prefix string, obj map[string]interface{}, fields map[string]string) {
+	if len(obj) == 0 && prefix != "" {
+		fields[prefix] = "{}"
+		return
+	}
+	for k, v := range obj {
+		path := k
+		if prefix != "" {
+			path = prefix + "." + k
+		}
+		if m, ok := v.(map[string]interface{}); ok {
+			flatten(path, m, fields)
+			continue
+		}
+		fields[path] = render(v)
+	}
+}
+
+func render(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// diff returns the changes from before to after sorted by field
+func diff(before, after map[string]string) []change {
+	var changes []change
+	for field, a := range after {
+		b, ok := before[field]
+		switch {
+		case !ok:
+			changes = append(changes, change{op: "+", field: field, after: a})
+		case b != a:
+			changes = append(changes, change{op: "~", field: field, before: b, after: a})
+		}
+	}
+	for field, b := range before {
+		if _, ok := after[field]; !ok {
+			changes = append(changes, change{op: "-", field: field, before: b})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].field < changes[j].field })
+	return changes
+}
@@ -0,0 +1,228 @@
+package simulate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+func NewSimulateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "simulate",
+		Short: "Try resources on sample data without changing the cluster",
+		Long:  "Run sample data through a resource, such as an ingest pipeline, and show the result without storing anything.",
+	}
+
+	cmd.AddCommand(NewSimulateIngestPipelineCmd())
+
+	return cmd
+}
+
+type options struct {
+	filename string
+	pipeline string
+	verbose  bool
+}
+
+func NewSimulateIngestPipelineCmd() *cobra.Command {
+	var opts options
+
+	cmd := &cobra.Command{
+		Use:   "ingest-pipeline [NAME] -f DOCS",
+		Short: "Run sample documents through an ingest pipeline",
+		Long: `Run the documents in DOCS through the ingest pipeline NAME with the simulate
+API and show how each document changed.
+
+DOCS is a JSON array, NDJSON, YAML, or a simulate request body with a docs list.
+Each document is either a bare source or an object with _source and optionally
+_index and _id. With --pipeline, the pipeline definition is read from a file
+instead of the cluster, so it can be tested before it is created.
+
+The output lists the fields each document gained (+), lost (-) or changed (~).
+With --verbose the changes are shown after every processor, along with its
+status: skipped by an if condition, failed, or failed and ignored. The command
+exits with status 1 when any document fails. -o json and -o yaml print the
+simulate response.`,
+		Aliases: []string{"ingestpipeline", "pipeline", "ip"},
+		Example: strings.TrimSpace(`
+# Try a stored pipeline on sample log lines, processor by processor
+searchctl simulate ingest-pipeline access-logs -f samples.json --verbose
+
+# Test a pipeline file before creating it
+searchctl simulate ingest-pipeline --pipeline access-logs.yaml -f samples.ndjson`),
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			name := ""
+			if len(args) > 0 {
+				name = args[0]
+			}
+			failed, err := run(cmd, name, opts)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if failed {
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.filename, "filename", "f", "", "file with the sample documents (JSON, NDJSON or YAML)")
+	cmd.Flags().StringVar(&opts.pipeline, "pipeline", "", "pipeline definition file to simulate instead of a stored pipeline")
+	cmd.Flags().BoolVar(&opts.verbose, "verbose", false, "show the changes made by every processor")
+	cmd.MarkFlagRequired("filename")
+
+	return cmd
+}
+
+// run simulates the pipeline and prints the result. It reports whether any document failed.
+func run(cmd *cobra.Command, name string, opts options) (bool, error) {
+	if (name == "") == (opts.pipeline == "") {
+		return false, fmt.Errorf("give either a pipeline NAME or --pipeline")
+	}
+
+	data, err := os.ReadFile(opts.filename)
+	if err != nil {
+		return false, fmt.Errorf("error reading documents: %v", err)
+	}
+	docs, err := parseDocs(data)
+	if err != nil {
+		return false, fmt.Errorf("error reading documents: %v", err)
+	}
+
+	var pipeline map[string]interface{}
+	if opts.pipeline != "" {
+		if pipeline, err = readPipeline(opts.pipeline); err != nil {
+			return false, fmt.Errorf("error reading pipeline: %v", err)
+		}
+	}
+
+	c, err := client.NewClient()
+	if err != nil {
+		return false, fmt.Errorf("error creating client: %v", err)
+	}
+
+	result, err := c.SimulateIngestPipeline(name, pipeline, docs, opts.verbose)
+	if err != nil {
+		return false, err
+	}
+
+	outFmt := viper.GetString("output")
+	if outFmt == "json" || outFmt == "yaml" {
+		if err := output.NewFormatter(outFmt).Format(result, cmd.OutOrStdout()); err != nil {
+			return false, err
+		}
+		return failedDocs(result) > 0, nil
+	}
+
+	printResults(cmd.OutOrStdout(), docs, result)
+	n := failedDocs(result)
+	if n > 0 {
+		cmd.Printf("%d of %d documents failed\n", n, len(result.Docs))
+	}
+	return n > 0, nil
+}
+
+// parseDocs reads sample documents and wraps bare sources in _source, as the
+// simulate API expects
+func parseDocs(data []byte) ([]interface{}, error) {
+	values, err := decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw []interface{}
+	if len(values) == 1 {
+		switch v := values[0].(type) {
+		case []interface{}:
+			raw = v
+		case map[string]interface{}:
+			if list, ok := v["docs"].([]interface{}); ok {
+				raw = list
+			} else {
+				raw = values
+			}
+		}
+	} else {
+		raw = values
+	}
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("no documents found")
+	}
+
+	docs := make([]interface{}, len(raw))
+	for i, item := range raw {
+		doc, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("document %d is not an object", i+1)
+		}
+		if _, ok := doc["_source"]; !ok {
+			doc = map[string]interface{}{"_source": doc}
+		}
+		docs[i] = doc
+	}
+	return docs, nil
+}
+
+// decode returns every JSON value in data, which covers a single document, an
+// array and NDJSON, or the YAML document when data is not JSON
+func decode(data []byte) ([]interface{}, error) {
+	trimmed := bytes.TrimSpace(data)
+	if bytes.HasPrefix(trimmed, []byte("{")) || bytes.HasPrefix(trimmed, []byte("[")) {
+		var values []interface{}
+		dec := json.NewDecoder(bytes.NewReader(trimmed))
+		dec.UseNumber()
+		for {
+			var v interface{}
+			err := dec.Decode(&v)
+			if err == io.EOF {
+				return values, nil
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse JSON: %w", err)
+			}
+			values = append(values, v)
+		}
+	}
+
+	var v interface{}
+	if err := yaml.Unmarshal(trimmed, &v); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	if v == nil {
+		return nil, nil
+	}
+	return []interface{}{v}, nil
+}
+
+// readPipeline reads a pipeline body, unwrapping an exported IngestPipeline resource
+func readPipeline(filename string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	values, err := decode(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) != 1 {
+		return nil, fmt.Errorf("expected a single pipeline definition")
+	}
+	body, ok := values[0].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("pipeline definition is not an object")
+	}
+	if spec, ok := body["spec"].(map[string]interface{}); ok && body["kind"] != nil {
+		body = spec
+	}
+	return body, nil
+}
@@ -0,0 +1,120 @@
+package simulate
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/chronicblondiee/searchctl/pkg/types"
+)
+
+func TestNewSimulateIngestPipelineCmd(t *testing.T) {
+	cmd := NewSimulateCmd()
+	sub, _, err := cmd.Find([]string{"ingest-pipeline"})
+	if err != nil || sub.Name() != "ingest-pipeline" {
+		t.Fatalf("Expected ingest-pipeline subcommand, got %v", err)
+	}
+	for _, name := range []string{"filename", "pipeline", "verbose"} {
+		if sub.Flags().Lookup(name) == nil {
+			t.Errorf("Expected flag %s", name)
+		}
+	}
+}
+
+func TestParseDocs(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		ids  []interface{}
+	}{
+		{"array", `[{"message":"a"},{"_id":"2","_source":{"message":"b"}}]`, []interface{}{nil, "2"}},
+		{"simulate body", `{"docs":[{"_id":"1","_source":{"message":"a"}}]}`, []interface{}{"1"}},
+		{"single", `{"message":"a"}`, []interface{}{nil}},
+		{"ndjson", "{\"message\":\"a\"}\n{\"message\":\"b\"}\n", []interface{}{nil, nil}},
+		{"yaml", "- message: a\n- _id: \"2\"\n  _source:\n    message: b\n", []interface{}{nil, "2"}},
+	}
+
+	for _, tt := range tests {
+		docs, err := parseDocs([]byte(tt.data))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		var ids []interface{}
+		for _, d := range docs {
+			doc := d.(map[string]interface{})
+			if _, ok := doc["_source"].(map[string]interface{}); !ok {
+				t.Errorf("%s: expected _source object in %v", tt.name, doc)
+			}
+			ids = append(ids, doc["_id"])
+		}
+		if !reflect.DeepEqual(ids, tt.ids) {
+			t.Errorf("%s: expected ids %v, got %v", tt.name, tt.ids, ids)
+		}
+	}
+
+	if _, err := parseDocs([]byte(`[1, 2]`)); err == nil {
+		t.Error("Expected error for documents that are not objects")
+	}
+	if _, err := parseDocs([]byte(`[]`)); err == nil {
+		t.Error("Expected error for no documents")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	before := map[string]string{"message": `"GET /"`, "status": `"200"`, "_id": `"_id"`}
+	after := map[string]string{"status": `200`, "http.method": `"GET"`, "_id": `"_id"`}
+
+	var got []string
+	for _, c := range diff(before, after) {
+		got = append(got, c.String())
+	}
+	expected := []string{`+ http.method: "GET"`, `- message: "GET /"`, `~ status: "200" -> 200`}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestPrintResultsVerbose(t *testing.T) {
+	docs, _ := parseDocs([]byte(`[{"_id":"1","_source":{"message":"10.0.0.1 GET"}}]`))
+	var result types.SimulateResponse
+	err := json.Unmarshal([]byte(`{"docs":[{"processor_results":[
+		{"processor_type":"grok","status":"success","doc":{"_index":"_index","_id":"1","_source":{"message":"10.0.0.1 GET","client":{"ip":"10.0.0.1"},"verb":"GET"}}},
+		{"processor_type":"set","tag":"env","status":"skipped"},
+		{"processor_type":"remove","status":"success","doc":{"_index":"_index","_id":"1","_source":{"client":{"ip":"10.0.0.1"},"verb":"GET"}}},
+		{"processor_type":"date","status":"error","error":{"root_cause":[{"type":"illegal_argument_exception","reason":"field [ts] not present"}],"type":"illegal_argument_exception","reason":"wrapped"}}
+	]}]}`), &result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	printResults(&buf, docs, &result)
+	expected := strings.TrimSpace(`
+Document 1 (1):
+  [1] grok: success
+      + client.ip: "10.0.0.1"
+      + verb: "GET"
+  [2] set (env): skipped
+  [3] remove: success
+      - message: "10.0.0.1 GET"
+  [4] date: error: illegal_argument_exception: field [ts] not present`)
+	if strings.TrimSpace(buf.String()) != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+	if failedDocs(&result) != 1 {
+		t.Errorf("Expected 1 failed document")
+	}
+}
+
+func TestFailedDocsIgnoresIgnoredErrors(t *testing.T) {
+	result := &types.SimulateResponse{Docs: []types.SimulateResult{
+		{ProcessorResults: []types.SimulateProcessorResult{{ProcessorType: "convert", Status: "error_ignored", IgnoredError: map[string]interface{}{"type": "x"}}}},
+		{Doc: &types.SimulateDocument{}},
+	}}
+	if n := failedDocs(result); n != 0 {
+		t.Errorf("Expected no failed documents, got %d", n)
+	}
+}
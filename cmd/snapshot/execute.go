@@ -0,0 +1,47 @@
+package snapshot
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewExecuteCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "execute POLICY",
+		Short: "Run a snapshot policy immediately",
+		Long: `Take a snapshot now using an SLM policy, outside of its schedule.
+
+Only Elasticsearch SLM supports this; on OpenSearch use 'searchctl create snapshot'.
+Follow the new snapshot with 'searchctl snapshot status'.`,
+		Aliases: []string{"exec", "run"},
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			policy := args[0]
+
+			if viper.GetBool("dry-run") {
+				cmd.Printf("Would execute snapshot policy: %s\n", policy)
+				return
+			}
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			name, err := c.ExecuteSnapshotPolicy(policy)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error executing snapshot policy: %v\n", err)
+				os.Exit(1)
+			}
+
+			cmd.Printf("Snapshot policy %s executed, started snapshot %s\n", policy, name)
+		},
+	}
+
+	return cmd
+}
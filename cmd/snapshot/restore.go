@@ -0,0 +1,435 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/output"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type restoreOptions struct {
+	indices             string
+	renamePattern       string
+	renameReplacement   string
+	indexSettings       []string
+	ignoreIndexSettings string
+	includeAliases      bool
+	includeGlobalState  bool
+	partial             bool
+	detach              bool
+	timeout             time.Duration
+	interval            time.Duration
+}
+
+// restoreTarget maps one index in the snapshot to the index it is restored as
+type restoreTarget struct {
+	source   string
+	target   string
+	conflict string
+	note     string
+}
+
+func NewRestoreCmd() *cobra.Command {
+	var opts restoreOptions
+
+	cmd := &cobra.Command{
+		Use:   "restore REPOSITORY SNAPSHOT",
+		Short: "Restore indices from a snapshot",
+		Long: `Restore indices and data streams from a snapshot, then follow recovery until
+every restored primary shard is started.
+
+Before restoring, the selected indices are resolved against the snapshot and the
+rename rules, and checked against the cluster. Restoring onto an existing open
+index is refused; existing closed indices are overwritten. --dry-run prints the
+resolved source to target list without restoring anything.
+
+The snapshot can also be given as REPOSITORY/SNAPSHOT. --rename-pattern is a
+regular expression and --rename-replacement may reference its groups as $1.`,
+		Example: strings.TrimSpace(`
+# Restore two indices next to the live ones
+searchctl restore backups nightly-1 --indices 'logs-2024.01.*' \
+  --rename-pattern '(.+)' --rename-replacement 'restored-$1'
+
+# Restore without replicas and without the snapshot's aliases
+searchctl restore backups/nightly-1 --indices orders \
+  --index-settings index.number_of_replicas=0 --include-aliases=false
+
+# Preview the resolved index list
+searchctl restore backups nightly-1 --indices 'logs-*' --dry-run`),
+		Args: cobra.RangeArgs(1, 2),
+		Run: func(cmd *cobra.Command, args []string) {
+			repository, name, err := parseSnapshotRef(args)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			var rename *regexp.Regexp
+			if opts.renamePattern != "" {
+				if rename, err = regexp.Compile(opts.renamePattern); err != nil {
+					fmt.Fprintf(os.Stderr, "Error: invalid --rename-pattern: %v\n", err)
+					os.Exit(1)
+				}
+			} else if opts.renameReplacement != "" {
+				fmt.Fprintln(os.Stderr, "Error: --rename-replacement requires --rename-pattern")
+				os.Exit(1)
+			}
+
+			body, err := buildRestoreBody(opts)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error building restore request: %v\n", err)
+				os.Exit(1)
+			}
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			snap, err := c.GetSnapshot(repository, name)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting snapshot: %v\n", err)
+				os.Exit(1)
+			}
+			selected := selectIndices(snap, opts.indices)
+			if len(selected) == 0 {
+				fmt.Fprintf(os.Stderr, "Error: no indices in snapshot %s match %q\n", name, opts.indices)
+				os.Exit(1)
+			}
+
+			existing, err := c.GetIndices("")
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting indices: %v\n", err)
+				os.Exit(1)
+			}
+			status := make(map[string]string, len(existing))
+			for _, idx := range existing {
+				status[idx.Name] = idx.Status
+			}
+			plan := planRestore(selected, rename, opts.renameReplacement, status)
+
+			conflicts := 0
+			for _, t := range plan {
+				if t.conflict != "" {
+					conflicts++
+				}
+			}
+
+			if viper.GetBool("dry-run") {
+				cmd.Printf("Would restore %d indices from snapshot %s/%s\n", len(plan), repository, name)
+				printPlan(cmd, plan)
+				bodyJSON, _ := json.MarshalIndent(body, "", "  ")
+				cmd.Printf("Request body:\n%s\n", string(bodyJSON))
+				if conflicts > 0 {
+					cmd.Printf("Warning: %d target indices conflict; the restore would fail\n", conflicts)
+				}
+				return
+			}
+
+			if conflicts > 0 {
+				printPlan(cmd, plan)
+				fmt.Fprintf(os.Stderr, "Error: %d target indices conflict with the cluster; close or delete them, or use --rename-pattern\n", conflicts)
+				os.Exit(1)
+			}
+
+			if err := c.RestoreSnapshot(repository, name, body); err != nil {
+				fmt.Fprintf(os.Stderr, "Error restoring snapshot: %v\n", err)
+				os.Exit(1)
+			}
+
+			targets := make([]string, len(plan))
+			for i, t := range plan {
+				targets[i] = t.target
+			}
+			cmd.Printf("Restore of %d indices from %s/%s started\n", len(targets), repository, name)
+
+			if opts.detach {
+				cmd.Printf("Check progress with: searchctl get shards '%s'\n", strings.Join(targets, ","))
+				return
+			}
+
+			followRecovery(cmd, c, targets, opts.timeout, opts.interval)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.indices, "indices", "", "comma-separated indices, data streams or patterns to restore (default: all in the snapshot)")
+	cmd.Flags().StringVar(&opts.renamePattern, "rename-pattern", "", "regular expression matched against restored index names")
+	cmd.Flags().StringVar(&opts.renameReplacement, "rename-replacement", "", "replacement for --rename-pattern matches (supports $1 group references)")
+	cmd.Flags().StringArrayVar(&opts.indexSettings, "index-settings", nil, "index setting override as KEY=VALUE (repeatable)")
+	cmd.Flags().StringVar(&opts.ignoreIndexSettings, "ignore-index-settings", "", "comma-separated index settings not to restore from the snapshot")
+	cmd.Flags().BoolVar(&opts.includeAliases, "include-aliases", true, "restore the aliases stored with the indices")
+	cmd.Flags().BoolVar(&opts.includeGlobalState, "include-global-state", false, "restore cluster state such as templates and persistent settings")
+	cmd.Flags().BoolVar(&opts.partial, "partial", false, "restore indices with missing shards from a partial snapshot")
+	cmd.Flags().BoolVar(&opts.detach, "detach", false, "start the restore and return without following recovery")
+	cmd.Flags().DurationVar(&opts.timeout, "timeout", time.Hour, "maximum time to wait for restored primaries to start")
+	cmd.Flags().DurationVar(&opts.interval, "interval", 5*time.Second, "polling interval while following recovery")
+
+	return cmd
+}
+
+// parseSnapshotRef accepts "REPOSITORY SNAPSHOT" or "REPOSITORY/SNAPSHOT"
+func parseSnapshotRef(args []string) (string, string, error) {
+	if len(args) == 2 {
+		return args[0], args[1], nil
+	}
+	parts := strings.SplitN(args[0], "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("expected REPOSITORY SNAPSHOT or REPOSITORY/SNAPSHOT, got %q", args[0])
+	}
+	return parts[0], parts[1], nil
+}
+
+func buildRestoreBody(opts restoreOptions) (map[string]interface{}, error) {
+	body := map[string]interface{}{
+		"include_aliases":      opts.includeAliases,
+		"include_global_state": opts.includeGlobalState,
+	}
+	if opts.indices != "" {
+		body["indices"] = opts.indices
+	}
+	if opts.renamePattern != "" {
+		body["rename_pattern"] = opts.renamePattern
+		body["rename_replacement"] = opts.renameReplacement
+	}
+	if len(opts.indexSettings) > 0 {
+		settings := map[string]interface{}{}
+		for _, kv := range opts.indexSettings {
+			parts := strings.SplitN(kv, "=", 2)
+			if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+				return nil, fmt.Errorf("invalid --index-settings %q: expected KEY=VALUE", kv)
+			}
+			settings[strings.TrimSpace(parts[0])] = parts[1]
+		}
+		body["index_settings"] = settings
+	}
+	if opts.ignoreIndexSettings != "" {
+		body["ignore_index_settings"] = strings.Split(opts.ignoreIndexSettings, ",")
+	}
+	if opts.partial {
+		body["partial"] = true
+	}
+	return body, nil
+}
+
+// selectIndices resolves the --indices expression against the snapshot contents the way the
+// restore API will: wildcards, "-" exclusions, and data stream names expanding to backing indices.
+func selectIndices(snap *types.Snapshot, expr string) []string {
+	if strings.TrimSpace(expr) == "" {
+		out := append([]string(nil), snap.Indices...)
+		sort.Strings(out)
+		return out
+	}
+
+	selected := map[string]bool{}
+	for _, raw := range strings.Split(expr, ",") {
+		p := strings.TrimSpace(raw)
+		if p == "" {
+			continue
+		}
+		exclude := strings.HasPrefix(p, "-")
+		p = strings.TrimPrefix(p, "-")
+
+		for _, idx := range snap.Indices {
+			if matchName(p, idx) {
+				selected[idx] = !exclude
+			}
+		}
+		for _, ds := range snap.DataStreams {
+			if !matchName(p, ds) {
+				continue
+			}
+			for _, idx := range snap.Indices {
+				if strings.HasPrefix(idx, ".ds-"+ds+"-") {
+					selected[idx] = !exclude
+				}
+			}
+		}
+	}
+
+	out := make([]string, 0, len(selected))
+	for idx, keep := range selected {
+		if keep {
+			out = append(out, idx)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func matchName(pattern, name string) bool {
+	ok, err := path.Match(pattern, name)
+	return err == nil && ok
+}
+
+// planRestore applies the rename rule and flags targets that collide with open indices or each other
+func planRestore(sources []string, rename *regexp.Regexp, replacement string, existing map[string]string) []restoreTarget {
+	plan := make([]restoreTarget, 0, len(sources))
+	seen := map[string]string{}
+	if rename != nil {
+		replacement = javaReplacement(rename, replacement)
+	}
+	for _, src := range sources {
+		t := restoreTarget{source: src, target: src, note: "new"}
+		if rename != nil {
+			t.target = rename.ReplaceAllString(src, replacement)
+		}
+		if other, dup := seen[t.target]; dup {
+			t.conflict = "also restored from " + other
+		} else if state, ok := existing[t.target]; ok {
+			if state == "close" {
+				t.note = "overwrites closed index"
+			} else {
+				t.conflict = "open index exists"
+			}
+		}
+		seen[t.target] = src
+		plan = append(plan, t)
+	}
+	return plan
+}
+
+// javaReplacement converts a rename replacement from the Java syntax used by the server
+// to Go's: $N becomes ${N}, taking as many digits as still name a group, and a
+// backslash makes the next character literal. Go would read $1_restored as a group
+// named "1_restored".
+func javaReplacement(re *regexp.Regexp, replacement string) string {
+	var b strings.Builder
+	for i := 0; i < len(replacement); i++ {
+		ch := replacement[i]
+		switch {
+		case ch == '\\' && i+1 < len(replacement):
+			i++
+			if replacement[i] == '$' {
+				b.WriteString("$$")
+			} else {
+				b.WriteByte(replacement[i])
+			}
+		case ch == '$' && i+1 < len(replacement) && isDigit(replacement[i+1]):
+			group := int(replacement[i+1] - '0')
+			i++
+			for i+1 < len(replacement) && isDigit(replacement[i+1]) {
+				next := group*10 + int(replacement[i+1]-'0')
+				if next > re.NumSubexp() {
+					break
+				}
+				group = next
+				i++
+			}
+			b.WriteString("${" + strconv.Itoa(group) + "}")
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	return b.String()
+}
+
+func isDigit(ch byte) bool {
+	return ch >= '0' && ch <= '9'
+}
+
+func printPlan(cmd *cobra.Command, plan []restoreTarget) {
+	data := make([]interface{}, len(plan))
+	for i, t := range plan {
+		status := t.note
+		if t.conflict != "" {
+			status = "CONFLICT: " + t.conflict
+		}
+		data[i] = map[string]interface{}{
+			"__columns": "SOURCE,TARGET,STATUS",
+			"SOURCE":    t.source,
+			"TARGET":    t.target,
+			"STATUS":    status,
+		}
+	}
+	if err := output.NewFormatter("table").Format(data, cmd.OutOrStdout()); err != nil {
+		fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+type restoreStatus struct {
+	started    int
+	total      int
+	bytesDone  int64
+	bytesTotal int64
+}
+
+// restoreProgress counts started primaries of the targets and the bytes copied from the snapshot
+func restoreProgress(targets map[string]bool, shards []types.CatShardRow, recoveries []types.CatRecoveryRow) restoreStatus {
+	var st restoreStatus
+	for _, s := range shards {
+		if !targets[s.Index] || s.PrimaryOrReplica != "p" {
+			continue
+		}
+		st.total++
+		if s.State == "STARTED" {
+			st.started++
+		}
+	}
+	for _, r := range recoveries {
+		if !targets[r.Index] || !strings.EqualFold(r.Type, "snapshot") {
+			continue
+		}
+		total, _ := strconv.ParseInt(r.BytesTotal, 10, 64)
+		pct, _ := strconv.ParseFloat(strings.TrimSuffix(r.BytesPercent, "%"), 64)
+		st.bytesTotal += total
+		st.bytesDone += int64(float64(total) * pct / 100)
+	}
+	return st
+}
+
+func followRecovery(cmd *cobra.Command, c client.SearchClient, targets []string, timeout, interval time.Duration) {
+	targetSet := make(map[string]bool, len(targets))
+	for _, t := range targets {
+		targetSet[t] = true
+	}
+	pattern := strings.Join(targets, ",")
+
+	deadline := time.Now().Add(timeout)
+	last := ""
+	for {
+		shards, err := c.GetShards(pattern)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting shards: %v\n", err)
+			os.Exit(1)
+		}
+		recoveries, err := c.GetRecovery(pattern, false)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting recovery status: %v\n", err)
+			os.Exit(1)
+		}
+
+		st := restoreProgress(targetSet, shards, recoveries)
+		line := fmt.Sprintf("Primaries started: %d/%d", st.started, st.total)
+		if st.bytesTotal > 0 {
+			line += fmt.Sprintf(", restored %s/%s (%.0f%%)", output.FormatBytes(st.bytesDone), output.FormatBytes(st.bytesTotal),
+				float64(st.bytesDone)/float64(st.bytesTotal)*100)
+		}
+		if line != last {
+			cmd.Println(line)
+			last = line
+		}
+
+		if st.total > 0 && st.started == st.total {
+			cmd.Printf("Restore complete: all %d primary shards started\n", st.total)
+			return
+		}
+		if time.Now().After(deadline) {
+			fmt.Fprintf(os.Stderr, "Error: timed out after %s waiting for restored primaries to start\n", timeout)
+			os.Exit(1)
+		}
+		time.Sleep(interval)
+	}
+}
@@ -0,0 +1,20 @@
+package snapshot
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewSnapshotCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "snapshot",
+		Short:   "Snapshot operations",
+		Long:    "Inspect running snapshots, verify snapshot repositories and run snapshot policies. Use get, describe, create and delete for snapshot and repository resources.",
+		Aliases: []string{"snapshots", "snap"},
+	}
+
+	cmd.AddCommand(NewStatusCmd())
+	cmd.AddCommand(NewVerifyRepositoryCmd())
+	cmd.AddCommand(NewExecuteCmd())
+
+	return cmd
+}
@@ -0,0 +1,163 @@
+package snapshot
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/chronicblondiee/searchctl/pkg/types"
+)
+
+func TestNewSnapshotCmd(t *testing.T) {
+	cmd := NewSnapshotCmd()
+
+	expected := map[string]bool{"status": false, "verify-repository": false, "execute": false}
+	for _, sub := range cmd.Commands() {
+		if _, ok := expected[sub.Name()]; ok {
+			expected[sub.Name()] = true
+		}
+	}
+	for name, found := range expected {
+		if !found {
+			t.Errorf("Expected subcommand '%s' not found", name)
+		}
+	}
+}
+
+func TestShardRows(t *testing.T) {
+	st := types.SnapshotStatus{
+		Snapshot:    "snap-1",
+		Repository:  "backups",
+		State:       "STARTED",
+		ShardsStats: types.SnapshotShardsStats{Done: 1, Total: 3},
+		Stats: types.SnapshotStats{
+			Incremental: types.SnapshotFileStats{SizeInBytes: 4096},
+			Processed:   &types.SnapshotFileStats{SizeInBytes: 1024},
+		},
+		Indices: map[string]types.SnapshotIndexStatus{
+			"logs": {Shards: map[string]types.SnapshotShardStatus{
+				"10": {Stage: "STARTED", Stats: types.SnapshotStats{
+					Incremental: types.SnapshotFileStats{SizeInBytes: 2000},
+					Processed:   &types.SnapshotFileStats{SizeInBytes: 500},
+				}},
+				"2": {Stage: "DONE", Stats: types.SnapshotStats{Incremental: types.SnapshotFileStats{SizeInBytes: 100}}},
+			}},
+			"app": {Shards: map[string]types.SnapshotShardStatus{"0": {Stage: "INIT"}}},
+		},
+	}
+
+	rows := shardRows(st)
+	if len(rows) != 3 {
+		t.Fatalf("Expected 3 rows, got %d", len(rows))
+	}
+	first := rows[0].(map[string]interface{})
+	if first["INDEX"] != "app" || first["PROGRESS"] != "0%" {
+		t.Errorf("Unexpected first row: %v", first)
+	}
+	second := rows[1].(map[string]interface{})
+	if second["SHARD"] != 2 || second["PROGRESS"] != "100%" {
+		t.Errorf("Expected shard 2 done before shard 10, got %v", second)
+	}
+	third := rows[2].(map[string]interface{})
+	if third["PROGRESS"] != "25%" {
+		t.Errorf("Expected 25%% progress, got %v", third["PROGRESS"])
+	}
+
+	line := summaryLine(st)
+	if !strings.Contains(line, "1/3 shards done") || !strings.Contains(line, "25%") {
+		t.Errorf("Unexpected summary line: %s", line)
+	}
+}
+
+func TestParseSnapshotRef(t *testing.T) {
+	repo, name, err := parseSnapshotRef([]string{"backups/nightly-1"})
+	if err != nil || repo != "backups" || name != "nightly-1" {
+		t.Errorf("Unexpected result: %s %s %v", repo, name, err)
+	}
+	if _, _, err := parseSnapshotRef([]string{"nightly-1"}); err == nil {
+		t.Error("Expected error without repository")
+	}
+}
+
+func TestSelectIndices(t *testing.T) {
+	snap := &types.Snapshot{
+		Indices:     []string{"logs-1", "logs-2", "orders", ".ds-metrics-2024.01.01-000001"},
+		DataStreams: []string{"metrics"},
+	}
+
+	got := strings.Join(selectIndices(snap, "logs-*,-logs-2,metrics"), ",")
+	if got != ".ds-metrics-2024.01.01-000001,logs-1" {
+		t.Errorf("Unexpected selection: %s", got)
+	}
+	if len(selectIndices(snap, "")) != 4 {
+		t.Error("Expected all indices without --indices")
+	}
+}
+
+func TestPlanRestore(t *testing.T) {
+	existing := map[string]string{"logs-1": "open", "restored-logs-2": "close"}
+
+	plan := planRestore([]string{"logs-1", "logs-2"}, nil, "", existing)
+	if plan[0].conflict == "" {
+		t.Error("Expected conflict with open index logs-1")
+	}
+
+	rename := regexp.MustCompile("(.+)")
+	plan = planRestore([]string{"logs-1", "logs-2"}, rename, "restored-$1", existing)
+	if plan[0].target != "restored-logs-1" || plan[0].conflict != "" {
+		t.Errorf("Unexpected target: %+v", plan[0])
+	}
+	if plan[1].note != "overwrites closed index" {
+		t.Errorf("Expected closed index to be overwritten, got %+v", plan[1])
+	}
+
+	plan = planRestore([]string{"a-1", "b-1"}, regexp.MustCompile(`^\w-`), "x-", nil)
+	if plan[1].conflict == "" {
+		t.Error("Expected duplicate target conflict")
+	}
+}
+
+func TestJavaReplacement(t *testing.T) {
+	tests := []struct {
+		pattern     string
+		replacement string
+		source      string
+		expected    string
+	}{
+		{"(.+)", "$1_restored", "logs-1", "logs-1_restored"},
+		{"(.+)", "$12", "logs-1", "logs-12"},
+		{"(a)(b)(c)(d)(e)(f)(g)(h)(i)(j)(k)(l)", "$12", "abcdefghijkl", "l"},
+		{"logs-(.+)", `\$$1`, "logs-1", "$1"},
+		{"(?P<day>.+)", "${day}-old", "2024.01.01", "2024.01.01-old"},
+	}
+	for _, tt := range tests {
+		re := regexp.MustCompile(tt.pattern)
+		plan := planRestore([]string{tt.source}, re, tt.replacement, nil)
+		if plan[0].target != tt.expected {
+			t.Errorf("%s -> %s on %s: expected %s, got %s", tt.pattern, tt.replacement, tt.source, tt.expected, plan[0].target)
+		}
+	}
+}
+
+func TestRestoreProgress(t *testing.T) {
+	targets := map[string]bool{"r-1": true}
+	shards := []types.CatShardRow{
+		{Index: "r-1", Shard: "0", PrimaryOrReplica: "p", State: "STARTED"},
+		{Index: "r-1", Shard: "1", PrimaryOrReplica: "p", State: "INITIALIZING"},
+		{Index: "r-1", Shard: "0", PrimaryOrReplica: "r", State: "UNASSIGNED"},
+		{Index: "other", Shard: "0", PrimaryOrReplica: "p", State: "STARTED"},
+	}
+	recoveries := []types.CatRecoveryRow{
+		{Index: "r-1", Type: "snapshot", BytesTotal: "1000", BytesPercent: "100.0%"},
+		{Index: "r-1", Type: "snapshot", BytesTotal: "1000", BytesPercent: "50.0%"},
+		{Index: "r-1", Type: "peer", BytesTotal: "1000", BytesPercent: "0.0%"},
+	}
+
+	st := restoreProgress(targets, shards, recoveries)
+	if st.started != 1 || st.total != 2 {
+		t.Errorf("Expected 1/2 primaries started, got %d/%d", st.started, st.total)
+	}
+	if st.bytesDone != 1500 || st.bytesTotal != 2000 {
+		t.Errorf("Expected 1500/2000 bytes, got %d/%d", st.bytesDone, st.bytesTotal)
+	}
+}
@@ -0,0 +1,158 @@
+package snapshot
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/output"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewStatusCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status REPOSITORY [SNAPSHOT]",
+		Short: "Show shard-level progress of snapshots",
+		Long: `Show the shard-level progress of a snapshot using the _status API.
+
+Without SNAPSHOT, every snapshot currently running in the repository is shown.
+Reading the status of a completed snapshot loads its metadata from the repository
+and can be slow for large snapshots.`,
+		Example: strings.TrimSpace(`
+# Running snapshots in a repository
+searchctl snapshot status backups
+
+# Per-shard progress of one snapshot
+searchctl snapshot status backups nightly-1`),
+		Args: cobra.RangeArgs(1, 2),
+		Run: func(cmd *cobra.Command, args []string) {
+			repository := args[0]
+			name := ""
+			if len(args) > 1 {
+				name = args[1]
+			}
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			statuses, err := c.GetSnapshotStatus(repository, name)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting snapshot status: %v\n", err)
+				os.Exit(1)
+			}
+
+			outFmt := viper.GetString("output")
+			if outFmt == "json" || outFmt == "yaml" {
+				if err := output.NewFormatter(outFmt).Format(statuses, cmd.OutOrStdout()); err != nil {
+					fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+					os.Exit(1)
+				}
+				return
+			}
+
+			if len(statuses) == 0 {
+				cmd.Printf("No running snapshots in repository %s\n", repository)
+				return
+			}
+
+			for i, st := range statuses {
+				if i > 0 {
+					fmt.Fprintln(cmd.OutOrStdout())
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), summaryLine(st))
+				if err := output.NewFormatter(outFmt).Format(shardRows(st), cmd.OutOrStdout()); err != nil {
+					fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+					os.Exit(1)
+				}
+			}
+		},
+	}
+
+	return cmd
+}
+
+func summaryLine(st types.SnapshotStatus) string {
+	line := fmt.Sprintf("Snapshot %s/%s %s: %d/%d shards done", st.Repository, st.Snapshot, st.State, st.ShardsStats.Done, st.ShardsStats.Total)
+	if st.ShardsStats.Failed > 0 {
+		line += fmt.Sprintf(", %d failed", st.ShardsStats.Failed)
+	}
+	return line + ", " + sizeProgress(st.Stats, st.State == "SUCCESS")
+}
+
+// sizeProgress reports processed/incremental bytes; only the incremental part of a snapshot is copied
+func sizeProgress(stats types.SnapshotStats, done bool) string {
+	incremental := stats.Incremental.SizeInBytes
+	var processed int64
+	if done {
+		processed = incremental
+	} else if stats.Processed != nil {
+		processed = stats.Processed.SizeInBytes
+	}
+	return fmt.Sprintf("%s/%s (%s)", output.FormatBytes(processed), output.FormatBytes(incremental), percent(processed, incremental, done))
+}
+
+func percent(processed, total int64, done bool) string {
+	if done {
+		return "100%"
+	}
+	if total <= 0 {
+		return "0%"
+	}
+	return fmt.Sprintf("%.0f%%", float64(processed)/float64(total)*100)
+}
+
+func shardRows(st types.SnapshotStatus) []interface{} {
+	type shardKey struct {
+		index string
+		shard int
+		id    string
+	}
+	var keys []shardKey
+	for index, idx := range st.Indices {
+		for id := range idx.Shards {
+			n, _ := strconv.Atoi(id)
+			keys = append(keys, shardKey{index: index, shard: n, id: id})
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].index != keys[j].index {
+			return keys[i].index < keys[j].index
+		}
+		return keys[i].shard < keys[j].shard
+	})
+
+	rows := make([]interface{}, 0, len(keys))
+	for _, k := range keys {
+		shard := st.Indices[k.index].Shards[k.id]
+		done := shard.Stage == "DONE"
+		var processed int64
+		if done {
+			processed = shard.Stats.Incremental.SizeInBytes
+		} else if shard.Stats.Processed != nil {
+			processed = shard.Stats.Processed.SizeInBytes
+		}
+		row := map[string]interface{}{
+			"__columns": "INDEX,SHARD,STAGE,NODE,PROGRESS,SIZE,FILES",
+			"INDEX":     k.index,
+			"SHARD":     k.shard,
+			"STAGE":     shard.Stage,
+			"NODE":      shard.Node,
+			"PROGRESS":  percent(processed, shard.Stats.Incremental.SizeInBytes, done),
+			"SIZE":      output.FormatBytes(shard.Stats.Incremental.SizeInBytes),
+			"FILES":     shard.Stats.Incremental.FileCount,
+		}
+		if shard.Reason != "" {
+			row["STAGE"] = shard.Stage + " (" + shard.Reason + ")"
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
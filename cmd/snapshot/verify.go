@@ -0,0 +1,61 @@
+package snapshot
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewVerifyRepositoryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "verify-repository REPOSITORY",
+		Short:   "Verify a snapshot repository",
+		Long:    "Check that every master and data node can access a snapshot repository, and list the nodes that verified it.",
+		Aliases: []string{"verify"},
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			name := args[0]
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			nodes, err := c.VerifySnapshotRepository(name)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error verifying snapshot repository: %v\n", err)
+				os.Exit(1)
+			}
+
+			outFmt := viper.GetString("output")
+			if outFmt == "json" || outFmt == "yaml" {
+				if err := output.NewFormatter(outFmt).Format(nodes, cmd.OutOrStdout()); err != nil {
+					fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+					os.Exit(1)
+				}
+				return
+			}
+
+			cmd.Printf("Snapshot repository %s verified on %d nodes\n", name, len(nodes))
+			data := make([]interface{}, len(nodes))
+			for i, n := range nodes {
+				data[i] = map[string]interface{}{
+					"__columns": "NODE,ID",
+					"NODE":      n.Name,
+					"ID":        n.ID,
+				}
+			}
+			if err := output.NewFormatter(outFmt).Format(data, cmd.OutOrStdout()); err != nil {
+				fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	return cmd
+}
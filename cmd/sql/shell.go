@@ -0,0 +1,176 @@
+package sql
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const maxHistory = 500
+
+// shell is the interactive mode. Statements may span lines and end with ';'.
+type shell struct {
+	lang    string
+	in      io.Reader
+	prompt  io.Writer
+	history *history
+	execute func(query string) error
+}
+
+func (s *shell) run() error {
+	fmt.Fprintf(s.prompt, "Enter %s statements ending with ';'. Type \\help for help, \\q to quit.\n", strings.ToUpper(s.lang))
+
+	scanner := bufio.NewScanner(s.in)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	var stmt strings.Builder
+	for {
+		if stmt.Len() == 0 {
+			fmt.Fprintf(s.prompt, "%s> ", s.lang)
+		} else {
+			fmt.Fprintf(s.prompt, "%s-> ", strings.Repeat(" ", len(s.lang)-1))
+		}
+		if !scanner.Scan() {
+			fmt.Fprintln(s.prompt)
+			return scanner.Err()
+		}
+		line := strings.TrimSpace(scanner.Text())
+
+		if stmt.Len() == 0 {
+			if line == "" {
+				continue
+			}
+			if quit, handled := s.command(line); handled {
+				if quit {
+					return nil
+				}
+				continue
+			}
+		}
+
+		if stmt.Len() > 0 {
+			stmt.WriteByte(' ')
+		}
+		stmt.WriteString(line)
+		if !strings.HasSuffix(line, ";") {
+			continue
+		}
+		query := strings.TrimSpace(strings.TrimSuffix(stmt.String(), ";"))
+		stmt.Reset()
+		if query != "" {
+			s.submit(query)
+		}
+	}
+}
+
+// command handles backslash commands and history expansion. It reports whether the
+// line was handled and whether the shell should exit.
+func (s *shell) command(line string) (quit bool, handled bool) {
+	switch {
+	case line == `\q` || line == "quit" || line == "exit":
+		return true, true
+	case line == `\help` || line == `\?`:
+		fmt.Fprintln(s.prompt, `Statements end with ';' and may span several lines.
+  \history   list previous statements
+  !N         run statement N from the history again
+  !!         run the last statement again
+  \q         quit`)
+		return false, true
+	case line == `\history` || line == `\h`:
+		for i, q := range s.history.entries {
+			fmt.Fprintf(s.prompt, "%4d  %s\n", i+1, q)
+		}
+		return false, true
+	case strings.HasPrefix(line, "!"):
+		query, err := s.history.expand(line)
+		if err != nil {
+			fmt.Fprintf(s.prompt, "Error: %v\n", err)
+			return false, true
+		}
+		fmt.Fprintln(s.prompt, query)
+		s.submit(query)
+		return false, true
+	}
+	return false, false
+}
+
+func (s *shell) submit(query string) {
+	s.history.add(query)
+	if err := s.execute(query); err != nil {
+		fmt.Fprintf(s.prompt, "Error: %v\n", err)
+	}
+}
+
+// history keeps previous statements and appends new ones to a file when it has one
+type history struct {
+	path    string
+	entries []string
+}
+
+func historyPath(lang string) string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".searchctl", lang+"_history")
+}
+
+func loadHistory(path string) *history {
+	h := &history{path: path}
+	if path == "" {
+		return h
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return h
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			h.entries = append(h.entries, line)
+		}
+	}
+	// Keep the file from growing without bound
+	if len(h.entries) > maxHistory {
+		h.entries = h.entries[len(h.entries)-maxHistory:]
+		_ = os.WriteFile(path, []byte(strings.Join(h.entries, "\n")+"\n"), 0600)
+	}
+	return h
+}
+
+func (h *history) add(query string) {
+	if n := len(h.entries); n > 0 && h.entries[n-1] == query {
+		return
+	}
+	h.entries = append(h.entries, query)
+	if len(h.entries) > maxHistory {
+		h.entries = h.entries[1:]
+	}
+	if h.path == "" {
+		return
+	}
+	f, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	fmt.Fprintln(f, query)
+}
+
+// expand resolves !! and !N to a statement from the history
+func (h *history) expand(line string) (string, error) {
+	if len(h.entries) == 0 {
+		return "", fmt.Errorf("history is empty")
+	}
+	ref := strings.TrimSuffix(strings.TrimPrefix(line, "!"), ";")
+	if ref == "!" {
+		return h.entries[len(h.entries)-1], nil
+	}
+	n, err := strconv.Atoi(ref)
+	if err != nil || n < 1 || n > len(h.entries) {
+		return "", fmt.Errorf("no history entry %q", ref)
+	}
+	return h.entries[n-1], nil
+}
@@ -0,0 +1,324 @@
+package sql
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/output"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	langSQL = "sql"
+	langPPL = "ppl"
+)
+
+type options struct {
+	fetchSize int
+	maxRows   int
+}
+
+func NewSQLCmd() *cobra.Command {
+	var opts options
+
+	cmd := &cobra.Command{
+		Use:   "sql [QUERY]",
+		Short: "Run SQL queries",
+		Long: `Run an SQL query with the Elasticsearch SQL API or the OpenSearch SQL plugin.
+
+Results are fetched in pages of --fetch-size rows by following the cursor until
+all rows or --max-rows rows have been read. They are rendered as a table, or with
+-o csv, -o json or -o yaml. CSV is written page by page as the rows arrive.
+
+Without a query, searchctl reads one from stdin, or starts an interactive shell
+when stdin is a terminal. In the shell, statements end with ';', \history lists
+previous statements, !N and !! run them again and \q exits. History is kept in
+~/.searchctl/sql_history.`,
+		Example: strings.TrimSpace(`
+# Top hosts by errors
+searchctl sql "SELECT host.name, COUNT(*) AS errors FROM \"logs-*\" WHERE level = 'ERROR' GROUP BY host.name ORDER BY errors DESC LIMIT 10"
+
+# Export to CSV
+searchctl sql "SELECT * FROM orders WHERE status = 'shipped'" -o csv --max-rows 0 > shipped.csv
+
+# Interactive shell
+searchctl sql`),
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			run(cmd, langSQL, args, opts)
+		},
+	}
+
+	addFlags(cmd, &opts)
+	return cmd
+}
+
+func NewPPLCmd() *cobra.Command {
+	var opts options
+
+	cmd := &cobra.Command{
+		Use:   "ppl [QUERY]",
+		Short: "Run PPL queries (OpenSearch)",
+		Long: `Run a piped processing language query with the OpenSearch SQL plugin.
+
+Output and the interactive shell work as for the sql command. History is kept
+in ~/.searchctl/ppl_history.`,
+		Example: strings.TrimSpace(`
+# Errors per host
+searchctl ppl "source=logs-* | where level = 'ERROR' | stats count() by host.name"
+
+# Interactive shell
+searchctl ppl`),
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			run(cmd, langPPL, args, opts)
+		},
+	}
+
+	addFlags(cmd, &opts)
+	return cmd
+}
+
+func addFlags(cmd *cobra.Command, opts *options) {
+	cmd.Flags().IntVar(&opts.fetchSize, "fetch-size", 1000, "rows fetched per page")
+	cmd.Flags().IntVar(&opts.maxRows, "max-rows", 10000, "stop after this many rows (0 for all)")
+}
+
+func run(cmd *cobra.Command, lang string, args []string, opts options) {
+	c, err := client.NewClient()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+		os.Exit(1)
+	}
+	r := &runner{client: c, lang: lang, fetchSize: opts.fetchSize, maxRows: opts.maxRows, out: cmd.OutOrStdout()}
+
+	if len(args) == 1 {
+		if err := r.execute(args[0]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if !output.IsTerminal(os.Stdin) {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading query: %v\n", err)
+			os.Exit(1)
+		}
+		query := strings.TrimSuffix(strings.TrimSpace(string(data)), ";")
+		if query == "" {
+			fmt.Fprintf(os.Stderr, "Error: no query given\n")
+			os.Exit(1)
+		}
+		if err := r.execute(query); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	sh := &shell{
+		lang:    lang,
+		in:      os.Stdin,
+		prompt:  os.Stderr,
+		history: loadHistory(historyPath(lang)),
+		execute: r.execute,
+	}
+	if err := sh.run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// runner executes queries, follows cursors and prints the results
+type runner struct {
+	client    client.SearchClient
+	lang      string
+	fetchSize int
+	maxRows   int
+	out       io.Writer
+}
+
+func (r *runner) execute(query string) error {
+	outFmt := viper.GetString("output")
+
+	// CSV rows are written as each page arrives; other formats need every row first
+	var result *types.SQLResponse
+	var emit func(page *types.SQLResponse) error
+	if outFmt == "csv" {
+		cw := &csvWriter{w: csv.NewWriter(r.out)}
+		emit = cw.write
+	} else {
+		emit = func(page *types.SQLResponse) error {
+			if result == nil {
+				result = page
+			} else {
+				result.Rows = append(result.Rows, page.Rows...)
+			}
+			return nil
+		}
+	}
+
+	rows, truncated, err := r.fetch(query, emit)
+	if err != nil {
+		return err
+	}
+
+	if outFmt == "json" || outFmt == "yaml" {
+		if err := output.NewFormatter(outFmt).Format(result, r.out); err != nil {
+			return err
+		}
+	} else if outFmt != "csv" && rows > 0 {
+		if err := output.NewFormatter(outFmt).Format(buildRows(result), r.out); err != nil {
+			return err
+		}
+	}
+
+	if truncated {
+		fmt.Fprintf(os.Stderr, "%d rows (stopped at --max-rows)\n", rows)
+	} else {
+		fmt.Fprintf(os.Stderr, "%d rows\n", rows)
+	}
+	return nil
+}
+
+// fetch runs the query and follows the cursor until all rows or maxRows rows are read,
+// passing each page to emit as it arrives. Only the first page carries the columns.
+// A cursor that is not read to the end is closed.
+func (r *runner) fetch(query string, emit func(page *types.SQLResponse) error) (int, bool, error) {
+	var page *types.SQLResponse
+	var err error
+	if r.lang == langPPL {
+		page, err = r.client.PPLQuery(query)
+	} else {
+		page, err = r.client.SQLQuery(query, r.fetchSize)
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	openSearch := page.OpenSearch
+
+	rows := 0
+	truncated := false
+	for {
+		cursor := page.Cursor
+		page.Cursor = ""
+		if r.maxRows > 0 && rows+len(page.Rows) > r.maxRows {
+			page.Rows = page.Rows[:r.maxRows-rows]
+			truncated = true
+		}
+		rows += len(page.Rows)
+		if err := emit(page); err != nil {
+			r.closeCursor(cursor, openSearch)
+			return rows, false, err
+		}
+
+		if cursor == "" {
+			break
+		}
+		if r.maxRows > 0 && rows >= r.maxRows {
+			truncated = true
+			r.closeCursor(cursor, openSearch)
+			break
+		}
+		if page, err = r.client.SQLNext(cursor, openSearch); err != nil {
+			return rows, false, err
+		}
+	}
+	return rows, truncated, nil
+}
+
+func (r *runner) closeCursor(cursor string, openSearch bool) {
+	if cursor == "" {
+		return
+	}
+	if err := r.client.SQLClose(cursor, openSearch); err != nil {
+		fmt.Fprintf(os.Stderr, "Error closing cursor: %v\n", err)
+	}
+}
+
+// csvWriter writes pages of results as CSV, with a header line before the first page
+type csvWriter struct {
+	w       *csv.Writer
+	columns []string
+}
+
+func (c *csvWriter) write(page *types.SQLResponse) error {
+	if c.columns == nil {
+		c.columns = columnNames(page.Columns)
+		if err := c.w.Write(c.columns); err != nil {
+			return err
+		}
+	}
+	for _, values := range page.Rows {
+		record := make([]string, len(c.columns))
+		for j := range record {
+			if j < len(values) {
+				record[j] = formatValue(values[j])
+			}
+		}
+		if err := c.w.Write(record); err != nil {
+			return err
+		}
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// columnNames returns the result column names. Repeated names get a numeric suffix
+// so no value is lost.
+func columnNames(cols []types.SQLColumn) []string {
+	columns := make([]string, len(cols))
+	used := map[string]int{}
+	for i, col := range cols {
+		name := col.Name
+		used[name]++
+		if n := used[name]; n > 1 {
+			name += "_" + strconv.Itoa(n)
+		}
+		columns[i] = name
+	}
+	return columns
+}
+
+// buildRows turns result rows into table rows keyed by column name
+func buildRows(result *types.SQLResponse) []interface{} {
+	columns := columnNames(result.Columns)
+
+	rows := make([]interface{}, len(result.Rows))
+	for i, values := range result.Rows {
+		row := map[string]interface{}{"__columns": columns}
+		for j, name := range columns {
+			var v interface{}
+			if j < len(values) {
+				v = values[j]
+			}
+			row[name] = formatValue(v)
+		}
+		rows[i] = row
+	}
+	return rows
+}
+
+func formatValue(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case map[string]interface{}, []interface{}:
+		data, _ := json.Marshal(val)
+		return string(data)
+	default:
+		return fmt.Sprint(val)
+	}
+}
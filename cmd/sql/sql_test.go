@@ -0,0 +1,131 @@
+package sql
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/chronicblondiee/searchctl/pkg/types"
+)
+
+func TestNewSQLCmd(t *testing.T) {
+	for _, cmd := range []struct {
+		use  string
+		name string
+	}{{NewSQLCmd().Use, "sql [QUERY]"}, {NewPPLCmd().Use, "ppl [QUERY]"}} {
+		if cmd.use != cmd.name {
+			t.Errorf("Expected Use %q, got %q", cmd.name, cmd.use)
+		}
+	}
+	cmd := NewSQLCmd()
+	for _, name := range []string{"fetch-size", "max-rows"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("Expected flag %s", name)
+		}
+	}
+}
+
+func TestBuildRows(t *testing.T) {
+	result := &types.SQLResponse{
+		Columns: []types.SQLColumn{{Name: "host", Type: "keyword"}, {Name: "count", Type: "long"}, {Name: "count", Type: "long"}},
+		Rows: [][]interface{}{
+			{"web-1", json.Number("3"), nil},
+			{map[string]interface{}{"a": 1.0}, json.Number("1")},
+		},
+	}
+
+	rows := buildRows(result)
+	first := rows[0].(map[string]interface{})
+	expected := map[string]interface{}{
+		"__columns": []string{"host", "count", "count_2"},
+		"host":      "web-1",
+		"count":     "3",
+		"count_2":   "",
+	}
+	if !reflect.DeepEqual(first, expected) {
+		t.Errorf("Expected %v, got %v", expected, first)
+	}
+	if second := rows[1].(map[string]interface{}); second["host"] != `{"a":1}` || second["count_2"] != "" {
+		t.Errorf("Unexpected row %v", second)
+	}
+}
+
+func TestCSVWriter(t *testing.T) {
+	var buf bytes.Buffer
+	cw := &csvWriter{w: csv.NewWriter(&buf)}
+	pages := []*types.SQLResponse{
+		{Columns: []types.SQLColumn{{Name: "host"}, {Name: "count"}}, Rows: [][]interface{}{{"web-1", json.Number("3")}}},
+		{Rows: [][]interface{}{{"web-2", nil}}},
+	}
+
+	for i, page := range pages {
+		if err := cw.write(page); err != nil {
+			t.Fatalf("write failed: %v", err)
+		}
+		// Each page is flushed before the next one is fetched
+		if i == 0 && buf.String() != "host,count\nweb-1,3\n" {
+			t.Errorf("Unexpected output after first page: %q", buf.String())
+		}
+	}
+	expected := "host,count\nweb-1,3\nweb-2,\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+}
+
+func TestShell(t *testing.T) {
+	var executed []string
+	var prompt bytes.Buffer
+	sh := &shell{
+		lang:    langSQL,
+		in:      strings.NewReader("SELECT 1;\nSELECT *\n  FROM logs\n  LIMIT 5;\n\\history\n!1\n!!\n!9\n\\q\nSELECT 2;\n"),
+		prompt:  &prompt,
+		history: &history{},
+		execute: func(q string) error {
+			executed = append(executed, q)
+			return nil
+		},
+	}
+	if err := sh.run(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{"SELECT 1", "SELECT * FROM logs LIMIT 5", "SELECT 1", "SELECT 1"}
+	if !reflect.DeepEqual(executed, expected) {
+		t.Errorf("Expected %v, got %v", expected, executed)
+	}
+	if !strings.Contains(prompt.String(), "   2  SELECT * FROM logs LIMIT 5") {
+		t.Errorf("Expected history listing, got %q", prompt.String())
+	}
+	if !strings.Contains(prompt.String(), `no history entry "9"`) {
+		t.Errorf("Expected error for unknown history entry, got %q", prompt.String())
+	}
+}
+
+func TestHistoryFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sql_history")
+
+	h := loadHistory(path)
+	h.add("SELECT 1")
+	h.add("SELECT 1")
+	h.add("SELECT 2")
+
+	loaded := loadHistory(path)
+	if !reflect.DeepEqual(loaded.entries, []string{"SELECT 1", "SELECT 2"}) {
+		t.Errorf("Unexpected history %v", loaded.entries)
+	}
+
+	var lines []string
+	for i := 0; i < maxHistory+10; i++ {
+		lines = append(lines, "SELECT "+strings.Repeat("x", i%3+1))
+	}
+	os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0600)
+	if loaded := loadHistory(path); len(loaded.entries) != maxHistory {
+		t.Errorf("Expected history to be capped at %d, got %d", maxHistory, len(loaded.entries))
+	}
+}
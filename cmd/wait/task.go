@@ -0,0 +1,81 @@
+package wait
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/chronicblondiee/searchctl/pkg/client"
+	"github.com/chronicblondiee/searchctl/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewWaitTaskCmd() *cobra.Command {
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "task TASK_ID",
+		Short: "Wait for a task to complete",
+		Long: `Wait until a task completes, using _tasks with wait_for_completion.
+
+Exits non-zero when the timeout expires or the task finishes with an error,
+which makes it usable in scripts after starting a background operation.`,
+		Example: strings.TrimSpace(`
+# Wait up to an hour for a force merge
+searchctl wait task oTUltX4IQMOUUVeiohTt8A:12345 --timeout 1h`),
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			taskID := args[0]
+
+			if viper.GetBool("dry-run") {
+				cmd.Printf("Would wait up to %s for task %s\n", timeout, taskID)
+				return
+			}
+
+			c, err := client.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+				os.Exit(1)
+			}
+
+			// Whole seconds, rounded up so that a sub-second timeout does not become 0s
+			result, err := c.WaitForTask(taskID, fmt.Sprintf("%ds", int(math.Ceil(timeout.Seconds()))))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error waiting for task: %v\n", err)
+				os.Exit(1)
+			}
+
+			outFmt := viper.GetString("output")
+			if outFmt == "json" || outFmt == "yaml" {
+				if err := output.NewFormatter(outFmt).Format(result, os.Stdout); err != nil {
+					fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+					os.Exit(1)
+				}
+			}
+
+			if !result.Completed {
+				fmt.Fprintf(os.Stderr, "Task %s did not complete within %s\n", taskID, timeout)
+				os.Exit(1)
+			}
+			if result.Error != nil {
+				reason := interface{}(result.Error)
+				if r, ok := result.Error["reason"]; ok {
+					reason = r
+				}
+				fmt.Fprintf(os.Stderr, "Task %s failed: %v\n", taskID, reason)
+				os.Exit(1)
+			}
+			if outFmt != "json" && outFmt != "yaml" {
+				running := time.Duration(result.Task.RunningTimeInNanos).Round(time.Millisecond)
+				cmd.Printf("Task %s (%s) completed after %s\n", taskID, result.Task.Action, running)
+			}
+		},
+	}
+
+	cmd.Flags().DurationVar(&timeout, "timeout", 30*time.Minute, "maximum time to wait")
+
+	return cmd
+}
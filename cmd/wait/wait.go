@@ -0,0 +1,17 @@
+package wait
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewWaitCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "wait",
+		Short: "Wait for an operation to complete",
+		Long:  "Block until an operation in the search cluster completes or a timeout expires.",
+	}
+
+	cmd.AddCommand(NewWaitTaskCmd())
+
+	return cmd
+}
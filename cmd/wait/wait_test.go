@@ -0,0 +1,21 @@
+package wait
+
+import (
+	"testing"
+)
+
+func TestNewWaitCmd(t *testing.T) {
+	cmd := NewWaitCmd()
+
+	if cmd.Use != "wait" {
+		t.Errorf("Expected Use 'wait', got %s", cmd.Use)
+	}
+
+	sub := NewWaitTaskCmd()
+	if sub.Use != "task TASK_ID" {
+		t.Errorf("Expected Use 'task TASK_ID', got %s", sub.Use)
+	}
+	if sub.Flags().Lookup("timeout") == nil {
+		t.Error("Expected --timeout flag")
+	}
+}
@@ -0,0 +1,56 @@
+// Package alias builds _aliases actions shared by the alias and resize commands.
+package alias
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/chronicblondiee/searchctl/pkg/types"
+)
+
+// SwapActions returns remove/add action pairs for every alias on oldIndex, optionally restricted to names
+func SwapActions(defs []types.IndexAlias, oldIndex, newIndex string, names []string) ([]types.AliasAction, []string, error) {
+	onOld := map[string]types.IndexAlias{}
+	for _, d := range defs {
+		if d.Index == oldIndex {
+			onOld[d.Alias] = d
+		}
+	}
+
+	selected := names
+	if len(selected) == 0 {
+		for name := range onOld {
+			selected = append(selected, name)
+		}
+		sort.Strings(selected)
+	}
+	if len(selected) == 0 {
+		return nil, nil, fmt.Errorf("index %q has no aliases to swap", oldIndex)
+	}
+
+	var actions []types.AliasAction
+	for _, name := range selected {
+		d, ok := onOld[name]
+		if !ok {
+			return nil, nil, fmt.Errorf("alias %q does not point to index %q", name, oldIndex)
+		}
+		add := map[string]interface{}{"index": newIndex, "alias": name}
+		if len(d.Filter) > 0 {
+			add["filter"] = d.Filter
+		}
+		if d.IndexRouting != "" {
+			add["index_routing"] = d.IndexRouting
+		}
+		if d.SearchRouting != "" {
+			add["search_routing"] = d.SearchRouting
+		}
+		if d.IsWriteIndex != nil {
+			add["is_write_index"] = *d.IsWriteIndex
+		}
+		actions = append(actions,
+			types.AliasAction{"remove": {"index": oldIndex, "alias": name}},
+			types.AliasAction{"add": add},
+		)
+	}
+	return actions, selected, nil
+}
@@ -0,0 +1,43 @@
+package alias
+
+import (
+	"testing"
+
+	"github.com/chronicblondiee/searchctl/pkg/types"
+)
+
+func TestSwapActions(t *testing.T) {
+	writeIndex := true
+	defs := []types.IndexAlias{
+		{Alias: "logs-write", Index: "logs-v1", IsWriteIndex: &writeIndex},
+		{Alias: "logs-read", Index: "logs-v1", Filter: map[string]interface{}{"term": map[string]interface{}{"env": "prod"}}},
+		{Alias: "logs-read", Index: "logs-archive"},
+	}
+
+	actions, moved, err := SwapActions(defs, "logs-v1", "logs-v2", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(moved) != 2 || moved[0] != "logs-read" || moved[1] != "logs-write" {
+		t.Errorf("Expected sorted aliases [logs-read logs-write], got %v", moved)
+	}
+	if len(actions) != 4 {
+		t.Fatalf("Expected 4 actions, got %d", len(actions))
+	}
+	if actions[0]["remove"]["index"] != "logs-v1" {
+		t.Errorf("Expected first action to remove from logs-v1, got %v", actions[0])
+	}
+	if _, ok := actions[1]["add"]["filter"]; !ok {
+		t.Error("Expected filter to be carried over to the new index")
+	}
+	if actions[3]["add"]["is_write_index"] != true {
+		t.Error("Expected is_write_index to be carried over to the new index")
+	}
+
+	if _, _, err := SwapActions(defs, "logs-v1", "logs-v2", []string{"missing"}); err == nil {
+		t.Error("Expected error for alias not on old index")
+	}
+	if _, _, err := SwapActions(defs, "other", "logs-v2", nil); err == nil {
+		t.Error("Expected error when old index has no aliases")
+	}
+}
@@ -0,0 +1,124 @@
+package aliases
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/chronicblondiee/searchctl/pkg/client/rest"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+)
+
+type client struct {
+	restClient *rest.Client
+}
+
+func New(restClient *rest.Client) Interface {
+	return &client{
+		restClient: restClient,
+	}
+}
+
+func (c *client) List(pattern string) ([]types.Alias, error) {
+	aliasPattern := ""
+	if pattern != "" {
+		aliasPattern = "/" + pattern
+	}
+	path := fmt.Sprintf("/_cat/aliases%s?format=json&h=alias,index,filter,routing.index,routing.search,is_write_index", aliasPattern)
+	resp, err := c.restClient.Get(path)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return []types.Alias{}, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error getting aliases: %s", string(resp.Body))
+	}
+	var rows []types.Alias
+	if err := json.Unmarshal(resp.Body, &rows); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (c *client) Get(name string) ([]types.IndexAlias, error) {
+	resp, err := c.restClient.Get(fmt.Sprintf("/_alias/%s", name))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("alias %q not found", name)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error getting alias: %s", string(resp.Body))
+	}
+
+	// Response is keyed by index, then by alias name
+	var body map[string]struct {
+		Aliases map[string]types.IndexAlias `json:"aliases"`
+	}
+	if err := json.Unmarshal(resp.Body, &body); err != nil {
+		return nil, err
+	}
+
+	var out []types.IndexAlias
+	for index, entry := range body {
+		for alias, def := range entry.Aliases {
+			def.Alias = alias
+			def.Index = index
+			out = append(out, def)
+		}
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("alias %q not found", name)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Alias == out[j].Alias {
+			return out[i].Index < out[j].Index
+		}
+		return out[i].Alias < out[j].Alias
+	})
+	return out, nil
+}
+
+func (c *client) Create(index, name string, body map[string]interface{}) error {
+	path := fmt.Sprintf("/%s/_alias/%s", index, name)
+	resp, err := c.restClient.Put(path, body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("error creating alias: %s", string(resp.Body))
+	}
+	return nil
+}
+
+func (c *client) Delete(index, name string) error {
+	if index == "" {
+		index = "_all"
+	}
+	path := fmt.Sprintf("/%s/_alias/%s", index, name)
+	resp, err := c.restClient.Delete(path)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("error deleting alias: %s", string(resp.Body))
+	}
+	return nil
+}
+
+// Update submits all actions in a single _aliases request so they are applied atomically
+func (c *client) Update(actions []types.AliasAction) error {
+	body := map[string]interface{}{"actions": actions}
+	resp, err := c.restClient.Post("/_aliases", body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("error updating aliases: %s", string(resp.Body))
+	}
+	return nil
+}
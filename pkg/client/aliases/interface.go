@@ -0,0 +1,11 @@
+package aliases
+
+import "github.com/chronicblondiee/searchctl/pkg/types"
+
+type Interface interface {
+	List(pattern string) ([]types.Alias, error)
+	Get(name string) ([]types.IndexAlias, error)
+	Create(index, name string, body map[string]interface{}) error
+	Delete(index, name string) error
+	Update(actions []types.AliasAction) error
+}
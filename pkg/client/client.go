@@ -1,19 +1,61 @@
 package client
 
 import (
+	"sort"
+
+	"github.com/chronicblondiee/searchctl/pkg/client/documents"
+	"github.com/chronicblondiee/searchctl/pkg/client/search"
 	"github.com/chronicblondiee/searchctl/pkg/types"
 )
 
+// ErrTooManyRequests is returned by Bulk when the cluster answers 429 and the request should be retried later
+var ErrTooManyRequests = documents.ErrTooManyRequests
+
+// ErrSearchContextMissing is returned when a scroll or point in time has expired
+var ErrSearchContextMissing = search.ErrSearchContextMissing
+
+// ErrPointInTimeUnsupported is returned by clusters that predate point in time searches
+var ErrPointInTimeUnsupported = search.ErrPointInTimeUnsupported
+
 type SearchClient interface {
 	ClusterHealth() (*types.ClusterHealth, error)
 	ClusterInfo() (*types.ClusterInfo, error)
+	WaitForHealth(index, status, timeout string) (*types.ClusterHealth, error)
 	ClusterStats() (*types.ClusterStats, error)
 	ClusterState(metrics []string, indices, masterTimeout string) (*types.ClusterState, error)
+	ClusterCoordinationState() (*types.ClusterState, error)
 	ClusterPendingTasks() (*types.ClusterPendingTasks, error)
+	AddVotingExclusions(nodeNames []string, timeout string) error
+	ClearVotingExclusions(waitForRemoval bool) error
 	GetIndices(pattern string) ([]types.Index, error)
 	GetIndex(name string) (*types.Index, error)
 	CreateIndex(name string, body map[string]interface{}) error
 	DeleteIndex(name string) error
+	GetIndexSettings(name string, includeDefaults, flat bool) ([]types.IndexSettings, error)
+	UpdateIndexSettings(name string, body map[string]interface{}) error
+	GetIndexMapping(name string) ([]types.IndexMapping, error)
+	PutIndexMapping(name string, body map[string]interface{}) error
+	OpenIndex(name string) (*types.IndexOperationResponse, error)
+	CloseIndex(name string) (*types.IndexOperationResponse, error)
+	FreezeIndex(name string) (*types.IndexOperationResponse, error)
+	UnfreezeIndex(name string) (*types.IndexOperationResponse, error)
+	AddIndexBlock(name, block string) (*types.IndexOperationResponse, error)
+	RefreshIndex(name string) (*types.IndexOperationResponse, error)
+	FlushIndex(name string) (*types.IndexOperationResponse, error)
+	ForceMergeIndex(name string, opts types.ForceMergeOptions) (*types.IndexOperationResponse, error)
+	ClearIndexCache(name string, opts types.ClearCacheOptions) (*types.IndexOperationResponse, error)
+	ShrinkIndex(source, target string, body map[string]interface{}) (*types.ResizeResponse, error)
+	SplitIndex(source, target string, body map[string]interface{}) (*types.ResizeResponse, error)
+	CloneIndex(source, target string, body map[string]interface{}) (*types.ResizeResponse, error)
+	Reindex(body map[string]interface{}, opts types.ReindexOptions) (string, error)
+	RethrottleReindex(taskID string, requestsPerSecond float64) error
+	GetRecovery(pattern string, activeOnly bool) ([]types.CatRecoveryRow, error)
+	AnalyzeText(index string, body map[string]interface{}) (*types.AnalyzeResponse, error)
+	GetFieldCaps(pattern, fields string, includeUnmapped bool) (*types.FieldCapsResponse, error)
+	GetTasks(opts types.TaskListOptions) ([]types.TaskInfo, error)
+	GetTask(taskID string) (*types.TaskResult, error)
+	CancelTasks(taskID, actions string) ([]types.TaskInfo, error)
+	WaitForTask(taskID, timeout string) (*types.TaskResult, error)
 	GetNodes() ([]types.Node, error)
 	GetNode(nodeID string) (*types.Node, error)
 	GetDataStreams(pattern string) ([]types.DataStream, error)
@@ -34,14 +76,53 @@ type SearchClient interface {
 	CreateLifecyclePolicy(name string, body map[string]interface{}) error
 	DeleteLifecyclePolicy(name string) error
 	GetShards(pattern string) ([]types.CatShardRow, error)
+	GetAllocation(nodes string) ([]types.CatAllocationRow, error)
 	ExplainAllocation(req types.AllocationExplainRequest, includeYes, includeDisk bool) (*types.AllocationExplainResponse, error)
 	Reroute(commands []types.RerouteCommand, opts types.RerouteOptions) (*types.RerouteResponse, error)
 	GetClusterSettings() (*types.ClusterSettings, error)
+	GetClusterSettingsWithDefaults() (*types.ClusterSettings, error)
 	UpdateClusterSettings(body map[string]interface{}) error
 	GetIngestPipelines(pattern string) ([]types.IngestPipeline, error)
 	GetIngestPipeline(name string) (*types.IngestPipeline, error)
 	CreateIngestPipeline(name string, body map[string]interface{}) error
 	DeleteIngestPipeline(name string) error
+	SimulateIngestPipeline(name string, pipeline map[string]interface{}, docs []interface{}, verbose bool) (*types.SimulateResponse, error)
+	GetAliases(pattern string) ([]types.Alias, error)
+	GetAlias(name string) ([]types.IndexAlias, error)
+	CreateAlias(index, name string, body map[string]interface{}) error
+	DeleteAlias(index, name string) error
+	UpdateAliases(actions []types.AliasAction) error
+	GetSnapshotRepositories(pattern string) ([]types.SnapshotRepository, error)
+	GetSnapshotRepository(name string) (*types.SnapshotRepository, error)
+	CreateSnapshotRepository(name string, body map[string]interface{}, verify bool) error
+	DeleteSnapshotRepository(name string) error
+	VerifySnapshotRepository(name string) ([]types.VerifiedNode, error)
+	GetSnapshots(repository, pattern string) ([]types.Snapshot, error)
+	GetSnapshot(repository, name string) (*types.Snapshot, error)
+	CreateSnapshot(repository, name string, body map[string]interface{}, wait bool) (*types.Snapshot, error)
+	DeleteSnapshot(repository, name string) error
+	GetSnapshotStatus(repository, name string) ([]types.SnapshotStatus, error)
+	RestoreSnapshot(repository, name string, body map[string]interface{}) error
+	GetSnapshotPolicies(pattern string) ([]types.SnapshotPolicy, error)
+	GetSnapshotPolicy(name string) (*types.SnapshotPolicy, error)
+	CreateSnapshotPolicy(name string, body map[string]interface{}) error
+	DeleteSnapshotPolicy(name string) error
+	ExecuteSnapshotPolicy(name string) (string, error)
+	GetDocument(index, id string, opts types.DocumentOptions) (*types.Document, error)
+	IndexDocument(index, id string, body map[string]interface{}, opts types.DocumentOptions) (*types.DocumentWriteResult, error)
+	UpdateDocument(index, id string, body map[string]interface{}, opts types.DocumentOptions) (*types.DocumentWriteResult, error)
+	DeleteDocument(index, id string, opts types.DocumentOptions) (*types.DocumentWriteResult, error)
+	Bulk(index string, body []byte, opts types.DocumentOptions) (*types.BulkResponse, error)
+	Search(index string, body map[string]interface{}, opts types.SearchOptions) (*types.SearchResponse, error)
+	Count(index string, query map[string]interface{}) (int64, error)
+	Scroll(scrollID, keepAlive string) (*types.SearchResponse, error)
+	ClearScroll(scrollID string) error
+	OpenPointInTime(index, keepAlive string) (*types.PointInTime, error)
+	ClosePointInTime(pit *types.PointInTime) error
+	SQLQuery(query string, fetchSize int) (*types.SQLResponse, error)
+	PPLQuery(query string) (*types.SQLResponse, error)
+	SQLNext(cursor string, openSearch bool) (*types.SQLResponse, error)
+	SQLClose(cursor string, openSearch bool) error
 }
 
 type Client struct {
@@ -63,6 +144,10 @@ func (c *Client) ClusterHealth() (*types.ClusterHealth, error) {
 	return c.clientset.Cluster().Health()
 }
 
+func (c *Client) WaitForHealth(index, status, timeout string) (*types.ClusterHealth, error) {
+	return c.clientset.Cluster().WaitForHealth(index, status, timeout)
+}
+
 func (c *Client) ClusterInfo() (*types.ClusterInfo, error) {
 	return c.clientset.Cluster().Info()
 }
@@ -75,14 +160,40 @@ func (c *Client) ClusterState(metrics []string, indices, masterTimeout string) (
 	return c.clientset.Cluster().State(metrics, indices, masterTimeout)
 }
 
+func (c *Client) ClusterCoordinationState() (*types.ClusterState, error) {
+	return c.clientset.Cluster().CoordinationState()
+}
+
 func (c *Client) ClusterPendingTasks() (*types.ClusterPendingTasks, error) {
 	return c.clientset.Cluster().PendingTasks()
 }
 
+func (c *Client) AddVotingExclusions(nodeNames []string, timeout string) error {
+	return c.clientset.Cluster().AddVotingConfigExclusions(nodeNames, timeout)
+}
+
+func (c *Client) ClearVotingExclusions(waitForRemoval bool) error {
+	return c.clientset.Cluster().ClearVotingConfigExclusions(waitForRemoval)
+}
+
 func (c *Client) GetIndices(pattern string) ([]types.Index, error) {
 	return c.clientset.Indices().List(pattern)
 }
 
+// IndexNames returns the sorted names of indices (open or closed) matching pattern
+func IndexNames(c SearchClient, pattern string) ([]string, error) {
+	indices, err := c.GetIndices(pattern)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(indices))
+	for _, idx := range indices {
+		names = append(names, idx.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
 func (c *Client) GetIndex(name string) (*types.Index, error) {
 	return c.clientset.Indices().Get(name)
 }
@@ -95,6 +206,106 @@ func (c *Client) DeleteIndex(name string) error {
 	return c.clientset.Indices().Delete(name)
 }
 
+func (c *Client) GetIndexSettings(name string, includeDefaults, flat bool) ([]types.IndexSettings, error) {
+	return c.clientset.Indices().GetSettings(name, includeDefaults, flat)
+}
+
+func (c *Client) UpdateIndexSettings(name string, body map[string]interface{}) error {
+	return c.clientset.Indices().UpdateSettings(name, body)
+}
+
+func (c *Client) GetIndexMapping(name string) ([]types.IndexMapping, error) {
+	return c.clientset.Indices().GetMapping(name)
+}
+
+func (c *Client) PutIndexMapping(name string, body map[string]interface{}) error {
+	return c.clientset.Indices().PutMapping(name, body)
+}
+
+func (c *Client) OpenIndex(name string) (*types.IndexOperationResponse, error) {
+	return c.clientset.Indices().Open(name)
+}
+
+func (c *Client) CloseIndex(name string) (*types.IndexOperationResponse, error) {
+	return c.clientset.Indices().Close(name)
+}
+
+func (c *Client) FreezeIndex(name string) (*types.IndexOperationResponse, error) {
+	return c.clientset.Indices().Freeze(name)
+}
+
+func (c *Client) UnfreezeIndex(name string) (*types.IndexOperationResponse, error) {
+	return c.clientset.Indices().Unfreeze(name)
+}
+
+func (c *Client) AddIndexBlock(name, block string) (*types.IndexOperationResponse, error) {
+	return c.clientset.Indices().AddBlock(name, block)
+}
+
+func (c *Client) RefreshIndex(name string) (*types.IndexOperationResponse, error) {
+	return c.clientset.Indices().Refresh(name)
+}
+
+func (c *Client) FlushIndex(name string) (*types.IndexOperationResponse, error) {
+	return c.clientset.Indices().Flush(name)
+}
+
+func (c *Client) ForceMergeIndex(name string, opts types.ForceMergeOptions) (*types.IndexOperationResponse, error) {
+	return c.clientset.Indices().ForceMerge(name, opts)
+}
+
+func (c *Client) ClearIndexCache(name string, opts types.ClearCacheOptions) (*types.IndexOperationResponse, error) {
+	return c.clientset.Indices().ClearCache(name, opts)
+}
+
+func (c *Client) ShrinkIndex(source, target string, body map[string]interface{}) (*types.ResizeResponse, error) {
+	return c.clientset.Indices().Shrink(source, target, body)
+}
+
+func (c *Client) SplitIndex(source, target string, body map[string]interface{}) (*types.ResizeResponse, error) {
+	return c.clientset.Indices().Split(source, target, body)
+}
+
+func (c *Client) CloneIndex(source, target string, body map[string]interface{}) (*types.ResizeResponse, error) {
+	return c.clientset.Indices().Clone(source, target, body)
+}
+
+func (c *Client) Reindex(body map[string]interface{}, opts types.ReindexOptions) (string, error) {
+	return c.clientset.Indices().Reindex(body, opts)
+}
+
+func (c *Client) RethrottleReindex(taskID string, requestsPerSecond float64) error {
+	return c.clientset.Indices().RethrottleReindex(taskID, requestsPerSecond)
+}
+
+func (c *Client) GetRecovery(pattern string, activeOnly bool) ([]types.CatRecoveryRow, error) {
+	return c.clientset.Indices().Recovery(pattern, activeOnly)
+}
+
+func (c *Client) AnalyzeText(index string, body map[string]interface{}) (*types.AnalyzeResponse, error) {
+	return c.clientset.Indices().Analyze(index, body)
+}
+
+func (c *Client) GetFieldCaps(pattern, fields string, includeUnmapped bool) (*types.FieldCapsResponse, error) {
+	return c.clientset.Indices().FieldCaps(pattern, fields, includeUnmapped)
+}
+
+func (c *Client) GetTasks(opts types.TaskListOptions) ([]types.TaskInfo, error) {
+	return c.clientset.Tasks().List(opts)
+}
+
+func (c *Client) GetTask(taskID string) (*types.TaskResult, error) {
+	return c.clientset.Tasks().Get(taskID)
+}
+
+func (c *Client) CancelTasks(taskID, actions string) ([]types.TaskInfo, error) {
+	return c.clientset.Tasks().Cancel(taskID, actions)
+}
+
+func (c *Client) WaitForTask(taskID, timeout string) (*types.TaskResult, error) {
+	return c.clientset.Tasks().Wait(taskID, timeout)
+}
+
 func (c *Client) GetNodes() ([]types.Node, error) {
 	return c.clientset.Nodes().List()
 }
@@ -175,6 +386,10 @@ func (c *Client) GetShards(pattern string) ([]types.CatShardRow, error) {
 	return c.clientset.Cluster().CatShards(pattern)
 }
 
+func (c *Client) GetAllocation(nodes string) ([]types.CatAllocationRow, error) {
+	return c.clientset.Cluster().CatAllocation(nodes)
+}
+
 func (c *Client) ExplainAllocation(req types.AllocationExplainRequest, includeYes, includeDisk bool) (*types.AllocationExplainResponse, error) {
 	return c.clientset.Cluster().ExplainAllocation(req, includeYes, includeDisk)
 }
@@ -187,6 +402,10 @@ func (c *Client) GetClusterSettings() (*types.ClusterSettings, error) {
 	return c.clientset.Cluster().GetSettings()
 }
 
+func (c *Client) GetClusterSettingsWithDefaults() (*types.ClusterSettings, error) {
+	return c.clientset.Cluster().GetSettingsWithDefaults()
+}
+
 func (c *Client) UpdateClusterSettings(body map[string]interface{}) error {
 	return c.clientset.Cluster().UpdateSettings(body)
 }
@@ -206,3 +425,151 @@ func (c *Client) CreateIngestPipeline(name string, body map[string]interface{})
 func (c *Client) DeleteIngestPipeline(name string) error {
 	return c.clientset.Ingest().Delete(name)
 }
+
+func (c *Client) SimulateIngestPipeline(name string, pipeline map[string]interface{}, docs []interface{}, verbose bool) (*types.SimulateResponse, error) {
+	return c.clientset.Ingest().Simulate(name, pipeline, docs, verbose)
+}
+
+func (c *Client) GetAliases(pattern string) ([]types.Alias, error) {
+	return c.clientset.Aliases().List(pattern)
+}
+
+func (c *Client) GetAlias(name string) ([]types.IndexAlias, error) {
+	return c.clientset.Aliases().Get(name)
+}
+
+func (c *Client) CreateAlias(index, name string, body map[string]interface{}) error {
+	return c.clientset.Aliases().Create(index, name, body)
+}
+
+func (c *Client) DeleteAlias(index, name string) error {
+	return c.clientset.Aliases().Delete(index, name)
+}
+
+func (c *Client) UpdateAliases(actions []types.AliasAction) error {
+	return c.clientset.Aliases().Update(actions)
+}
+
+func (c *Client) GetSnapshotRepositories(pattern string) ([]types.SnapshotRepository, error) {
+	return c.clientset.Snapshots().ListRepositories(pattern)
+}
+
+func (c *Client) GetSnapshotRepository(name string) (*types.SnapshotRepository, error) {
+	return c.clientset.Snapshots().GetRepository(name)
+}
+
+func (c *Client) CreateSnapshotRepository(name string, body map[string]interface{}, verify bool) error {
+	return c.clientset.Snapshots().CreateRepository(name, body, verify)
+}
+
+func (c *Client) DeleteSnapshotRepository(name string) error {
+	return c.clientset.Snapshots().DeleteRepository(name)
+}
+
+func (c *Client) VerifySnapshotRepository(name string) ([]types.VerifiedNode, error) {
+	return c.clientset.Snapshots().VerifyRepository(name)
+}
+
+func (c *Client) GetSnapshots(repository, pattern string) ([]types.Snapshot, error) {
+	return c.clientset.Snapshots().List(repository, pattern)
+}
+
+func (c *Client) GetSnapshot(repository, name string) (*types.Snapshot, error) {
+	return c.clientset.Snapshots().Get(repository, name)
+}
+
+func (c *Client) CreateSnapshot(repository, name string, body map[string]interface{}, wait bool) (*types.Snapshot, error) {
+	return c.clientset.Snapshots().Create(repository, name, body, wait)
+}
+
+func (c *Client) DeleteSnapshot(repository, name string) error {
+	return c.clientset.Snapshots().Delete(repository, name)
+}
+
+func (c *Client) GetSnapshotStatus(repository, name string) ([]types.SnapshotStatus, error) {
+	return c.clientset.Snapshots().Status(repository, name)
+}
+
+func (c *Client) RestoreSnapshot(repository, name string, body map[string]interface{}) error {
+	return c.clientset.Snapshots().Restore(repository, name, body)
+}
+
+func (c *Client) GetSnapshotPolicies(pattern string) ([]types.SnapshotPolicy, error) {
+	return c.clientset.Snapshots().Policies().List(pattern)
+}
+
+func (c *Client) GetSnapshotPolicy(name string) (*types.SnapshotPolicy, error) {
+	return c.clientset.Snapshots().Policies().Get(name)
+}
+
+func (c *Client) CreateSnapshotPolicy(name string, body map[string]interface{}) error {
+	return c.clientset.Snapshots().Policies().Create(name, body)
+}
+
+func (c *Client) DeleteSnapshotPolicy(name string) error {
+	return c.clientset.Snapshots().Policies().Delete(name)
+}
+
+func (c *Client) ExecuteSnapshotPolicy(name string) (string, error) {
+	return c.clientset.Snapshots().Policies().Execute(name)
+}
+
+func (c *Client) GetDocument(index, id string, opts types.DocumentOptions) (*types.Document, error) {
+	return c.clientset.Documents().Get(index, id, opts)
+}
+
+func (c *Client) IndexDocument(index, id string, body map[string]interface{}, opts types.DocumentOptions) (*types.DocumentWriteResult, error) {
+	return c.clientset.Documents().Index(index, id, body, opts)
+}
+
+func (c *Client) UpdateDocument(index, id string, body map[string]interface{}, opts types.DocumentOptions) (*types.DocumentWriteResult, error) {
+	return c.clientset.Documents().Update(index, id, body, opts)
+}
+
+func (c *Client) DeleteDocument(index, id string, opts types.DocumentOptions) (*types.DocumentWriteResult, error) {
+	return c.clientset.Documents().Delete(index, id, opts)
+}
+
+func (c *Client) Bulk(index string, body []byte, opts types.DocumentOptions) (*types.BulkResponse, error) {
+	return c.clientset.Documents().Bulk(index, body, opts)
+}
+
+func (c *Client) Search(index string, body map[string]interface{}, opts types.SearchOptions) (*types.SearchResponse, error) {
+	return c.clientset.Search().Search(index, body, opts)
+}
+
+func (c *Client) Count(index string, query map[string]interface{}) (int64, error) {
+	return c.clientset.Search().Count(index, query)
+}
+
+func (c *Client) Scroll(scrollID, keepAlive string) (*types.SearchResponse, error) {
+	return c.clientset.Search().Scroll(scrollID, keepAlive)
+}
+
+func (c *Client) ClearScroll(scrollID string) error {
+	return c.clientset.Search().ClearScroll(scrollID)
+}
+
+func (c *Client) OpenPointInTime(index, keepAlive string) (*types.PointInTime, error) {
+	return c.clientset.Search().OpenPointInTime(index, keepAlive)
+}
+
+func (c *Client) ClosePointInTime(pit *types.PointInTime) error {
+	return c.clientset.Search().ClosePointInTime(pit)
+}
+
+func (c *Client) SQLQuery(query string, fetchSize int) (*types.SQLResponse, error) {
+	return c.clientset.SQL().Query(query, fetchSize)
+}
+
+func (c *Client) PPLQuery(query string) (*types.SQLResponse, error) {
+	return c.clientset.SQL().PPL(query)
+}
+
+func (c *Client) SQLNext(cursor string, openSearch bool) (*types.SQLResponse, error) {
+	return c.clientset.SQL().Next(cursor, openSearch)
+}
+
+func (c *Client) SQLClose(cursor string, openSearch bool) error {
+	return c.clientset.SQL().Close(cursor, openSearch)
+}
@@ -1,12 +1,18 @@
 package client
 
 import (
+	"github.com/chronicblondiee/searchctl/pkg/client/aliases"
 	"github.com/chronicblondiee/searchctl/pkg/client/cluster"
 	"github.com/chronicblondiee/searchctl/pkg/client/datastreams"
+	"github.com/chronicblondiee/searchctl/pkg/client/documents"
 	"github.com/chronicblondiee/searchctl/pkg/client/indices"
 	"github.com/chronicblondiee/searchctl/pkg/client/ingest"
 	"github.com/chronicblondiee/searchctl/pkg/client/nodes"
 	"github.com/chronicblondiee/searchctl/pkg/client/rest"
+	"github.com/chronicblondiee/searchctl/pkg/client/search"
+	"github.com/chronicblondiee/searchctl/pkg/client/snapshots"
+	"github.com/chronicblondiee/searchctl/pkg/client/sql"
+	"github.com/chronicblondiee/searchctl/pkg/client/tasks"
 )
 
 type Interface interface {
@@ -15,6 +21,12 @@ type Interface interface {
 	DataStreams() datastreams.Interface
 	Nodes() nodes.Interface
 	Ingest() ingest.Interface
+	Aliases() aliases.Interface
+	Tasks() tasks.Interface
+	Snapshots() snapshots.Interface
+	Documents() documents.Interface
+	Search() search.Interface
+	SQL() sql.Interface
 }
 
 type Clientset struct {
@@ -23,6 +35,12 @@ type Clientset struct {
 	dataStreamsClient datastreams.Interface
 	nodesClient       nodes.Interface
 	ingestClient      ingest.Interface
+	aliasesClient     aliases.Interface
+	tasksClient       tasks.Interface
+	snapshotsClient   snapshots.Interface
+	documentsClient   documents.Interface
+	searchClient      search.Interface
+	sqlClient         sql.Interface
 }
 
 func NewClientset() (Interface, error) {
@@ -45,6 +63,12 @@ func NewClientset() (Interface, error) {
 		dataStreamsClient: datastreams.New(restClient),
 		nodesClient:       nodes.New(restClient),
 		ingestClient:      ingest.New(restClient),
+		aliasesClient:     aliases.New(restClient),
+		tasksClient:       tasks.New(restClient),
+		snapshotsClient:   snapshots.New(restClient),
+		documentsClient:   documents.New(restClient),
+		searchClient:      search.New(restClient),
+		sqlClient:         sql.New(restClient),
 	}, nil
 }
 
@@ -67,3 +91,27 @@ func (c *Clientset) Nodes() nodes.Interface {
 func (c *Clientset) Ingest() ingest.Interface {
 	return c.ingestClient
 }
+
+func (c *Clientset) Aliases() aliases.Interface {
+	return c.aliasesClient
+}
+
+func (c *Clientset) Tasks() tasks.Interface {
+	return c.tasksClient
+}
+
+func (c *Clientset) Snapshots() snapshots.Interface {
+	return c.snapshotsClient
+}
+
+func (c *Clientset) Documents() documents.Interface {
+	return c.documentsClient
+}
+
+func (c *Clientset) Search() search.Interface {
+	return c.searchClient
+}
+
+func (c *Clientset) SQL() sql.Interface {
+	return c.sqlClient
+}
@@ -39,6 +39,40 @@ func (c *client) Health() (*types.ClusterHealth, error) {
 	return &health, nil
 }
 
+// WaitForHealth blocks server-side until index (or the whole cluster when empty) reaches status or timeout elapses
+func (c *client) WaitForHealth(index, status, timeout string) (*types.ClusterHealth, error) {
+	v := url.Values{}
+	if status != "" {
+		v.Set("wait_for_status", status)
+	}
+	if timeout != "" {
+		v.Set("timeout", timeout)
+	}
+	path := "/_cluster/health"
+	if index != "" {
+		path += "/" + index
+	}
+	if len(v) > 0 {
+		path += "?" + v.Encode()
+	}
+	resp, err := c.restClient.Get(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Some versions answer 408 when the wait times out; the body is still a health response
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusRequestTimeout {
+		return nil, fmt.Errorf("error getting cluster health: %s", string(resp.Body))
+	}
+
+	var health types.ClusterHealth
+	if err := json.Unmarshal(resp.Body, &health); err != nil {
+		return nil, err
+	}
+
+	return &health, nil
+}
+
 func (c *client) Info() (*types.ClusterInfo, error) {
 	resp, err := c.restClient.Get("/")
 	if err != nil {
@@ -77,6 +111,27 @@ func (c *client) CatShards(pattern string) ([]types.CatShardRow, error) {
 	return rows, nil
 }
 
+// CatAllocation lists disk usage and shard counts per node, with sizes in bytes
+func (c *client) CatAllocation(nodes string) ([]types.CatAllocationRow, error) {
+	nodePattern := ""
+	if nodes != "" {
+		nodePattern = "/" + nodes
+	}
+	path := fmt.Sprintf("/_cat/allocation%s?format=json&bytes=b&h=shards,disk.indices,disk.used,disk.avail,disk.total,disk.percent,host,ip,node", nodePattern)
+	resp, err := c.restClient.Get(path)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error getting allocation: %s", string(resp.Body))
+	}
+	var rows []types.CatAllocationRow
+	if err := json.Unmarshal(resp.Body, &rows); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
 func (c *client) ExplainAllocation(req types.AllocationExplainRequest, includeYes, includeDisk bool) (*types.AllocationExplainResponse, error) {
 	v := url.Values{}
 	if includeYes {
@@ -134,7 +189,16 @@ func (c *client) Reroute(commands []types.RerouteCommand, opts types.RerouteOpti
 }
 
 func (c *client) GetSettings() (*types.ClusterSettings, error) {
-	resp, err := c.restClient.Get("/_cluster/settings")
+	return c.getSettings("/_cluster/settings")
+}
+
+// GetSettingsWithDefaults also returns the default values of settings that are not set
+func (c *client) GetSettingsWithDefaults() (*types.ClusterSettings, error) {
+	return c.getSettings("/_cluster/settings?include_defaults=true")
+}
+
+func (c *client) getSettings(path string) (*types.ClusterSettings, error) {
+	resp, err := c.restClient.Get(path)
 	if err != nil {
 		return nil, err
 	}
@@ -190,6 +254,16 @@ func (c *client) State(metrics []string, indices string, masterTimeout string) (
 	if len(v) > 0 {
 		path += "?" + v.Encode()
 	}
+	return c.getState(path)
+}
+
+// CoordinationState returns only the nodes and the cluster_coordination metadata, so that
+// checking the voting configuration does not fetch the metadata of every index
+func (c *client) CoordinationState() (*types.ClusterState, error) {
+	return c.getState("/_cluster/state/metadata,nodes?filter_path=metadata.cluster_coordination,nodes")
+}
+
+func (c *client) getState(path string) (*types.ClusterState, error) {
 	resp, err := c.restClient.Get(path)
 	if err != nil {
 		return nil, err
@@ -218,3 +292,34 @@ func (c *client) PendingTasks() (*types.ClusterPendingTasks, error) {
 	}
 	return &out, nil
 }
+
+func (c *client) AddVotingConfigExclusions(nodeNames []string, timeout string) error {
+	v := url.Values{}
+	v.Set("node_names", strings.Join(nodeNames, ","))
+	if timeout != "" {
+		v.Set("timeout", timeout)
+	}
+	resp, err := c.restClient.Post("/_cluster/voting_config_exclusions?"+v.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("error adding voting config exclusions: %s", string(resp.Body))
+	}
+	return nil
+}
+
+func (c *client) ClearVotingConfigExclusions(waitForRemoval bool) error {
+	path := "/_cluster/voting_config_exclusions"
+	if !waitForRemoval {
+		path += "?wait_for_removal=false"
+	}
+	resp, err := c.restClient.Delete(path)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("error clearing voting config exclusions: %s", string(resp.Body))
+	}
+	return nil
+}
@@ -4,15 +4,21 @@ import "github.com/chronicblondiee/searchctl/pkg/types"
 
 type Interface interface {
 	Health() (*types.ClusterHealth, error)
+	WaitForHealth(index, status, timeout string) (*types.ClusterHealth, error)
 	Info() (*types.ClusterInfo, error)
 	CatShards(pattern string) ([]types.CatShardRow, error)
+	CatAllocation(nodes string) ([]types.CatAllocationRow, error)
 	ExplainAllocation(req types.AllocationExplainRequest, includeYes, includeDisk bool) (*types.AllocationExplainResponse, error)
 	Reroute(commands []types.RerouteCommand, opts types.RerouteOptions) (*types.RerouteResponse, error)
 	GetSettings() (*types.ClusterSettings, error)
+	GetSettingsWithDefaults() (*types.ClusterSettings, error)
 	UpdateSettings(body map[string]interface{}) error
 
 	// New operations
 	Stats() (*types.ClusterStats, error)
 	State(metrics []string, indices string, masterTimeout string) (*types.ClusterState, error)
+	CoordinationState() (*types.ClusterState, error)
 	PendingTasks() (*types.ClusterPendingTasks, error)
+	AddVotingConfigExclusions(nodeNames []string, timeout string) error
+	ClearVotingConfigExclusions(waitForRemoval bool) error
 }
@@ -0,0 +1,288 @@
+package documents
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/chronicblondiee/searchctl/pkg/client/rest"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+)
+
+// ErrTooManyRequests is returned when the cluster rejects a bulk request with 429,
+// which callers should treat as a signal to back off and retry
+var ErrTooManyRequests = errors.New("too many requests")
+
+type client struct {
+	restClient *rest.Client
+}
+
+func New(restClient *rest.Client) Interface {
+	return &client{restClient: restClient}
+}
+
+func query(opts types.DocumentOptions) url.Values {
+	v := url.Values{}
+	if opts.Routing != "" {
+		v.Set("routing", opts.Routing)
+	}
+	if opts.Refresh != "" {
+		v.Set("refresh", opts.Refresh)
+	}
+	if opts.Pipeline != "" {
+		v.Set("pipeline", opts.Pipeline)
+	}
+	if opts.RetryOnConflict > 0 {
+		v.Set("retry_on_conflict", strconv.Itoa(opts.RetryOnConflict))
+	}
+	if opts.SourceIncludes != "" {
+		v.Set("_source_includes", opts.SourceIncludes)
+	}
+	if opts.SourceExcludes != "" {
+		v.Set("_source_excludes", opts.SourceExcludes)
+	}
+	return v
+}
+
+func withQuery(path string, v url.Values) string {
+	if len(v) == 0 {
+		return path
+	}
+	return path + "?" + v.Encode()
+}
+
+// backingIndices returns the backing indices of data stream name, oldest first, or nil
+// when name is not a data stream. Clusters without data stream support answer with an
+// error, which is treated as "not a data stream".
+func (c *client) backingIndices(name string) ([]string, error) {
+	resp, err := c.restClient.Get(fmt.Sprintf("/_data_stream/%s", url.PathEscape(name)))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil
+	}
+	var body struct {
+		DataStreams []types.DataStream `json:"data_streams"`
+	}
+	if err := json.Unmarshal(resp.Body, &body); err != nil {
+		return nil, err
+	}
+	for _, ds := range body.DataStreams {
+		if ds.Name == name {
+			indices := make([]string, len(ds.Indices))
+			for i, idx := range ds.Indices {
+				indices[i] = idx.IndexName
+			}
+			return indices, nil
+		}
+	}
+	return nil, nil
+}
+
+// resolve returns the index that holds document id. Documents in a data stream can only be
+// read, updated or deleted by id through their backing index. Backing indices are tried
+// newest first with realtime GETs, so a document is found before the next refresh.
+func (c *client) resolve(index, id, routing string) (string, error) {
+	indices, err := c.backingIndices(index)
+	if err != nil || len(indices) == 0 {
+		return index, err
+	}
+
+	v := url.Values{}
+	v.Set("_source", "false")
+	if routing != "" {
+		v.Set("routing", routing)
+	}
+	for i := len(indices) - 1; i >= 0; i-- {
+		resp, err := c.restClient.Get(withQuery(fmt.Sprintf("/%s/_doc/%s", indices[i], url.PathEscape(id)), v))
+		if err != nil {
+			return "", err
+		}
+		if resp.StatusCode == http.StatusNotFound {
+			continue
+		}
+		if resp.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("error resolving backing index: %s", string(resp.Body))
+		}
+		return indices[i], nil
+	}
+	return "", fmt.Errorf("document %q not found in data stream %q", id, index)
+}
+
+func (c *client) Get(index, id string, opts types.DocumentOptions) (*types.Document, error) {
+	target, err := c.resolve(index, id, opts.Routing)
+	if err != nil {
+		return nil, err
+	}
+
+	v := query(types.DocumentOptions{
+		Routing:        opts.Routing,
+		SourceIncludes: opts.SourceIncludes,
+		SourceExcludes: opts.SourceExcludes,
+	})
+	resp, err := c.restClient.Get(withQuery(fmt.Sprintf("/%s/_doc/%s", target, url.PathEscape(id)), v))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return nil, fmt.Errorf("error getting document: %s", string(resp.Body))
+	}
+
+	var doc types.Document
+	if err := json.Unmarshal(resp.Body, &doc); err != nil {
+		return nil, err
+	}
+	if !doc.Found {
+		if doc.ID == "" {
+			// A 404 without a document body means the index itself is missing
+			return nil, fmt.Errorf("error getting document: %s", string(resp.Body))
+		}
+		return nil, fmt.Errorf("document %q not found in %q", id, index)
+	}
+	return &doc, nil
+}
+
+// Index writes a document. Data streams are append-only, so documents are always created
+// with op_type=create there; an explicit op_type=index is rejected.
+func (c *client) Index(index, id string, body map[string]interface{}, opts types.DocumentOptions) (*types.DocumentWriteResult, error) {
+	indices, err := c.backingIndices(index)
+	if err != nil {
+		return nil, err
+	}
+	opType := opts.OpType
+	if len(indices) > 0 {
+		if opType == "index" {
+			return nil, fmt.Errorf("%q is a data stream and only accepts op_type=create", index)
+		}
+		opType = "create"
+	}
+
+	v := query(types.DocumentOptions{
+		Routing:  opts.Routing,
+		Refresh:  opts.Refresh,
+		Pipeline: opts.Pipeline,
+	})
+
+	var resp *rest.Response
+	switch {
+	case id == "":
+		if opType == "create" {
+			v.Set("op_type", "create")
+		}
+		resp, err = c.restClient.Post(withQuery(fmt.Sprintf("/%s/_doc", index), v), body)
+	case opType == "create":
+		resp, err = c.restClient.Put(withQuery(fmt.Sprintf("/%s/_create/%s", index, url.PathEscape(id)), v), body)
+	default:
+		resp, err = c.restClient.Put(withQuery(fmt.Sprintf("/%s/_doc/%s", index, url.PathEscape(id)), v), body)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusConflict {
+		return nil, fmt.Errorf("document %q already exists in %q", id, index)
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("error indexing document: %s", string(resp.Body))
+	}
+	return decodeResult(resp)
+}
+
+func (c *client) Update(index, id string, body map[string]interface{}, opts types.DocumentOptions) (*types.DocumentWriteResult, error) {
+	target, err := c.resolve(index, id, opts.Routing)
+	if err != nil {
+		return nil, err
+	}
+
+	v := query(types.DocumentOptions{
+		Routing:         opts.Routing,
+		Refresh:         opts.Refresh,
+		RetryOnConflict: opts.RetryOnConflict,
+	})
+	resp, err := c.restClient.Post(withQuery(fmt.Sprintf("/%s/_update/%s", target, url.PathEscape(id)), v), body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("document %q not found in %q", id, index)
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("error updating document: %s", string(resp.Body))
+	}
+	return decodeResult(resp)
+}
+
+func (c *client) Delete(index, id string, opts types.DocumentOptions) (*types.DocumentWriteResult, error) {
+	target, err := c.resolve(index, id, opts.Routing)
+	if err != nil {
+		return nil, err
+	}
+
+	v := query(types.DocumentOptions{
+		Routing: opts.Routing,
+		Refresh: opts.Refresh,
+	})
+	resp, err := c.restClient.Delete(withQuery(fmt.Sprintf("/%s/_doc/%s", target, url.PathEscape(id)), v))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		var result types.DocumentWriteResult
+		if json.Unmarshal(resp.Body, &result) == nil && result.Result == "not_found" {
+			return nil, fmt.Errorf("document %q not found in %q", id, index)
+		}
+		return nil, fmt.Errorf("error deleting document: %s", string(resp.Body))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error deleting document: %s", string(resp.Body))
+	}
+	return decodeResult(resp)
+}
+
+func decodeResult(resp *rest.Response) (*types.DocumentWriteResult, error) {
+	var result types.DocumentWriteResult
+	if err := json.Unmarshal(resp.Body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Bulk sends an NDJSON body to INDEX/_bulk. Per-item failures are reported in the response
+// rather than as an error.
+func (c *client) Bulk(index string, body []byte, opts types.DocumentOptions) (*types.BulkResponse, error) {
+	v := query(types.DocumentOptions{
+		Routing:  opts.Routing,
+		Refresh:  opts.Refresh,
+		Pipeline: opts.Pipeline,
+	})
+	resp, err := c.restClient.PostRaw(withQuery(fmt.Sprintf("/%s/_bulk", index), v), body, "application/x-ndjson")
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, ErrTooManyRequests
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error sending bulk request: %s", string(resp.Body))
+	}
+
+	var raw struct {
+		Took   int64                       `json:"took"`
+		Errors bool                        `json:"errors"`
+		Items  []map[string]types.BulkItem `json:"items"`
+	}
+	if err := json.Unmarshal(resp.Body, &raw); err != nil {
+		return nil, err
+	}
+	result := &types.BulkResponse{Took: raw.Took, Errors: raw.Errors, Items: make([]types.BulkItem, 0, len(raw.Items))}
+	for _, item := range raw.Items {
+		for action, r := range item {
+			r.Action = action
+			result.Items = append(result.Items, r)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,11 @@
+package documents
+
+import "github.com/chronicblondiee/searchctl/pkg/types"
+
+type Interface interface {
+	Get(index, id string, opts types.DocumentOptions) (*types.Document, error)
+	Index(index, id string, body map[string]interface{}, opts types.DocumentOptions) (*types.DocumentWriteResult, error)
+	Update(index, id string, body map[string]interface{}, opts types.DocumentOptions) (*types.DocumentWriteResult, error)
+	Delete(index, id string, opts types.DocumentOptions) (*types.DocumentWriteResult, error)
+	Bulk(index string, body []byte, opts types.DocumentOptions) (*types.BulkResponse, error)
+}
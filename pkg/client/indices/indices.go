@@ -4,6 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/chronicblondiee/searchctl/pkg/client/rest"
@@ -99,6 +102,335 @@ func (c *client) Delete(name string) error {
 	return nil
 }
 
+func (c *client) GetSettings(name string, includeDefaults, flat bool) ([]types.IndexSettings, error) {
+	v := url.Values{}
+	if includeDefaults {
+		v.Set("include_defaults", "true")
+	}
+	if flat {
+		v.Set("flat_settings", "true")
+	}
+	path := fmt.Sprintf("/%s/_settings", name)
+	if len(v) > 0 {
+		path += "?" + v.Encode()
+	}
+	resp, err := c.restClient.Get(path)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error getting index settings: %s", string(resp.Body))
+	}
+
+	var response map[string]struct {
+		Settings map[string]interface{} `json:"settings"`
+		Defaults map[string]interface{} `json:"defaults"`
+	}
+	if err := json.Unmarshal(resp.Body, &response); err != nil {
+		return nil, err
+	}
+
+	out := make([]types.IndexSettings, 0, len(response))
+	for index, s := range response {
+		out = append(out, types.IndexSettings{Index: index, Settings: s.Settings, Defaults: s.Defaults})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Index < out[j].Index })
+	return out, nil
+}
+
+func (c *client) UpdateSettings(name string, body map[string]interface{}) error {
+	path := fmt.Sprintf("/%s/_settings", name)
+	resp, err := c.restClient.Put(path, body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("error updating index settings: %s", string(resp.Body))
+	}
+	return nil
+}
+
+func (c *client) GetMapping(name string) ([]types.IndexMapping, error) {
+	path := fmt.Sprintf("/%s/_mapping", name)
+	resp, err := c.restClient.Get(path)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error getting index mapping: %s", string(resp.Body))
+	}
+
+	var response map[string]struct {
+		Mappings map[string]interface{} `json:"mappings"`
+	}
+	if err := json.Unmarshal(resp.Body, &response); err != nil {
+		return nil, err
+	}
+
+	out := make([]types.IndexMapping, 0, len(response))
+	for index, m := range response {
+		out = append(out, types.IndexMapping{Index: index, Mappings: m.Mappings})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Index < out[j].Index })
+	return out, nil
+}
+
+func (c *client) PutMapping(name string, body map[string]interface{}) error {
+	path := fmt.Sprintf("/%s/_mapping", name)
+	resp, err := c.restClient.Put(path, body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("error updating index mapping: %s", string(resp.Body))
+	}
+	return nil
+}
+
+func (c *client) Open(name string) (*types.IndexOperationResponse, error) {
+	return c.indexOperation(fmt.Sprintf("/%s/_open", name), "opening index")
+}
+
+func (c *client) Close(name string) (*types.IndexOperationResponse, error) {
+	return c.indexOperation(fmt.Sprintf("/%s/_close", name), "closing index")
+}
+
+// Freeze is only available on Elasticsearch 7.x; newer versions and OpenSearch return an error
+func (c *client) Freeze(name string) (*types.IndexOperationResponse, error) {
+	return c.indexOperation(fmt.Sprintf("/%s/_freeze", name), "freezing index")
+}
+
+func (c *client) Unfreeze(name string) (*types.IndexOperationResponse, error) {
+	return c.indexOperation(fmt.Sprintf("/%s/_unfreeze", name), "unfreezing index")
+}
+
+func (c *client) AddBlock(name, block string) (*types.IndexOperationResponse, error) {
+	path := fmt.Sprintf("/%s/_block/%s", name, block)
+	resp, err := c.restClient.Put(path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error adding index block: %s", string(resp.Body))
+	}
+	var out types.IndexOperationResponse
+	if err := json.Unmarshal(resp.Body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *client) Refresh(name string) (*types.IndexOperationResponse, error) {
+	return c.indexOperation(fmt.Sprintf("/%s/_refresh", name), "refreshing index")
+}
+
+func (c *client) Flush(name string) (*types.IndexOperationResponse, error) {
+	return c.indexOperation(fmt.Sprintf("/%s/_flush", name), "flushing index")
+}
+
+func (c *client) ForceMerge(name string, opts types.ForceMergeOptions) (*types.IndexOperationResponse, error) {
+	v := url.Values{}
+	if opts.MaxNumSegments > 0 {
+		v.Set("max_num_segments", fmt.Sprintf("%d", opts.MaxNumSegments))
+	}
+	if opts.OnlyExpungeDeletes {
+		v.Set("only_expunge_deletes", "true")
+	}
+	if opts.Flush != nil {
+		v.Set("flush", fmt.Sprintf("%t", *opts.Flush))
+	}
+	path := fmt.Sprintf("/%s/_forcemerge", name)
+	if len(v) > 0 {
+		path += "?" + v.Encode()
+	}
+	return c.indexOperation(path, "force merging index")
+}
+
+func (c *client) ClearCache(name string, opts types.ClearCacheOptions) (*types.IndexOperationResponse, error) {
+	v := url.Values{}
+	if opts.Query {
+		v.Set("query", "true")
+	}
+	if opts.Fielddata {
+		v.Set("fielddata", "true")
+	}
+	if opts.Request {
+		v.Set("request", "true")
+	}
+	if len(opts.Fields) > 0 {
+		v.Set("fields", strings.Join(opts.Fields, ","))
+	}
+	path := fmt.Sprintf("/%s/_cache/clear", name)
+	if len(v) > 0 {
+		path += "?" + v.Encode()
+	}
+	return c.indexOperation(path, "clearing index cache")
+}
+
+func (c *client) Shrink(source, target string, body map[string]interface{}) (*types.ResizeResponse, error) {
+	return c.resize("_shrink", source, target, body)
+}
+
+func (c *client) Split(source, target string, body map[string]interface{}) (*types.ResizeResponse, error) {
+	return c.resize("_split", source, target, body)
+}
+
+func (c *client) Clone(source, target string, body map[string]interface{}) (*types.ResizeResponse, error) {
+	return c.resize("_clone", source, target, body)
+}
+
+func (c *client) resize(op, source, target string, body map[string]interface{}) (*types.ResizeResponse, error) {
+	path := fmt.Sprintf("/%s/%s/%s", source, op, target)
+	resp, err := c.restClient.Post(path, body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error resizing index (%s): %s", strings.TrimPrefix(op, "_"), string(resp.Body))
+	}
+	var out types.ResizeResponse
+	if err := json.Unmarshal(resp.Body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Reindex starts a reindex without waiting for completion and returns the task ID to follow
+func (c *client) Reindex(body map[string]interface{}, opts types.ReindexOptions) (string, error) {
+	v := url.Values{}
+	v.Set("wait_for_completion", "false")
+	if opts.Slices != "" {
+		v.Set("slices", opts.Slices)
+	}
+	if opts.RequestsPerSecond != 0 {
+		v.Set("requests_per_second", strconv.FormatFloat(opts.RequestsPerSecond, 'f', -1, 64))
+	}
+	if opts.Refresh {
+		v.Set("refresh", "true")
+	}
+	resp, err := c.restClient.Post("/_reindex?"+v.Encode(), body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("error starting reindex: %s", string(resp.Body))
+	}
+	var out struct {
+		Task string `json:"task"`
+	}
+	if err := json.Unmarshal(resp.Body, &out); err != nil {
+		return "", err
+	}
+	if out.Task == "" {
+		return "", fmt.Errorf("error starting reindex: no task id in response")
+	}
+	return out.Task, nil
+}
+
+// RethrottleReindex changes requests_per_second of a running reindex; -1 removes the throttle
+func (c *client) RethrottleReindex(taskID string, requestsPerSecond float64) error {
+	path := fmt.Sprintf("/_reindex/%s/_rethrottle?requests_per_second=%s", taskID, strconv.FormatFloat(requestsPerSecond, 'f', -1, 64))
+	resp, err := c.restClient.Post(path, nil)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("error rethrottling reindex: %s", string(resp.Body))
+	}
+	return nil
+}
+
+func (c *client) Recovery(pattern string, activeOnly bool) ([]types.CatRecoveryRow, error) {
+	path := "/_cat/recovery"
+	if pattern != "" {
+		path += "/" + pattern
+	}
+	path += "?format=json&bytes=b&h=index,shard,type,stage,source_node,target_node,repository,snapshot,files_percent,bytes_percent,bytes_total,time"
+	if activeOnly {
+		path += "&active_only=true"
+	}
+	resp, err := c.restClient.Get(path)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return []types.CatRecoveryRow{}, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error getting recovery status: %s", string(resp.Body))
+	}
+	var rows []types.CatRecoveryRow
+	if err := json.Unmarshal(resp.Body, &rows); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// Analyze runs the analyze API against an index, which makes its custom analyzers and
+// field mappings available, or against the cluster when index is empty
+func (c *client) Analyze(index string, body map[string]interface{}) (*types.AnalyzeResponse, error) {
+	path := "/_analyze"
+	if index != "" {
+		path = fmt.Sprintf("/%s/_analyze", index)
+	}
+	resp, err := c.restClient.Post(path, body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("index %q not found", index)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error analyzing text: %s", string(resp.Body))
+	}
+
+	var result types.AnalyzeResponse
+	if err := json.Unmarshal(resp.Body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *client) FieldCaps(pattern, fields string, includeUnmapped bool) (*types.FieldCapsResponse, error) {
+	v := url.Values{}
+	v.Set("fields", fields)
+	if includeUnmapped {
+		v.Set("include_unmapped", "true")
+	}
+	resp, err := c.restClient.Get(fmt.Sprintf("/%s/_field_caps?%s", pattern, v.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("index %q not found", pattern)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error getting field capabilities: %s", string(resp.Body))
+	}
+
+	var result types.FieldCapsResponse
+	if err := json.Unmarshal(resp.Body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// indexOperation issues a body-less POST and decodes the acknowledged/_shards response
+func (c *client) indexOperation(path, action string) (*types.IndexOperationResponse, error) {
+	resp, err := c.restClient.Post(path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error %s: %s", action, string(resp.Body))
+	}
+	var out types.IndexOperationResponse
+	if err := json.Unmarshal(resp.Body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *client) Templates() TemplatesInterface {
 	return &templatesClient{restClient: c.restClient}
 }
@@ -7,6 +7,27 @@ type Interface interface {
 	Get(name string) (*types.Index, error)
 	Create(name string, body map[string]interface{}) error
 	Delete(name string) error
+	GetSettings(name string, includeDefaults, flat bool) ([]types.IndexSettings, error)
+	UpdateSettings(name string, body map[string]interface{}) error
+	GetMapping(name string) ([]types.IndexMapping, error)
+	PutMapping(name string, body map[string]interface{}) error
+	Open(name string) (*types.IndexOperationResponse, error)
+	Close(name string) (*types.IndexOperationResponse, error)
+	Freeze(name string) (*types.IndexOperationResponse, error)
+	Unfreeze(name string) (*types.IndexOperationResponse, error)
+	AddBlock(name, block string) (*types.IndexOperationResponse, error)
+	Refresh(name string) (*types.IndexOperationResponse, error)
+	Flush(name string) (*types.IndexOperationResponse, error)
+	ForceMerge(name string, opts types.ForceMergeOptions) (*types.IndexOperationResponse, error)
+	ClearCache(name string, opts types.ClearCacheOptions) (*types.IndexOperationResponse, error)
+	Shrink(source, target string, body map[string]interface{}) (*types.ResizeResponse, error)
+	Split(source, target string, body map[string]interface{}) (*types.ResizeResponse, error)
+	Clone(source, target string, body map[string]interface{}) (*types.ResizeResponse, error)
+	Reindex(body map[string]interface{}, opts types.ReindexOptions) (string, error)
+	RethrottleReindex(taskID string, requestsPerSecond float64) error
+	Recovery(pattern string, activeOnly bool) ([]types.CatRecoveryRow, error)
+	Analyze(index string, body map[string]interface{}) (*types.AnalyzeResponse, error)
+	FieldCaps(pattern, fields string, includeUnmapped bool) (*types.FieldCapsResponse, error)
 	Templates() TemplatesInterface
 	ComponentTemplates() ComponentTemplatesInterface
 	LifecyclePolicies() LifecyclePoliciesInterface
@@ -1,6 +1,7 @@
 package ingest
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -90,3 +91,37 @@ func (c *client) Delete(name string) error {
 	}
 	return nil
 }
+
+// Simulate runs docs through the stored pipeline name, or through the pipeline
+// definition when one is given
+func (c *client) Simulate(name string, pipeline map[string]interface{}, docs []interface{}, verbose bool) (*types.SimulateResponse, error) {
+	path := "/_ingest/pipeline/_simulate"
+	body := map[string]interface{}{"docs": docs}
+	if pipeline != nil {
+		body["pipeline"] = pipeline
+	} else {
+		path = fmt.Sprintf("/_ingest/pipeline/%s/_simulate", name)
+	}
+	if verbose {
+		path += "?verbose=true"
+	}
+
+	resp, err := c.restClient.Post(path, body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("ingest pipeline %q not found", name)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error simulating ingest pipeline: %s", string(resp.Body))
+	}
+
+	var result types.SimulateResponse
+	dec := json.NewDecoder(bytes.NewReader(resp.Body))
+	dec.UseNumber()
+	if err := dec.Decode(&result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -7,4 +7,5 @@ type Interface interface {
 	Get(name string) (*types.IngestPipeline, error)
 	Create(name string, body map[string]interface{}) error
 	Delete(name string) error
+	Simulate(name string, pipeline map[string]interface{}, docs []interface{}, verbose bool) (*types.SimulateResponse, error)
 }
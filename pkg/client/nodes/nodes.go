@@ -20,7 +20,7 @@ func New(restClient *rest.Client) Interface {
 }
 
 func (c *client) List() ([]types.Node, error) {
-	resp, err := c.restClient.Get("/_cat/nodes?format=json&h=name,host,ip,heap.percent,ram.percent,cpu,load_1m,load_5m,load_15m,node.role,master")
+	resp, err := c.restClient.Get("/_cat/nodes?format=json&h=name,host,ip,heap.percent,ram.percent,cpu,load_1m,load_5m,load_15m,node.role,master,uptime")
 	if err != nil {
 		return nil, err
 	}
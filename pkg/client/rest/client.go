@@ -37,6 +37,9 @@ type Request struct {
 	Method string
 	Path   string
 	Body   interface{}
+	// RawBody is sent as-is instead of JSON-encoding Body, e.g. for NDJSON bulk requests
+	RawBody     []byte
+	ContentType string
 }
 
 type Response struct {
@@ -49,7 +52,14 @@ func (c *Client) Do(req *Request) (*Response, error) {
 
 	var reqBody io.Reader
 	var hasBody bool
-	if req.Body != nil {
+	contentType := "application/json"
+	if req.RawBody != nil {
+		reqBody = bytes.NewReader(req.RawBody)
+		hasBody = true
+		if req.ContentType != "" {
+			contentType = req.ContentType
+		}
+	} else if req.Body != nil {
 		// Check if it's a nil map (which would marshal to "null")
 		if m, ok := req.Body.(map[string]interface{}); ok && m == nil {
 			// Don't send anything for nil maps
@@ -71,7 +81,7 @@ func (c *Client) Do(req *Request) (*Response, error) {
 
 	// Only set Content-Type when there's actually a body to send
 	if hasBody {
-		httpReq.Header.Set("Content-Type", "application/json")
+		httpReq.Header.Set("Content-Type", contentType)
 	}
 
 	if c.apiKey != "" {
@@ -112,6 +122,16 @@ func (c *Client) Post(path string, body interface{}) (*Response, error) {
 	})
 }
 
+// PostRaw sends body unmodified with the given content type
+func (c *Client) PostRaw(path string, body []byte, contentType string) (*Response, error) {
+	return c.Do(&Request{
+		Method:      "POST",
+		Path:        path,
+		RawBody:     body,
+		ContentType: contentType,
+	})
+}
+
 func (c *Client) Put(path string, body interface{}) (*Response, error) {
 	return c.Do(&Request{
 		Method: "PUT",
@@ -0,0 +1,12 @@
+package search
+
+import "github.com/chronicblondiee/searchctl/pkg/types"
+
+type Interface interface {
+	Search(index string, body map[string]interface{}, opts types.SearchOptions) (*types.SearchResponse, error)
+	Count(index string, query map[string]interface{}) (int64, error)
+	Scroll(scrollID, keepAlive string) (*types.SearchResponse, error)
+	ClearScroll(scrollID string) error
+	OpenPointInTime(index, keepAlive string) (*types.PointInTime, error)
+	ClosePointInTime(pit *types.PointInTime) error
+}
@@ -0,0 +1,205 @@
+package search
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/chronicblondiee/searchctl/pkg/client/rest"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+)
+
+// ErrSearchContextMissing is returned when a scroll or point in time has expired
+var ErrSearchContextMissing = errors.New("search context expired")
+
+// ErrPointInTimeUnsupported is returned by clusters that predate point in time searches
+var ErrPointInTimeUnsupported = errors.New("point in time is not supported by this cluster")
+
+type client struct {
+	restClient *rest.Client
+}
+
+func New(restClient *rest.Client) Interface {
+	return &client{restClient: restClient}
+}
+
+func contextMissing(resp *rest.Response) bool {
+	body := strings.ToLower(string(resp.Body))
+	return resp.StatusCode == http.StatusNotFound &&
+		(strings.Contains(body, "search_context_missing") || strings.Contains(body, "no search context found"))
+}
+
+func unsupported(resp *rest.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusBadRequest, http.StatusNotFound, http.StatusMethodNotAllowed:
+		return strings.Contains(string(resp.Body), "no handler found") || strings.Contains(string(resp.Body), "invalid_type_name")
+	}
+	return false
+}
+
+// decode uses json.Number so sort values such as _shard_doc survive being sent back in search_after
+func decode(resp *rest.Response) (*types.SearchResponse, error) {
+	var result types.SearchResponse
+	dec := json.NewDecoder(bytes.NewReader(resp.Body))
+	dec.UseNumber()
+	if err := dec.Decode(&result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *client) Search(index string, body map[string]interface{}, opts types.SearchOptions) (*types.SearchResponse, error) {
+	v := url.Values{}
+	if opts.Scroll != "" {
+		v.Set("scroll", opts.Scroll)
+	}
+	if opts.Routing != "" {
+		v.Set("routing", opts.Routing)
+	}
+
+	// Searches against a point in time must not name an index
+	path := "/_search"
+	if index != "" {
+		path = fmt.Sprintf("/%s/_search", index)
+	}
+	if len(v) > 0 {
+		path += "?" + v.Encode()
+	}
+
+	resp, err := c.restClient.Post(path, body)
+	if err != nil {
+		return nil, err
+	}
+	if contextMissing(resp) {
+		return nil, ErrSearchContextMissing
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("index %q not found", index)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error searching: %s", string(resp.Body))
+	}
+	return decode(resp)
+}
+
+func (c *client) Count(index string, query map[string]interface{}) (int64, error) {
+	var body map[string]interface{}
+	if query != nil {
+		body = map[string]interface{}{"query": query}
+	}
+	resp, err := c.restClient.Post(fmt.Sprintf("/%s/_count", index), body)
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return 0, fmt.Errorf("index %q not found", index)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("error counting documents: %s", string(resp.Body))
+	}
+	var result struct {
+		Count int64 `json:"count"`
+	}
+	if err := json.Unmarshal(resp.Body, &result); err != nil {
+		return 0, err
+	}
+	return result.Count, nil
+}
+
+func (c *client) Scroll(scrollID, keepAlive string) (*types.SearchResponse, error) {
+	resp, err := c.restClient.Post("/_search/scroll", map[string]interface{}{
+		"scroll":    keepAlive,
+		"scroll_id": scrollID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if contextMissing(resp) {
+		return nil, ErrSearchContextMissing
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error scrolling: %s", string(resp.Body))
+	}
+	return decode(resp)
+}
+
+func (c *client) ClearScroll(scrollID string) error {
+	resp, err := c.restClient.Do(&rest.Request{
+		Method: http.MethodDelete,
+		Path:   "/_search/scroll",
+		Body:   map[string]interface{}{"scroll_id": scrollID},
+	})
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("error clearing scroll: %s", string(resp.Body))
+	}
+	return nil
+}
+
+// OpenPointInTime opens a point in time with the Elasticsearch _pit API (7.10+), falling back
+// to the OpenSearch point_in_time API (2.4+)
+func (c *client) OpenPointInTime(index, keepAlive string) (*types.PointInTime, error) {
+	v := url.Values{}
+	v.Set("keep_alive", keepAlive)
+
+	resp, err := c.restClient.Post(fmt.Sprintf("/%s/_pit?%s", index, v.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusOK {
+		var body struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(resp.Body, &body); err != nil {
+			return nil, err
+		}
+		return &types.PointInTime{ID: body.ID}, nil
+	}
+	if !unsupported(resp) {
+		return nil, fmt.Errorf("error opening point in time: %s", string(resp.Body))
+	}
+
+	resp, err = c.restClient.Post(fmt.Sprintf("/%s/_search/point_in_time?%s", index, v.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+	if unsupported(resp) {
+		return nil, ErrPointInTimeUnsupported
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error opening point in time: %s", string(resp.Body))
+	}
+	var body struct {
+		PitID string `json:"pit_id"`
+	}
+	if err := json.Unmarshal(resp.Body, &body); err != nil {
+		return nil, err
+	}
+	return &types.PointInTime{ID: body.PitID, OpenSearch: true}, nil
+}
+
+func (c *client) ClosePointInTime(pit *types.PointInTime) error {
+	req := &rest.Request{
+		Method: http.MethodDelete,
+		Path:   "/_pit",
+		Body:   map[string]interface{}{"id": pit.ID},
+	}
+	if pit.OpenSearch {
+		req.Path = "/_search/point_in_time"
+		req.Body = map[string]interface{}{"pit_id": []string{pit.ID}}
+	}
+	resp, err := c.restClient.Do(req)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("error closing point in time: %s", string(resp.Body))
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package snapshots
+
+import "github.com/chronicblondiee/searchctl/pkg/types"
+
+type Interface interface {
+	ListRepositories(pattern string) ([]types.SnapshotRepository, error)
+	GetRepository(name string) (*types.SnapshotRepository, error)
+	CreateRepository(name string, body map[string]interface{}, verify bool) error
+	DeleteRepository(name string) error
+	VerifyRepository(name string) ([]types.VerifiedNode, error)
+	List(repository, pattern string) ([]types.Snapshot, error)
+	Get(repository, name string) (*types.Snapshot, error)
+	Create(repository, name string, body map[string]interface{}, wait bool) (*types.Snapshot, error)
+	Delete(repository, name string) error
+	Status(repository, name string) ([]types.SnapshotStatus, error)
+	Restore(repository, name string, body map[string]interface{}) error
+	Policies() PoliciesInterface
+}
+
+type PoliciesInterface interface {
+	List(pattern string) ([]types.SnapshotPolicy, error)
+	Get(name string) (*types.SnapshotPolicy, error)
+	Create(name string, body map[string]interface{}) error
+	Delete(name string) error
+	Execute(name string) (string, error)
+}
@@ -0,0 +1,304 @@
+package snapshots
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/chronicblondiee/searchctl/pkg/client/rest"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+)
+
+type policiesClient struct {
+	restClient *rest.Client
+}
+
+func (c *client) Policies() PoliciesInterface {
+	return &policiesClient{restClient: c.restClient}
+}
+
+// Fields OpenSearch maintains itself and rejects or ignores when a policy is written back
+var smServerFields = []string{"name", "schema_version", "last_updated_time", "enabled_time", "schedule"}
+
+func (c *policiesClient) send(method, p string) (*rest.Response, error) {
+	if method == http.MethodDelete {
+		return c.restClient.Delete(p)
+	}
+	return c.restClient.Get(p)
+}
+
+func unsupported(resp *rest.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusBadRequest, http.StatusNotFound, http.StatusMethodNotAllowed:
+		return strings.Contains(string(resp.Body), "no handler found")
+	}
+	return false
+}
+
+// request tries the Elasticsearch SLM endpoint first and falls back to OpenSearch snapshot
+// management when SLM is not available. The returned flag reports whether OpenSearch answered.
+func (c *policiesClient) request(method, esPath, osPath string) (*rest.Response, bool, error) {
+	resp, err := c.send(method, esPath)
+	if err == nil && !unsupported(resp) {
+		return resp, false, nil
+	}
+	osResp, osErr := c.send(method, osPath)
+	if osErr != nil {
+		if err == nil {
+			return resp, false, nil
+		}
+		return nil, false, osErr
+	}
+	if unsupported(osResp) && err == nil {
+		return resp, false, nil
+	}
+	return osResp, true, nil
+}
+
+type slmPolicy struct {
+	Version       int                    `json:"version"`
+	ModifiedDate  string                 `json:"modified_date"`
+	Policy        map[string]interface{} `json:"policy"`
+	NextExecution string                 `json:"next_execution"`
+	LastSuccess   map[string]interface{} `json:"last_success"`
+	LastFailure   map[string]interface{} `json:"last_failure"`
+}
+
+func fromSLM(name string, p slmPolicy) types.SnapshotPolicy {
+	out := types.SnapshotPolicy{
+		Name:          name,
+		Policy:        p.Policy,
+		Version:       p.Version,
+		ModifiedDate:  p.ModifiedDate,
+		NextExecution: p.NextExecution,
+		LastSuccess:   p.LastSuccess,
+		LastFailure:   p.LastFailure,
+	}
+	out.Schedule, _ = p.Policy["schedule"].(string)
+	out.Repository, _ = p.Policy["repository"].(string)
+	if retention, ok := p.Policy["retention"].(map[string]interface{}); ok {
+		out.Retention = retentionSummary(retention, "expire_after")
+	}
+	return out
+}
+
+func fromSM(smPolicy map[string]interface{}) types.SnapshotPolicy {
+	name, _ := smPolicy["name"].(string)
+	policy := make(map[string]interface{}, len(smPolicy))
+	for k, v := range smPolicy {
+		policy[k] = v
+	}
+	for _, f := range smServerFields {
+		delete(policy, f)
+	}
+
+	out := types.SnapshotPolicy{Name: name, Policy: policy}
+	if cron, ok := nested(policy, "creation", "schedule", "cron").(map[string]interface{}); ok {
+		expr, _ := cron["expression"].(string)
+		if tz, ok := cron["timezone"].(string); ok && tz != "" {
+			expr += " (" + tz + ")"
+		}
+		out.Schedule = expr
+	}
+	out.Repository, _ = nested(policy, "snapshot_config", "repository").(string)
+	if cond, ok := nested(policy, "deletion", "condition").(map[string]interface{}); ok {
+		out.Retention = retentionSummary(cond, "max_age")
+	}
+	return out
+}
+
+func nested(m map[string]interface{}, keys ...string) interface{} {
+	var cur interface{} = m
+	for _, k := range keys {
+		obj, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		cur = obj[k]
+	}
+	return cur
+}
+
+func retentionSummary(m map[string]interface{}, ageKey string) string {
+	var parts []string
+	for _, k := range []string{ageKey, "min_count", "max_count"} {
+		if v, ok := m[k]; ok {
+			parts = append(parts, fmt.Sprintf("%s=%v", k, v))
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+func matchPattern(pattern, name string) bool {
+	if pattern == "" || pattern == "*" {
+		return true
+	}
+	for _, p := range strings.Split(pattern, ",") {
+		if ok, err := path.Match(strings.TrimSpace(p), name); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *policiesClient) List(pattern string) ([]types.SnapshotPolicy, error) {
+	resp, isOpenSearch, err := c.request(http.MethodGet, "/_slm/policy?human=true", "/_plugins/_sm/policies")
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return []types.SnapshotPolicy{}, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error getting snapshot policies: %s", string(resp.Body))
+	}
+
+	policies := make([]types.SnapshotPolicy, 0)
+	if isOpenSearch {
+		var body struct {
+			Policies []struct {
+				SMPolicy map[string]interface{} `json:"sm_policy"`
+			} `json:"policies"`
+		}
+		if err := json.Unmarshal(resp.Body, &body); err != nil {
+			return nil, err
+		}
+		for _, p := range body.Policies {
+			policy := fromSM(p.SMPolicy)
+			if matchPattern(pattern, policy.Name) {
+				policies = append(policies, policy)
+			}
+		}
+	} else {
+		var body map[string]slmPolicy
+		if err := json.Unmarshal(resp.Body, &body); err != nil {
+			return nil, err
+		}
+		for name, p := range body {
+			if matchPattern(pattern, name) {
+				policies = append(policies, fromSLM(name, p))
+			}
+		}
+	}
+	sort.Slice(policies, func(i, j int) bool { return policies[i].Name < policies[j].Name })
+	return policies, nil
+}
+
+func (c *policiesClient) Get(name string) (*types.SnapshotPolicy, error) {
+	resp, isOpenSearch, err := c.request(http.MethodGet,
+		fmt.Sprintf("/_slm/policy/%s?human=true", name), fmt.Sprintf("/_plugins/_sm/policies/%s", name))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("snapshot policy %q not found", name)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error getting snapshot policy: %s", string(resp.Body))
+	}
+
+	if isOpenSearch {
+		var body struct {
+			SMPolicy map[string]interface{} `json:"sm_policy"`
+		}
+		if err := json.Unmarshal(resp.Body, &body); err != nil {
+			return nil, err
+		}
+		policy := fromSM(body.SMPolicy)
+		return &policy, nil
+	}
+
+	var body map[string]slmPolicy
+	if err := json.Unmarshal(resp.Body, &body); err != nil {
+		return nil, err
+	}
+	p, ok := body[name]
+	if !ok {
+		return nil, fmt.Errorf("snapshot policy %q not found", name)
+	}
+	policy := fromSLM(name, p)
+	return &policy, nil
+}
+
+// Create creates or replaces a policy. OpenSearch only creates with POST, so an existing
+// policy is updated with PUT using its current sequence number.
+func (c *policiesClient) Create(name string, body map[string]interface{}) error {
+	resp, err := c.restClient.Put(fmt.Sprintf("/_slm/policy/%s", name), body)
+	if err != nil || unsupported(resp) {
+		resp, err = c.restClient.Post(fmt.Sprintf("/_plugins/_sm/policies/%s", name), body)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode == http.StatusConflict {
+			resp, err = c.updateSM(name, body)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("error creating snapshot policy: %s", string(resp.Body))
+	}
+	return nil
+}
+
+func (c *policiesClient) updateSM(name string, body map[string]interface{}) (*rest.Response, error) {
+	path := fmt.Sprintf("/_plugins/_sm/policies/%s", name)
+	resp, err := c.restClient.Get(path)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error getting snapshot policy: %s", string(resp.Body))
+	}
+	var current struct {
+		SeqNo       int64 `json:"_seq_no"`
+		PrimaryTerm int64 `json:"_primary_term"`
+	}
+	if err := json.Unmarshal(resp.Body, &current); err != nil {
+		return nil, err
+	}
+	return c.restClient.Put(fmt.Sprintf("%s?if_seq_no=%d&if_primary_term=%d", path, current.SeqNo, current.PrimaryTerm), body)
+}
+
+func (c *policiesClient) Delete(name string) error {
+	resp, _, err := c.request(http.MethodDelete, fmt.Sprintf("/_slm/policy/%s", name), fmt.Sprintf("/_plugins/_sm/policies/%s", name))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("snapshot policy %q not found", name)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("error deleting snapshot policy: %s", string(resp.Body))
+	}
+	return nil
+}
+
+// Execute runs an SLM policy immediately and returns the name of the snapshot it started.
+// OpenSearch snapshot management has no equivalent API.
+func (c *policiesClient) Execute(name string) (string, error) {
+	resp, err := c.restClient.Post(fmt.Sprintf("/_slm/policy/%s/_execute", name), nil)
+	if err != nil {
+		return "", err
+	}
+	if unsupported(resp) {
+		return "", fmt.Errorf("executing a snapshot policy is not supported by OpenSearch snapshot management; use 'searchctl create snapshot' instead")
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("snapshot policy %q not found", name)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("error executing snapshot policy: %s", string(resp.Body))
+	}
+	var out struct {
+		SnapshotName string `json:"snapshot_name"`
+	}
+	if err := json.Unmarshal(resp.Body, &out); err != nil {
+		return "", err
+	}
+	return out.SnapshotName, nil
+}
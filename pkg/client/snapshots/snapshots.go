@@ -0,0 +1,262 @@
+package snapshots
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/chronicblondiee/searchctl/pkg/client/rest"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+)
+
+type client struct {
+	restClient *rest.Client
+}
+
+func New(restClient *rest.Client) Interface {
+	return &client{restClient: restClient}
+}
+
+func (c *client) ListRepositories(pattern string) ([]types.SnapshotRepository, error) {
+	path := "/_snapshot"
+	if pattern != "" {
+		path = fmt.Sprintf("/_snapshot/%s", pattern)
+	}
+	resp, err := c.restClient.Get(path)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return []types.SnapshotRepository{}, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error getting snapshot repositories: %s", string(resp.Body))
+	}
+	var body map[string]types.SnapshotRepository
+	if err := json.Unmarshal(resp.Body, &body); err != nil {
+		return nil, err
+	}
+	repos := make([]types.SnapshotRepository, 0, len(body))
+	for name, repo := range body {
+		repo.Name = name
+		repos = append(repos, repo)
+	}
+	sort.Slice(repos, func(i, j int) bool { return repos[i].Name < repos[j].Name })
+	return repos, nil
+}
+
+func (c *client) GetRepository(name string) (*types.SnapshotRepository, error) {
+	resp, err := c.restClient.Get(fmt.Sprintf("/_snapshot/%s", name))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("snapshot repository %q not found", name)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error getting snapshot repository: %s", string(resp.Body))
+	}
+	var body map[string]types.SnapshotRepository
+	if err := json.Unmarshal(resp.Body, &body); err != nil {
+		return nil, err
+	}
+	repo, ok := body[name]
+	if !ok {
+		return nil, fmt.Errorf("snapshot repository %q not found", name)
+	}
+	repo.Name = name
+	return &repo, nil
+}
+
+func (c *client) CreateRepository(name string, body map[string]interface{}, verify bool) error {
+	path := fmt.Sprintf("/_snapshot/%s", name)
+	if !verify {
+		path += "?verify=false"
+	}
+	resp, err := c.restClient.Put(path, body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("error creating snapshot repository: %s", string(resp.Body))
+	}
+	return nil
+}
+
+func (c *client) DeleteRepository(name string) error {
+	resp, err := c.restClient.Delete(fmt.Sprintf("/_snapshot/%s", name))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("snapshot repository %q not found", name)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("error deleting snapshot repository: %s", string(resp.Body))
+	}
+	return nil
+}
+
+func (c *client) VerifyRepository(name string) ([]types.VerifiedNode, error) {
+	resp, err := c.restClient.Post(fmt.Sprintf("/_snapshot/%s/_verify", name), nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("snapshot repository %q not found", name)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error verifying snapshot repository: %s", string(resp.Body))
+	}
+	var body struct {
+		Nodes map[string]struct {
+			Name string `json:"name"`
+		} `json:"nodes"`
+	}
+	if err := json.Unmarshal(resp.Body, &body); err != nil {
+		return nil, err
+	}
+	nodes := make([]types.VerifiedNode, 0, len(body.Nodes))
+	for id, n := range body.Nodes {
+		nodes = append(nodes, types.VerifiedNode{ID: id, Name: n.Name})
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+	return nodes, nil
+}
+
+// List returns the snapshots in a repository sorted by start time. Per-index sizes are
+// requested where supported (Elasticsearch 7.13+) and skipped on clusters that reject the flag.
+func (c *client) List(repository, pattern string) ([]types.Snapshot, error) {
+	if pattern == "" {
+		pattern = "_all"
+	}
+	path := fmt.Sprintf("/_snapshot/%s/%s", repository, url.PathEscape(pattern))
+	resp, err := c.restClient.Get(path + "?index_details=true")
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusBadRequest {
+		resp, err = c.restClient.Get(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("snapshot repository %q or snapshot %q not found", repository, pattern)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error getting snapshots: %s", string(resp.Body))
+	}
+	var body struct {
+		Snapshots []types.Snapshot `json:"snapshots"`
+	}
+	if err := json.Unmarshal(resp.Body, &body); err != nil {
+		return nil, err
+	}
+	for i := range body.Snapshots {
+		if body.Snapshots[i].Repository == "" {
+			body.Snapshots[i].Repository = repository
+		}
+	}
+	sort.SliceStable(body.Snapshots, func(i, j int) bool {
+		return body.Snapshots[i].StartTimeInMillis < body.Snapshots[j].StartTimeInMillis
+	})
+	return body.Snapshots, nil
+}
+
+func (c *client) Get(repository, name string) (*types.Snapshot, error) {
+	snapshots, err := c.List(repository, name)
+	if err != nil {
+		return nil, err
+	}
+	for i := range snapshots {
+		if snapshots[i].Snapshot == name {
+			return &snapshots[i], nil
+		}
+	}
+	return nil, fmt.Errorf("snapshot %q not found in repository %q", name, repository)
+}
+
+// Create starts a snapshot. The snapshot is only returned when wait is set.
+func (c *client) Create(repository, name string, body map[string]interface{}, wait bool) (*types.Snapshot, error) {
+	path := fmt.Sprintf("/_snapshot/%s/%s", repository, url.PathEscape(name))
+	if wait {
+		path += "?wait_for_completion=true"
+	}
+	resp, err := c.restClient.Put(path, body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		return nil, fmt.Errorf("error creating snapshot: %s", string(resp.Body))
+	}
+	if !wait {
+		return nil, nil
+	}
+	var out struct {
+		Snapshot types.Snapshot `json:"snapshot"`
+	}
+	if err := json.Unmarshal(resp.Body, &out); err != nil {
+		return nil, err
+	}
+	if out.Snapshot.Repository == "" {
+		out.Snapshot.Repository = repository
+	}
+	return &out.Snapshot, nil
+}
+
+func (c *client) Delete(repository, name string) error {
+	resp, err := c.restClient.Delete(fmt.Sprintf("/_snapshot/%s/%s", repository, url.PathEscape(name)))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("snapshot %q not found in repository %q", name, repository)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("error deleting snapshot: %s", string(resp.Body))
+	}
+	return nil
+}
+
+// Status returns shard-level progress; an empty name returns the repository's running snapshots
+func (c *client) Status(repository, name string) ([]types.SnapshotStatus, error) {
+	path := fmt.Sprintf("/_snapshot/%s/_status", repository)
+	if name != "" {
+		path = fmt.Sprintf("/_snapshot/%s/%s/_status", repository, url.PathEscape(name))
+	}
+	resp, err := c.restClient.Get(path)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("snapshot repository %q or snapshot %q not found", repository, name)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error getting snapshot status: %s", string(resp.Body))
+	}
+	var body struct {
+		Snapshots []types.SnapshotStatus `json:"snapshots"`
+	}
+	if err := json.Unmarshal(resp.Body, &body); err != nil {
+		return nil, err
+	}
+	return body.Snapshots, nil
+}
+
+// Restore starts restoring a snapshot and returns once the restore has been accepted
+func (c *client) Restore(repository, name string, body map[string]interface{}) error {
+	resp, err := c.restClient.Post(fmt.Sprintf("/_snapshot/%s/%s/_restore", repository, url.PathEscape(name)), body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("snapshot %q not found in repository %q", name, repository)
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("error restoring snapshot: %s", string(resp.Body))
+	}
+	return nil
+}
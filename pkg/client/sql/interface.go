@@ -0,0 +1,10 @@
+package sql
+
+import "github.com/chronicblondiee/searchctl/pkg/types"
+
+type Interface interface {
+	Query(query string, fetchSize int) (*types.SQLResponse, error)
+	PPL(query string) (*types.SQLResponse, error)
+	Next(cursor string, openSearch bool) (*types.SQLResponse, error)
+	Close(cursor string, openSearch bool) error
+}
@@ -0,0 +1,152 @@
+package sql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/chronicblondiee/searchctl/pkg/client/rest"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+)
+
+const (
+	esPath = "/_sql?format=json"
+	osPath = "/_plugins/_sql"
+)
+
+type client struct {
+	restClient *rest.Client
+}
+
+func New(restClient *rest.Client) Interface {
+	return &client{restClient: restClient}
+}
+
+func unsupported(resp *rest.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusBadRequest, http.StatusNotFound, http.StatusMethodNotAllowed:
+		return strings.Contains(string(resp.Body), "no handler found")
+	}
+	return false
+}
+
+// esResult is the Elasticsearch format=json response
+type esResult struct {
+	Columns []types.SQLColumn `json:"columns"`
+	Rows    [][]interface{}   `json:"rows"`
+	Cursor  string            `json:"cursor"`
+}
+
+// osResult is the OpenSearch jdbc response
+type osResult struct {
+	Schema   []types.SQLColumn `json:"schema"`
+	DataRows [][]interface{}   `json:"datarows"`
+	Cursor   string            `json:"cursor"`
+}
+
+func decode(body []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+func fromES(resp *rest.Response) (*types.SQLResponse, error) {
+	var result esResult
+	if err := decode(resp.Body, &result); err != nil {
+		return nil, err
+	}
+	return &types.SQLResponse{Columns: result.Columns, Rows: result.Rows, Cursor: result.Cursor}, nil
+}
+
+func fromOS(resp *rest.Response) (*types.SQLResponse, error) {
+	var result osResult
+	if err := decode(resp.Body, &result); err != nil {
+		return nil, err
+	}
+	return &types.SQLResponse{Columns: result.Schema, Rows: result.DataRows, Cursor: result.Cursor, OpenSearch: true}, nil
+}
+
+// Query runs an SQL query with the Elasticsearch SQL API, falling back to the OpenSearch
+// SQL plugin when it is not available
+func (c *client) Query(query string, fetchSize int) (*types.SQLResponse, error) {
+	body := map[string]interface{}{"query": query}
+	if fetchSize > 0 {
+		body["fetch_size"] = fetchSize
+	}
+
+	resp, err := c.restClient.Post(esPath, body)
+	if err != nil {
+		return nil, err
+	}
+	if !unsupported(resp) {
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("error running SQL query: %s", string(resp.Body))
+		}
+		return fromES(resp)
+	}
+
+	resp, err = c.restClient.Post(osPath, body)
+	if err != nil {
+		return nil, err
+	}
+	if unsupported(resp) {
+		return nil, fmt.Errorf("SQL is not available on this cluster")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error running SQL query: %s", string(resp.Body))
+	}
+	return fromOS(resp)
+}
+
+// PPL runs a piped processing language query. PPL is only provided by OpenSearch.
+func (c *client) PPL(query string) (*types.SQLResponse, error) {
+	resp, err := c.restClient.Post("/_plugins/_ppl", map[string]interface{}{"query": query})
+	if err != nil {
+		return nil, err
+	}
+	if unsupported(resp) {
+		return nil, fmt.Errorf("PPL is only supported by the OpenSearch SQL plugin")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error running PPL query: %s", string(resp.Body))
+	}
+	return fromOS(resp)
+}
+
+// Next fetches the page after cursor. Elasticsearch returns no columns on later pages,
+// so callers keep the columns of the first page.
+func (c *client) Next(cursor string, openSearch bool) (*types.SQLResponse, error) {
+	path := esPath
+	if openSearch {
+		path = osPath
+	}
+	resp, err := c.restClient.Post(path, map[string]interface{}{"cursor": cursor})
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error fetching next page: %s", string(resp.Body))
+	}
+	if openSearch {
+		return fromOS(resp)
+	}
+	return fromES(resp)
+}
+
+// Close releases the server side state of a cursor that was not read to the end
+func (c *client) Close(cursor string, openSearch bool) error {
+	path := "/_sql/close"
+	if openSearch {
+		path = osPath + "/close"
+	}
+	resp, err := c.restClient.Post(path, map[string]interface{}{"cursor": cursor})
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("error closing cursor: %s", string(resp.Body))
+	}
+	return nil
+}
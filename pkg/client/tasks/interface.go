@@ -0,0 +1,10 @@
+package tasks
+
+import "github.com/chronicblondiee/searchctl/pkg/types"
+
+type Interface interface {
+	List(opts types.TaskListOptions) ([]types.TaskInfo, error)
+	Get(taskID string) (*types.TaskResult, error)
+	Cancel(taskID, actions string) ([]types.TaskInfo, error)
+	Wait(taskID, timeout string) (*types.TaskResult, error)
+}
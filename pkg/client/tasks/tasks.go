@@ -0,0 +1,165 @@
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/chronicblondiee/searchctl/pkg/client/rest"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+)
+
+type client struct {
+	restClient *rest.Client
+}
+
+func New(restClient *rest.Client) Interface {
+	return &client{restClient: restClient}
+}
+
+// taskListResponse is the group_by=nodes form returned by _tasks and _tasks/_cancel
+type taskListResponse struct {
+	Nodes map[string]struct {
+		Name  string                    `json:"name"`
+		Tasks map[string]types.TaskInfo `json:"tasks"`
+	} `json:"nodes"`
+	NodeFailures []map[string]interface{} `json:"node_failures,omitempty"`
+	TaskFailures []map[string]interface{} `json:"task_failures,omitempty"`
+}
+
+func (r *taskListResponse) flatten() []types.TaskInfo {
+	tasks := make([]types.TaskInfo, 0)
+	for _, node := range r.Nodes {
+		for _, t := range node.Tasks {
+			t.NodeName = node.Name
+			tasks = append(tasks, t)
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		if tasks[i].StartTimeInMillis != tasks[j].StartTimeInMillis {
+			return tasks[i].StartTimeInMillis < tasks[j].StartTimeInMillis
+		}
+		return tasks[i].ID < tasks[j].ID
+	})
+	return tasks
+}
+
+func (r *taskListResponse) failures() []string {
+	var reasons []string
+	for _, f := range append(r.NodeFailures, r.TaskFailures...) {
+		if reason, ok := f["reason"]; ok {
+			if m, ok := reason.(map[string]interface{}); ok {
+				reasons = append(reasons, fmt.Sprintf("%v", m["reason"]))
+				continue
+			}
+			reasons = append(reasons, fmt.Sprintf("%v", reason))
+		}
+	}
+	return reasons
+}
+
+func (c *client) List(opts types.TaskListOptions) ([]types.TaskInfo, error) {
+	v := url.Values{}
+	v.Set("group_by", "nodes")
+	if opts.Actions != "" {
+		v.Set("actions", opts.Actions)
+	}
+	if opts.Nodes != "" {
+		v.Set("nodes", opts.Nodes)
+	}
+	if opts.Parent != "" {
+		v.Set("parent_task_id", opts.Parent)
+	}
+	if opts.Detailed {
+		v.Set("detailed", "true")
+	}
+	resp, err := c.restClient.Get("/_tasks?" + v.Encode())
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error listing tasks: %s", string(resp.Body))
+	}
+	var out taskListResponse
+	if err := json.Unmarshal(resp.Body, &out); err != nil {
+		return nil, err
+	}
+	return out.flatten(), nil
+}
+
+func (c *client) Get(taskID string) (*types.TaskResult, error) {
+	return c.get(fmt.Sprintf("/_tasks/%s", taskID), taskID)
+}
+
+// Cancel cancels a single task, or every cancellable task matching actions when taskID is empty
+func (c *client) Cancel(taskID, actions string) ([]types.TaskInfo, error) {
+	path := "/_tasks/_cancel"
+	if taskID != "" {
+		path = fmt.Sprintf("/_tasks/%s/_cancel", taskID)
+	} else if actions != "" {
+		path += "?" + url.Values{"actions": []string{actions}}.Encode()
+	}
+	resp, err := c.restClient.Post(path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound && taskID != "" {
+		return nil, fmt.Errorf("task %q not found", taskID)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error cancelling task: %s", string(resp.Body))
+	}
+	var out taskListResponse
+	if err := json.Unmarshal(resp.Body, &out); err != nil {
+		return nil, err
+	}
+	tasks := out.flatten()
+	if reasons := out.failures(); len(reasons) > 0 && len(tasks) == 0 {
+		return nil, fmt.Errorf("error cancelling task: %s", strings.Join(reasons, "; "))
+	}
+	return tasks, nil
+}
+
+// Wait blocks server-side until the task completes or the timeout expires. When the
+// timeout expires the task is returned with Completed unset.
+func (c *client) Wait(taskID, timeout string) (*types.TaskResult, error) {
+	v := url.Values{}
+	v.Set("wait_for_completion", "true")
+	if timeout != "" {
+		v.Set("timeout", timeout)
+	}
+	resp, err := c.restClient.Get(fmt.Sprintf("/_tasks/%s?%s", taskID, v.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	// The cluster answers an expired wait with a timeout error rather than the running task
+	if resp.StatusCode == http.StatusRequestTimeout || (resp.StatusCode != http.StatusOK && strings.Contains(string(resp.Body), "timeout_exception")) {
+		return c.Get(taskID)
+	}
+	return decodeTask(resp, taskID)
+}
+
+func (c *client) get(path, taskID string) (*types.TaskResult, error) {
+	resp, err := c.restClient.Get(path)
+	if err != nil {
+		return nil, err
+	}
+	return decodeTask(resp, taskID)
+}
+
+func decodeTask(resp *rest.Response, taskID string) (*types.TaskResult, error) {
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("task %q not found", taskID)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error getting task: %s", string(resp.Body))
+	}
+	var out types.TaskResult
+	if err := json.Unmarshal(resp.Body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -152,13 +152,21 @@ func GetCurrentContext() (*Context, error) {
 		return nil, fmt.Errorf("no current context available")
 	}
 
+	return GetContext(currentContextName)
+}
+
+func GetContext(name string) (*Context, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config not initialized")
+	}
+
 	for _, ctx := range config.Contexts {
-		if ctx.Name == currentContextName {
+		if ctx.Name == name {
 			return &ctx, nil
 		}
 	}
 
-	return nil, fmt.Errorf("context %q not found", currentContextName)
+	return nil, fmt.Errorf("context %q not found", name)
 }
 
 func GetCluster(name string) (*Cluster, error) {
@@ -94,3 +94,33 @@ func TestGetCluster(t *testing.T) {
 		t.Error("Expected error for non-existent cluster")
 	}
 }
+
+func TestGetContext(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "searchctl-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	oldHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpDir)
+	defer os.Setenv("HOME", oldHome)
+
+	err = config.InitConfig("")
+	if err != nil {
+		t.Fatalf("InitConfig failed: %v", err)
+	}
+
+	ctx, err := config.GetContext("default")
+	if err != nil {
+		t.Fatalf("GetContext failed: %v", err)
+	}
+	if ctx.Context.Cluster != "default" {
+		t.Errorf("Expected cluster default, got %s", ctx.Context.Cluster)
+	}
+
+	_, err = config.GetContext("nonexistent")
+	if err == nil {
+		t.Error("Expected error for non-existent context")
+	}
+}
@@ -0,0 +1,86 @@
+// Package disk reads the disk allocation watermarks shared by get allocation and doctor.
+package disk
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/chronicblondiee/searchctl/pkg/output"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+)
+
+// Watermark levels, in increasing order
+const (
+	Low        = "low"
+	High       = "high"
+	FloodStage = "flood-stage"
+)
+
+// Watermark is a disk watermark setting. Percentages and ratios limit disk usage;
+// byte values require that much free space instead.
+type Watermark struct {
+	Level       string `json:"level"`
+	Value       string `json:"value"`
+	MaxHeadroom string `json:"max_headroom,omitempty"`
+}
+
+// Watermarks reads the watermarks from the cluster settings, lowest first, and whether
+// disk thresholds are enabled. Built-in defaults are used when the cluster does not
+// report its defaults.
+func Watermarks(settings *types.ClusterSettings) (bool, []Watermark) {
+	const prefix = "cluster.routing.allocation.disk."
+	enabled := true
+	if v, _ := settings.Value(prefix + "threshold_enabled"); v == "false" {
+		enabled = false
+	}
+
+	defaults := map[string]string{Low: "85%", High: "90%", FloodStage: "95%"}
+	keys := map[string]string{Low: "low", High: "high", FloodStage: "flood_stage"}
+	var marks []Watermark
+	for _, level := range []string{Low, High, FloodStage} {
+		w := Watermark{Level: level, Value: defaults[level]}
+		if v, _ := settings.Value(prefix + "watermark." + keys[level]); v != "" {
+			w.Value = v
+		}
+		if v, _ := settings.Value(prefix + "watermark." + keys[level] + ".max_headroom"); v != "" && v != "-1" {
+			w.MaxHeadroom = v
+		}
+		marks = append(marks, w)
+	}
+	return enabled, marks
+}
+
+// Threshold returns the disk usage in bytes above which a node of the given size
+// crosses the watermark
+func (w Watermark) Threshold(total int64) (int64, error) {
+	value := strings.TrimSpace(w.Value)
+	var ratio float64
+	switch {
+	case strings.HasSuffix(value, "%"):
+		pct, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s watermark %q", w.Level, w.Value)
+		}
+		ratio = pct / 100
+	default:
+		r, err := strconv.ParseFloat(value, 64)
+		if err != nil || r > 1 {
+			// An absolute value is the free space to keep
+			free, err := output.ParseBytes(value)
+			if err != nil {
+				return 0, fmt.Errorf("invalid %s watermark %q", w.Level, w.Value)
+			}
+			return total - free, nil
+		}
+		ratio = r
+	}
+
+	limit := int64(float64(total) * ratio)
+	if w.MaxHeadroom != "" {
+		if headroom, err := output.ParseBytes(w.MaxHeadroom); err == nil && total-headroom > limit {
+			limit = total - headroom
+		}
+	}
+	return limit, nil
+}
@@ -0,0 +1,60 @@
+package disk_test
+
+import (
+	"testing"
+
+	"github.com/chronicblondiee/searchctl/pkg/disk"
+	"github.com/chronicblondiee/searchctl/pkg/types"
+)
+
+func TestWatermarkThreshold(t *testing.T) {
+	const gb = int64(1) << 30
+	tests := []struct {
+		mark     disk.Watermark
+		total    int64
+		expected int64
+	}{
+		{disk.Watermark{Value: "90%"}, 100 * gb, 90 * gb},
+		{disk.Watermark{Value: "0.85"}, 100 * gb, 85 * gb},
+		{disk.Watermark{Value: "20gb"}, 100 * gb, 80 * gb},
+		{disk.Watermark{Value: "90%", MaxHeadroom: "150gb"}, 10000 * gb, 9850 * gb},
+		{disk.Watermark{Value: "90%", MaxHeadroom: "150gb"}, 100 * gb, 90 * gb},
+	}
+	for _, tt := range tests {
+		got, err := tt.mark.Threshold(tt.total)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.expected {
+			t.Errorf("Threshold(%+v, %d) = %d, expected %d", tt.mark, tt.total, got, tt.expected)
+		}
+	}
+	if _, err := (disk.Watermark{Value: "lots"}).Threshold(gb); err == nil {
+		t.Error("Expected an error for an invalid watermark")
+	}
+}
+
+func TestWatermarks(t *testing.T) {
+	settings := &types.ClusterSettings{
+		Persistent: map[string]interface{}{"cluster": map[string]interface{}{"routing": map[string]interface{}{
+			"allocation": map[string]interface{}{"disk": map[string]interface{}{
+				"threshold_enabled": "false",
+				"watermark":         map[string]interface{}{"low": "80%", "flood_stage.max_headroom": "100gb"},
+			}},
+		}}},
+	}
+	enabled, marks := disk.Watermarks(settings)
+	if enabled {
+		t.Error("Expected disk thresholds to be disabled")
+	}
+	expected := []disk.Watermark{
+		{Level: disk.Low, Value: "80%"},
+		{Level: disk.High, Value: "90%"},
+		{Level: disk.FloodStage, Value: "95%", MaxHeadroom: "100gb"},
+	}
+	for i, w := range expected {
+		if marks[i] != w {
+			t.Errorf("Expected %+v, got %+v", w, marks[i])
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package output
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -18,6 +19,7 @@ type Formatter interface {
 type TableFormatter struct{}
 type JSONFormatter struct{}
 type YAMLFormatter struct{}
+type CSVFormatter struct{}
 
 func NewFormatter(format string) Formatter {
 	switch format {
@@ -25,6 +27,8 @@ func NewFormatter(format string) Formatter {
 		return &JSONFormatter{}
 	case "yaml":
 		return &YAMLFormatter{}
+	case "csv":
+		return &CSVFormatter{}
 	default:
 		return &TableFormatter{}
 	}
@@ -55,25 +59,7 @@ func (f *TableFormatter) formatSlice(data []interface{}, w *tabwriter.Writer) er
 	first := data[0]
 	switch first.(type) {
 	case map[string]interface{}:
-		firstMap := first.(map[string]interface{})
-		// Extract headers from map keys, skipping internal keys starting with "__"
-		headers := make([]string, 0)
-		for k := range firstMap {
-			if len(k) >= 2 && k[:2] == "__" {
-				continue
-			}
-			headers = append(headers, k)
-		}
-		// If a preferred header order is provided via "__columns", honor it
-		if pref, ok := firstMap["__columns"]; ok {
-			ordered := orderFromPreference(pref, headers)
-			if len(ordered) > 0 {
-				headers = ordered
-			}
-		} else {
-			// Otherwise, sort headers for deterministic output
-			sort.Strings(headers)
-		}
+		headers := headersFor(first.(map[string]interface{}))
 
 		// Print headers
 		for i, header := range headers {
@@ -103,6 +89,25 @@ func (f *TableFormatter) formatSlice(data []interface{}, w *tabwriter.Writer) er
 	return nil
 }
 
+// headersFor extracts the column headers of a row, skipping internal keys starting with "__".
+// A preferred order provided via "__columns" is honored, otherwise headers are sorted.
+func headersFor(row map[string]interface{}) []string {
+	headers := make([]string, 0)
+	for k := range row {
+		if len(k) >= 2 && k[:2] == "__" {
+			continue
+		}
+		headers = append(headers, k)
+	}
+	if pref, ok := row["__columns"]; ok {
+		if ordered := orderFromPreference(pref, headers); len(ordered) > 0 {
+			return ordered
+		}
+	}
+	sort.Strings(headers)
+	return headers
+}
+
 // orderFromPreference builds an ordered header slice from a preference value and available headers
 // pref can be a comma-delimited string or []interface{} / []string
 func orderFromPreference(pref interface{}, available []string) []string {
@@ -170,3 +175,66 @@ func (f *YAMLFormatter) Format(data interface{}, writer io.Writer) error {
 	defer encoder.Close()
 	return encoder.Encode(data)
 }
+
+// Format writes slices of rows as CSV with a header line, using the same columns as the
+// table output. A single object is written as KEY,VALUE pairs.
+func (f *CSVFormatter) Format(data interface{}, writer io.Writer) error {
+	w := csv.NewWriter(writer)
+
+	switch v := data.(type) {
+	case []interface{}:
+		if len(v) == 0 {
+			return nil
+		}
+		first, ok := v[0].(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("csv output is not supported for this resource")
+		}
+		headers := headersFor(first)
+		if err := w.Write(headers); err != nil {
+			return err
+		}
+		for _, item := range v {
+			m, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			record := make([]string, len(headers))
+			for i, header := range headers {
+				if val, exists := m[header]; exists && val != nil {
+					record[i] = fmt.Sprint(val)
+				}
+			}
+			if err := w.Write(record); err != nil {
+				return err
+			}
+		}
+	default:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			jsonData, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			if err := json.Unmarshal(jsonData, &m); err != nil {
+				return fmt.Errorf("csv output is not supported for this resource")
+			}
+		}
+		if err := w.Write([]string{"KEY", "VALUE"}); err != nil {
+			return err
+		}
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if err := w.Write([]string{k, fmt.Sprint(m[k])}); err != nil {
+				return err
+			}
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
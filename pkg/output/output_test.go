@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/chronicblondiee/searchctl/pkg/output"
 )
@@ -77,6 +78,7 @@ func TestNewFormatter(t *testing.T) {
 		{"table", "*output.TableFormatter"},
 		{"json", "*output.JSONFormatter"},
 		{"yaml", "*output.YAMLFormatter"},
+		{"csv", "*output.CSVFormatter"},
 		{"unknown", "*output.TableFormatter"},
 	}
 
@@ -87,3 +89,101 @@ func TestNewFormatter(t *testing.T) {
 		}
 	}
 }
+
+func TestCSVFormatter(t *testing.T) {
+	formatter := &output.CSVFormatter{}
+	var buf bytes.Buffer
+
+	data := []interface{}{
+		map[string]interface{}{"__columns": []string{"name", "count(a, b)"}, "name": "x,y", "count(a, b)": 3},
+		map[string]interface{}{"__columns": []string{"name", "count(a, b)"}, "name": "z", "count(a, b)": nil},
+	}
+	if err := formatter.Format(data, &buf); err != nil {
+		t.Fatalf("CSVFormatter.Format failed: %v", err)
+	}
+
+	expected := "name,\"count(a, b)\"\n\"x,y\",3\nz,\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := map[int64]string{
+		0:               "0 B",
+		512:             "512 B",
+		1536:            "1.5 KB",
+		5 * 1024 * 1024: "5.0 MB",
+		3 << 30:         "3.0 GB",
+	}
+	for in, want := range tests {
+		if got := output.FormatBytes(in); got != want {
+			t.Errorf("FormatBytes(%d) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestParseBytes(t *testing.T) {
+	tests := map[string]int64{
+		"512":   512,
+		"10kb":  10 * 1024,
+		"5MB":   5 * 1024 * 1024,
+		"1.5g":  3 << 29,
+		" 2 mb": 2 * 1024 * 1024,
+	}
+	for in, want := range tests {
+		got, err := output.ParseBytes(in)
+		if err != nil {
+			t.Errorf("ParseBytes(%q) returned error: %v", in, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("ParseBytes(%q) = %d, want %d", in, got, want)
+		}
+	}
+	for _, in := range []string{"", "abc", "-1mb"} {
+		if _, err := output.ParseBytes(in); err == nil {
+			t.Errorf("ParseBytes(%q) expected error", in)
+		}
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := map[string]time.Duration{
+		"41s":   41 * time.Second,
+		"12.5m": 750 * time.Second,
+		"2h":    2 * time.Hour,
+		"1.5d":  36 * time.Hour,
+		"250ms": 250 * time.Millisecond,
+	}
+	for in, want := range tests {
+		got, err := output.ParseDuration(in)
+		if err != nil || got != want {
+			t.Errorf("ParseDuration(%q) = %v, %v, want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "soon", "-1s"} {
+		if _, err := output.ParseDuration(in); err == nil {
+			t.Errorf("ParseDuration(%q) expected error", in)
+		}
+	}
+}
+
+func TestStartProgress(t *testing.T) {
+	var buf bytes.Buffer
+	calls := 0
+	stop := output.StartProgress(&buf, 5*time.Millisecond, func() string {
+		calls++
+		return "42 documents"
+	})
+	time.Sleep(30 * time.Millisecond)
+	stop()
+
+	if calls == 0 {
+		t.Fatal("Expected the progress line to be rendered")
+	}
+	// Without a terminal every update is a line of its own
+	if lines := strings.Count(buf.String(), "42 documents\n"); lines != calls {
+		t.Errorf("Expected %d lines, got %q", calls, buf.String())
+	}
+}
@@ -0,0 +1,53 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// IsTerminal reports whether f is an interactive terminal
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// StartProgress writes the line returned by line to w every interval and returns a
+// function that stops it. On a terminal the line is redrawn in place; otherwise one
+// line is written per interval.
+func StartProgress(w io.Writer, interval time.Duration, line func() string) func() {
+	tty := false
+	if f, ok := w.(*os.File); ok {
+		tty = IsTerminal(f)
+	}
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				if tty {
+					fmt.Fprintln(w)
+				}
+				return
+			case <-ticker.C:
+				if tty {
+					fmt.Fprintf(w, "\r%s\033[K", line())
+				} else {
+					fmt.Fprintln(w, line())
+				}
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-finished
+	}
+}
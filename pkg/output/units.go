@@ -0,0 +1,71 @@
+package output
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FormatBytes renders a byte count with binary units, e.g. "1.5 GB"
+func FormatBytes(n int64) string {
+	value := float64(n)
+	units := []string{"B", "KB", "MB", "GB", "TB", "PB"}
+	unitIdx := 0
+	for value >= 1024.0 && unitIdx < len(units)-1 {
+		value /= 1024.0
+		unitIdx++
+	}
+	if unitIdx == 0 {
+		return fmt.Sprintf("%.0f %s", value, units[unitIdx])
+	}
+	return fmt.Sprintf("%.1f %s", value, units[unitIdx])
+}
+
+// ParseBytes parses a size such as "512", "10kb" or "5MB" using binary units
+func ParseBytes(s string) (int64, error) {
+	str := strings.ToLower(strings.TrimSpace(s))
+	multipliers := []struct {
+		suffix string
+		factor int64
+	}{
+		{"tb", 1 << 40}, {"gb", 1 << 30}, {"mb", 1 << 20}, {"kb", 1 << 10},
+		{"t", 1 << 40}, {"g", 1 << 30}, {"m", 1 << 20}, {"k", 1 << 10}, {"b", 1},
+	}
+	factor := int64(1)
+	for _, m := range multipliers {
+		if strings.HasSuffix(str, m.suffix) {
+			str = strings.TrimSpace(strings.TrimSuffix(str, m.suffix))
+			factor = m.factor
+			break
+		}
+	}
+	value, err := strconv.ParseFloat(str, 64)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	return int64(value * float64(factor)), nil
+}
+
+// ParseDuration parses a _cat API time value such as "41s", "12.5m", "3.2h" or "7d"
+func ParseDuration(s string) (time.Duration, error) {
+	str := strings.TrimSpace(s)
+	units := []struct {
+		suffix string
+		unit   time.Duration
+	}{
+		{"micros", time.Microsecond}, {"nanos", time.Nanosecond}, {"ms", time.Millisecond},
+		{"s", time.Second}, {"m", time.Minute}, {"h", time.Hour}, {"d", 24 * time.Hour},
+	}
+	for _, u := range units {
+		if !strings.HasSuffix(str, u.suffix) {
+			continue
+		}
+		value, err := strconv.ParseFloat(strings.TrimSuffix(str, u.suffix), 64)
+		if err != nil || value < 0 {
+			break
+		}
+		return time.Duration(value * float64(u.unit)), nil
+	}
+	return 0, fmt.Errorf("invalid duration %q", s)
+}
@@ -0,0 +1,29 @@
+// Package prompt asks the user to confirm destructive commands.
+package prompt
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Confirm prompts the user for confirmation unless -y flag is set
+func Confirm(cmd *cobra.Command, action string) bool {
+	// Check if -y flag is set
+	if yes, _ := cmd.Flags().GetBool("yes"); yes {
+		return true
+	}
+
+	fmt.Printf("Are you sure you want to %s? (y/N): ", action)
+	reader := bufio.NewReader(os.Stdin)
+	response, err := reader.ReadString('\n')
+	if err != nil {
+		return false
+	}
+
+	response = strings.TrimSpace(strings.ToLower(response))
+	return response == "y" || response == "yes"
+}
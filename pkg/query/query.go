@@ -0,0 +1,86 @@
+// Package query turns command line query arguments into query DSL.
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Parse accepts a JSON query DSL object, falling back to a query_string query.
+// An empty string matches all documents.
+func Parse(q string) (map[string]interface{}, error) {
+	trimmed := strings.TrimSpace(q)
+	if trimmed == "" {
+		return map[string]interface{}{"match_all": map[string]interface{}{}}, nil
+	}
+	if strings.HasPrefix(trimmed, "{") {
+		var query map[string]interface{}
+		if err := json.Unmarshal([]byte(trimmed), &query); err != nil {
+			return nil, fmt.Errorf("invalid JSON query: %v", err)
+		}
+		// Accept both {"match": ...} and {"query": {"match": ...}}
+		if inner, ok := query["query"].(map[string]interface{}); ok && len(query) == 1 {
+			return inner, nil
+		}
+		return query, nil
+	}
+	return map[string]interface{}{
+		"query_string": map[string]interface{}{"query": trimmed},
+	}, nil
+}
+
+var relativeTime = regexp.MustCompile(`^\d+(ms|s|m|h|H|d|w|M|y)$`)
+
+// ParseSort turns FIELD[:asc|desc] values into a sort clause
+func ParseSort(values []string) ([]interface{}, error) {
+	var sort []interface{}
+	for _, v := range values {
+		field, order, found := strings.Cut(v, ":")
+		if field == "" {
+			return nil, fmt.Errorf("invalid sort %q", v)
+		}
+		if !found {
+			order = "asc"
+		}
+		if order != "asc" && order != "desc" {
+			return nil, fmt.Errorf("invalid sort %q: order must be asc or desc", v)
+		}
+		sort = append(sort, map[string]interface{}{field: order})
+	}
+	return sort, nil
+}
+
+// TimeBound turns a relative duration such as 15m or 7d into date math (now-15m).
+// Dates and date math expressions are returned unchanged.
+func TimeBound(v string) string {
+	if relativeTime.MatchString(v) {
+		return "now-" + v
+	}
+	return v
+}
+
+// WithTimeRange restricts q to documents whose field is between from and to.
+// Either bound may be empty; q is returned unchanged when both are.
+func WithTimeRange(q map[string]interface{}, field, from, to string) map[string]interface{} {
+	if from == "" && to == "" {
+		return q
+	}
+	bounds := map[string]interface{}{}
+	if from != "" {
+		bounds["gte"] = TimeBound(from)
+	}
+	if to != "" {
+		bounds["lte"] = TimeBound(to)
+	}
+	if q == nil {
+		q = map[string]interface{}{"match_all": map[string]interface{}{}}
+	}
+	return map[string]interface{}{
+		"bool": map[string]interface{}{
+			"must":   []interface{}{q},
+			"filter": []interface{}{map[string]interface{}{"range": map[string]interface{}{field: bounds}}},
+		},
+	}
+}
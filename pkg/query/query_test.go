@@ -0,0 +1,87 @@
+package query
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	q, err := Parse(`{"query": {"term": {"status": "x"}}}`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if _, ok := q["term"]; !ok {
+		t.Errorf("Expected unwrapped term query, got %v", q)
+	}
+
+	q, err = Parse("status:shipped AND total:>100")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if qs, ok := q["query_string"].(map[string]interface{}); !ok || qs["query"] != "status:shipped AND total:>100" {
+		t.Errorf("Expected query_string query, got %v", q)
+	}
+
+	q, _ = Parse("  ")
+	if _, ok := q["match_all"]; !ok {
+		t.Errorf("Expected match_all for empty query, got %v", q)
+	}
+
+	if _, err := Parse(`{"term":`); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+}
+
+func TestParseSort(t *testing.T) {
+	sort, err := ParseSort([]string{"@timestamp:desc", "id"})
+	if err != nil {
+		t.Fatalf("ParseSort failed: %v", err)
+	}
+	expected := []interface{}{
+		map[string]interface{}{"@timestamp": "desc"},
+		map[string]interface{}{"id": "asc"},
+	}
+	if !reflect.DeepEqual(sort, expected) {
+		t.Errorf("Expected %v, got %v", expected, sort)
+	}
+
+	for _, invalid := range []string{":asc", "id:up"} {
+		if _, err := ParseSort([]string{invalid}); err == nil {
+			t.Errorf("Expected error for %q", invalid)
+		}
+	}
+}
+
+func TestWithTimeRange(t *testing.T) {
+	q := map[string]interface{}{"term": map[string]interface{}{"status": 500}}
+	if got := WithTimeRange(q, "@timestamp", "", ""); !reflect.DeepEqual(got, q) {
+		t.Errorf("Expected query unchanged, got %v", got)
+	}
+
+	got := WithTimeRange(q, "@timestamp", "15m", "2024-01-02")
+	expected := map[string]interface{}{
+		"bool": map[string]interface{}{
+			"must": []interface{}{q},
+			"filter": []interface{}{map[string]interface{}{"range": map[string]interface{}{
+				"@timestamp": map[string]interface{}{"gte": "now-15m", "lte": "2024-01-02"},
+			}}},
+		},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestTimeBound(t *testing.T) {
+	tests := map[string]string{
+		"15m":        "now-15m",
+		"7d":         "now-7d",
+		"now-1d/d":   "now-1d/d",
+		"2024-01-01": "2024-01-01",
+	}
+	for in, expected := range tests {
+		if got := TimeBound(in); got != expected {
+			t.Errorf("TimeBound(%q) = %q, expected %q", in, got, expected)
+		}
+	}
+}
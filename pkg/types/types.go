@@ -1,5 +1,10 @@
 package types
 
+import (
+	"encoding/json"
+	"fmt"
+)
+
 type ClusterHealth struct {
 	ClusterName         string `json:"cluster_name"`
 	Status              string `json:"status"`
@@ -34,6 +39,20 @@ type CatShardRow struct {
 	UnassignedReason string `json:"unassigned.reason,omitempty"`
 }
 
+// CatAllocationRow is a node in _cat/allocation. Disk sizes are in bytes; they are
+// empty for the UNASSIGNED row.
+type CatAllocationRow struct {
+	Shards      string `json:"shards"`
+	DiskIndices string `json:"disk.indices"`
+	DiskUsed    string `json:"disk.used"`
+	DiskAvail   string `json:"disk.avail"`
+	DiskTotal   string `json:"disk.total"`
+	DiskPercent string `json:"disk.percent"`
+	Host        string `json:"host"`
+	IP          string `json:"ip"`
+	Node        string `json:"node"`
+}
+
 // AllocationExplainRequest describes a shard to explain
 type AllocationExplainRequest struct {
 	Index   string `json:"index,omitempty"`
@@ -43,14 +62,15 @@ type AllocationExplainRequest struct {
 
 // AllocationExplainResponse is a simplified view of explain output
 type AllocationExplainResponse struct {
-	Index               string                   `json:"index"`
-	Shard               int                      `json:"shard"`
-	Primary             bool                     `json:"primary"`
-	CurrentNode         map[string]interface{}   `json:"current_node,omitempty"`
-	NodeExplanations    []map[string]interface{} `json:"node_explanations,omitempty"`
-	CanAllocate         string                   `json:"can_allocate"`
-	AllocateExplanation string                   `json:"allocate_explanation,omitempty"`
-	UnassignedInfo      map[string]interface{}   `json:"unassigned_info,omitempty"`
+	Index                   string                   `json:"index"`
+	Shard                   int                      `json:"shard"`
+	Primary                 bool                     `json:"primary"`
+	CurrentNode             map[string]interface{}   `json:"current_node,omitempty"`
+	NodeExplanations        []map[string]interface{} `json:"node_explanations,omitempty"`
+	NodeAllocationDecisions []map[string]interface{} `json:"node_allocation_decisions,omitempty"`
+	CanAllocate             string                   `json:"can_allocate"`
+	AllocateExplanation     string                   `json:"allocate_explanation,omitempty"`
+	UnassignedInfo          map[string]interface{}   `json:"unassigned_info,omitempty"`
 }
 
 // RerouteCommand supports multiple command forms
@@ -70,6 +90,40 @@ type RerouteResponse struct {
 type ClusterSettings struct {
 	Persistent map[string]interface{} `json:"persistent"`
 	Transient  map[string]interface{} `json:"transient"`
+	Defaults   map[string]interface{} `json:"defaults,omitempty"`
+}
+
+// Value returns the effective value of a dotted setting key and the section it was
+// found in. Transient settings take precedence over persistent ones, which take
+// precedence over defaults. Settings may be nested or flat.
+func (s ClusterSettings) Value(key string) (value string, section string) {
+	if v, ok := lookupSetting(s.Transient, key); ok {
+		return fmt.Sprint(v), "transient"
+	}
+	if v, ok := lookupSetting(s.Persistent, key); ok {
+		return fmt.Sprint(v), "persistent"
+	}
+	if v, ok := lookupSetting(s.Defaults, key); ok {
+		return fmt.Sprint(v), "defaults"
+	}
+	return "", ""
+}
+
+func lookupSetting(m map[string]interface{}, key string) (interface{}, bool) {
+	if v, ok := m[key]; ok && v != nil {
+		return v, true
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] != '.' {
+			continue
+		}
+		if sub, ok := m[key[:i]].(map[string]interface{}); ok {
+			if v, ok := lookupSetting(sub, key[i+1:]); ok {
+				return v, true
+			}
+		}
+	}
+	return nil, false
 }
 
 // ClusterStats is a lightweight representation of /_cluster/stats
@@ -90,6 +144,81 @@ type ClusterState struct {
 	Nodes        map[string]interface{} `json:"nodes,omitempty"`
 }
 
+// ClusterCoordination is metadata.cluster_coordination of the cluster state: the
+// voting configuration used to elect the master and the nodes excluded from it
+type ClusterCoordination struct {
+	Term                   int64                   `json:"term"`
+	LastCommittedConfig    []string                `json:"last_committed_config"`
+	LastAcceptedConfig     []string                `json:"last_accepted_config"`
+	VotingConfigExclusions []VotingConfigExclusion `json:"voting_config_exclusions"`
+}
+
+type VotingConfigExclusion struct {
+	NodeID   string `json:"node_id"`
+	NodeName string `json:"node_name"`
+}
+
+// Quorum is the number of votes from the committed voting configuration needed to
+// elect a master
+func (c ClusterCoordination) Quorum() int {
+	return len(c.LastCommittedConfig)/2 + 1
+}
+
+// Coordination decodes metadata.cluster_coordination. It returns nil when the state
+// was fetched without metadata.
+func (s *ClusterState) Coordination() (*ClusterCoordination, error) {
+	raw, ok := s.Metadata["cluster_coordination"]
+	if !ok {
+		return nil, nil
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	var c ClusterCoordination
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// NodeName returns the name of a node in the state, or an empty string when the node
+// is not part of the cluster
+func (s *ClusterState) NodeName(id string) string {
+	node, _ := s.Nodes[id].(map[string]interface{})
+	name, _ := node["name"].(string)
+	return name
+}
+
+// NodeID returns the ID of the node with the given name or ID
+func (s *ClusterState) NodeID(name string) (string, bool) {
+	if _, ok := s.Nodes[name]; ok {
+		return name, true
+	}
+	for id := range s.Nodes {
+		if s.NodeName(id) == name {
+			return id, true
+		}
+	}
+	return "", false
+}
+
+// RemainingVoters counts the nodes of the committed voting configuration that are
+// part of the cluster and are not among the given node IDs
+func (s *ClusterState) RemainingVoters(c *ClusterCoordination, without ...string) int {
+	gone := make(map[string]bool, len(without))
+	for _, id := range without {
+		gone[id] = true
+	}
+	count := 0
+	for _, id := range c.LastCommittedConfig {
+		if _, ok := s.Nodes[id]; ok && !gone[id] {
+			count++
+		}
+	}
+	return count
+}
+
 // ClusterPendingTasks represents /_cluster/pending_tasks
 type ClusterPendingTasks struct {
 	Tasks []map[string]interface{} `json:"tasks"`
@@ -120,19 +249,21 @@ type Node struct {
 	Load15m     string `json:"load_15m"`
 	NodeRole    string `json:"node.role"`
 	Master      string `json:"master"`
+	Uptime      string `json:"uptime"`
 }
 
 type DataStream struct {
-	Name               string             `json:"name"`
-	TimestampField     TimestampFieldType `json:"timestamp_field"`
-	Indices            []DataStreamIndex  `json:"indices"`
-	Generation         int                `json:"generation"`
-	Status             string             `json:"status"`
-	Template           string             `json:"template,omitempty"`
-	IlmPolicy          string             `json:"ilm_policy,omitempty"`
-	Hidden             bool               `json:"hidden,omitempty"`
-	System             bool               `json:"system,omitempty"`
-	AllowCustomRouting bool               `json:"allow_custom_routing,omitempty"`
+	Name               string                 `json:"name"`
+	TimestampField     TimestampFieldType     `json:"timestamp_field"`
+	Indices            []DataStreamIndex      `json:"indices"`
+	Generation         int                    `json:"generation"`
+	Status             string                 `json:"status"`
+	Template           string                 `json:"template,omitempty"`
+	IlmPolicy          string                 `json:"ilm_policy,omitempty"`
+	Lifecycle          map[string]interface{} `json:"lifecycle,omitempty"`
+	Hidden             bool                   `json:"hidden,omitempty"`
+	System             bool                   `json:"system,omitempty"`
+	AllowCustomRouting bool                   `json:"allow_custom_routing,omitempty"`
 }
 
 type TimestampFieldType struct {
@@ -191,3 +322,489 @@ type IngestPipeline struct {
 	Name string                 `json:"name"`
 	Body map[string]interface{} `json:"body"`
 }
+
+// Processors returns the processor types of the pipeline in order, or of its
+// on_failure handlers when onFailure is set
+func (p IngestPipeline) Processors(onFailure bool) []string {
+	key := "processors"
+	if onFailure {
+		key = "on_failure"
+	}
+	list, _ := p.Body[key].([]interface{})
+	names := make([]string, 0, len(list))
+	for _, item := range list {
+		// Each processor is an object with the processor type as its only key
+		if m, ok := item.(map[string]interface{}); ok {
+			for name := range m {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// SimulateResponse is the result of simulating an ingest pipeline, one entry per document
+type SimulateResponse struct {
+	Docs []SimulateResult `json:"docs"`
+}
+
+// SimulateResult holds the final document, or with verbose the document after every
+// processor. Error is set when the document failed.
+type SimulateResult struct {
+	Doc              *SimulateDocument         `json:"doc,omitempty"`
+	ProcessorResults []SimulateProcessorResult `json:"processor_results,omitempty"`
+	Error            map[string]interface{}    `json:"error,omitempty"`
+}
+
+type SimulateDocument struct {
+	Index  string                 `json:"_index,omitempty"`
+	ID     string                 `json:"_id,omitempty"`
+	Source map[string]interface{} `json:"_source"`
+	Ingest map[string]interface{} `json:"_ingest,omitempty"`
+}
+
+// SimulateProcessorResult is the outcome of one processor. Status is success, error,
+// error_ignored, skipped or dropped.
+type SimulateProcessorResult struct {
+	ProcessorType string                 `json:"processor_type,omitempty"`
+	Tag           string                 `json:"tag,omitempty"`
+	Status        string                 `json:"status,omitempty"`
+	Doc           *SimulateDocument      `json:"doc,omitempty"`
+	Error         map[string]interface{} `json:"error,omitempty"`
+	IgnoredError  map[string]interface{} `json:"ignored_error,omitempty"`
+}
+
+// Alias represents a row from _cat/aliases
+type Alias struct {
+	Alias         string `json:"alias"`
+	Index         string `json:"index"`
+	Filter        string `json:"filter"`
+	RoutingIndex  string `json:"routing.index"`
+	RoutingSearch string `json:"routing.search"`
+	IsWriteIndex  string `json:"is_write_index"`
+}
+
+// IndexAlias is the full definition of an alias on a single index, as returned by _alias
+type IndexAlias struct {
+	Alias         string                 `json:"alias"`
+	Index         string                 `json:"index"`
+	Filter        map[string]interface{} `json:"filter,omitempty"`
+	IndexRouting  string                 `json:"index_routing,omitempty"`
+	SearchRouting string                 `json:"search_routing,omitempty"`
+	IsWriteIndex  *bool                  `json:"is_write_index,omitempty"`
+	IsHidden      *bool                  `json:"is_hidden,omitempty"`
+}
+
+// AliasAction is a single add/remove/remove_index action for the _aliases API
+type AliasAction map[string]map[string]interface{}
+
+// IndexSettings holds the settings of a single index from _settings
+type IndexSettings struct {
+	Index    string                 `json:"index"`
+	Settings map[string]interface{} `json:"settings"`
+	Defaults map[string]interface{} `json:"defaults,omitempty"`
+}
+
+// IndexMapping holds the mappings of a single index from _mapping
+type IndexMapping struct {
+	Index    string                 `json:"index"`
+	Mappings map[string]interface{} `json:"mappings"`
+}
+
+// ShardsInfo is the _shards summary returned by broadcast index operations
+type ShardsInfo struct {
+	Total      int            `json:"total"`
+	Successful int            `json:"successful"`
+	Skipped    int            `json:"skipped,omitempty"`
+	Failed     int            `json:"failed"`
+	Failures   []ShardFailure `json:"failures,omitempty"`
+}
+
+type ShardFailure struct {
+	Index  string                 `json:"index"`
+	Shard  int                    `json:"shard"`
+	Node   string                 `json:"node,omitempty"`
+	Status string                 `json:"status,omitempty"`
+	Reason map[string]interface{} `json:"reason,omitempty"`
+}
+
+// IndexOperationResponse covers open/close/block and broadcast operations like refresh or flush
+type IndexOperationResponse struct {
+	Acknowledged       bool        `json:"acknowledged,omitempty"`
+	ShardsAcknowledged bool        `json:"shards_acknowledged,omitempty"`
+	Shards             *ShardsInfo `json:"_shards,omitempty"`
+	Indices            interface{} `json:"indices,omitempty"`
+}
+
+type ForceMergeOptions struct {
+	MaxNumSegments     int
+	OnlyExpungeDeletes bool
+	Flush              *bool
+}
+
+type ClearCacheOptions struct {
+	Query     bool
+	Fielddata bool
+	Request   bool
+	Fields    []string
+}
+
+// ResizeResponse is returned by the _shrink, _split and _clone APIs
+type ResizeResponse struct {
+	Acknowledged       bool   `json:"acknowledged"`
+	ShardsAcknowledged bool   `json:"shards_acknowledged"`
+	Index              string `json:"index"`
+}
+
+type ReindexOptions struct {
+	Slices            string
+	RequestsPerSecond float64
+	Refresh           bool
+}
+
+// TaskInfo describes a single running or completed task from the _tasks API
+type TaskInfo struct {
+	Node               string                 `json:"node"`
+	NodeName           string                 `json:"node_name,omitempty"`
+	ID                 int64                  `json:"id"`
+	Type               string                 `json:"type"`
+	Action             string                 `json:"action"`
+	Description        string                 `json:"description,omitempty"`
+	StartTimeInMillis  int64                  `json:"start_time_in_millis"`
+	RunningTimeInNanos int64                  `json:"running_time_in_nanos"`
+	Cancellable        bool                   `json:"cancellable"`
+	Cancelled          bool                   `json:"cancelled,omitempty"`
+	ParentTaskID       string                 `json:"parent_task_id,omitempty"`
+	Headers            map[string]string      `json:"headers,omitempty"`
+	Status             map[string]interface{} `json:"status,omitempty"`
+}
+
+type TaskListOptions struct {
+	Actions  string
+	Nodes    string
+	Parent   string
+	Detailed bool
+}
+
+// TaskResult is returned by GET _tasks/<id>; Response or Error is set once the task completed
+type TaskResult struct {
+	Completed bool                   `json:"completed"`
+	Task      TaskInfo               `json:"task"`
+	Response  map[string]interface{} `json:"response,omitempty"`
+	Error     map[string]interface{} `json:"error,omitempty"`
+}
+
+// BulkByScrollStatus is the status reported by reindex, update-by-query and delete-by-query tasks
+type BulkByScrollStatus struct {
+	Total                int64   `json:"total"`
+	Updated              int64   `json:"updated"`
+	Created              int64   `json:"created"`
+	Deleted              int64   `json:"deleted"`
+	Batches              int64   `json:"batches"`
+	VersionConflicts     int64   `json:"version_conflicts"`
+	Noops                int64   `json:"noops"`
+	RequestsPerSecond    float64 `json:"requests_per_second"`
+	ThrottledMillis      int64   `json:"throttled_millis"`
+	ThrottledUntilMillis int64   `json:"throttled_until_millis"`
+}
+
+// TaskID returns the node:id form used to address a task
+func (t TaskInfo) TaskID() string {
+	return fmt.Sprintf("%s:%d", t.Node, t.ID)
+}
+
+// SnapshotRepository is a registered snapshot repository from _snapshot
+type SnapshotRepository struct {
+	Name     string                 `json:"name"`
+	Type     string                 `json:"type"`
+	Settings map[string]interface{} `json:"settings,omitempty"`
+}
+
+// VerifiedNode is a node that could access a repository during _verify
+type VerifiedNode struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Snapshot describes a single snapshot from _snapshot/<repo>/<snapshot>
+type Snapshot struct {
+	Snapshot           string                          `json:"snapshot"`
+	UUID               string                          `json:"uuid"`
+	Repository         string                          `json:"repository,omitempty"`
+	Version            string                          `json:"version,omitempty"`
+	Indices            []string                        `json:"indices"`
+	DataStreams        []string                        `json:"data_streams,omitempty"`
+	IncludeGlobalState bool                            `json:"include_global_state"`
+	State              string                          `json:"state"`
+	Reason             string                          `json:"reason,omitempty"`
+	StartTime          string                          `json:"start_time,omitempty"`
+	StartTimeInMillis  int64                           `json:"start_time_in_millis,omitempty"`
+	EndTime            string                          `json:"end_time,omitempty"`
+	EndTimeInMillis    int64                           `json:"end_time_in_millis,omitempty"`
+	DurationInMillis   int64                           `json:"duration_in_millis,omitempty"`
+	Failures           []map[string]interface{}        `json:"failures,omitempty"`
+	Shards             ShardsInfo                      `json:"shards"`
+	Metadata           map[string]interface{}          `json:"metadata,omitempty"`
+	IndexDetails       map[string]SnapshotIndexDetails `json:"index_details,omitempty"`
+}
+
+// SnapshotIndexDetails is returned with index_details=true (Elasticsearch 7.13+)
+type SnapshotIndexDetails struct {
+	ShardCount          int   `json:"shard_count"`
+	SizeInBytes         int64 `json:"size_in_bytes"`
+	MaxSegmentsPerShard int   `json:"max_segments_per_shard"`
+}
+
+// SnapshotStatus is the detailed shard-level status from _snapshot/<repo>/<snapshot>/_status
+type SnapshotStatus struct {
+	Snapshot           string                         `json:"snapshot"`
+	Repository         string                         `json:"repository"`
+	UUID               string                         `json:"uuid"`
+	State              string                         `json:"state"`
+	IncludeGlobalState bool                           `json:"include_global_state"`
+	ShardsStats        SnapshotShardsStats            `json:"shards_stats"`
+	Stats              SnapshotStats                  `json:"stats"`
+	Indices            map[string]SnapshotIndexStatus `json:"indices"`
+}
+
+type SnapshotShardsStats struct {
+	Initializing int `json:"initializing"`
+	Started      int `json:"started"`
+	Finalizing   int `json:"finalizing"`
+	Done         int `json:"done"`
+	Failed       int `json:"failed"`
+	Total        int `json:"total"`
+}
+
+type SnapshotStats struct {
+	Incremental       SnapshotFileStats  `json:"incremental"`
+	Processed         *SnapshotFileStats `json:"processed,omitempty"`
+	Total             SnapshotFileStats  `json:"total"`
+	StartTimeInMillis int64              `json:"start_time_in_millis"`
+	TimeInMillis      int64              `json:"time_in_millis"`
+}
+
+type SnapshotFileStats struct {
+	FileCount   int   `json:"file_count"`
+	SizeInBytes int64 `json:"size_in_bytes"`
+}
+
+type SnapshotIndexStatus struct {
+	ShardsStats SnapshotShardsStats            `json:"shards_stats"`
+	Stats       SnapshotStats                  `json:"stats"`
+	Shards      map[string]SnapshotShardStatus `json:"shards"`
+}
+
+type SnapshotShardStatus struct {
+	Stage  string        `json:"stage"`
+	Node   string        `json:"node,omitempty"`
+	Reason string        `json:"reason,omitempty"`
+	Stats  SnapshotStats `json:"stats"`
+}
+
+// CatRecoveryRow represents a row from _cat/recovery (requested with bytes=b)
+type CatRecoveryRow struct {
+	Index        string `json:"index"`
+	Shard        string `json:"shard"`
+	Type         string `json:"type"`
+	Stage        string `json:"stage"`
+	SourceNode   string `json:"source_node"`
+	TargetNode   string `json:"target_node"`
+	Repository   string `json:"repository"`
+	Snapshot     string `json:"snapshot"`
+	FilesPercent string `json:"files_percent"`
+	BytesPercent string `json:"bytes_percent"`
+	BytesTotal   string `json:"bytes_total"`
+	Time         string `json:"time"`
+}
+
+// SnapshotPolicy is an Elasticsearch SLM policy or an OpenSearch snapshot management policy.
+// Schedule, Repository and Retention are summaries extracted from Policy for display.
+type SnapshotPolicy struct {
+	Name          string                 `json:"name"`
+	Schedule      string                 `json:"schedule,omitempty"`
+	Repository    string                 `json:"repository,omitempty"`
+	Retention     string                 `json:"retention,omitempty"`
+	Policy        map[string]interface{} `json:"policy"`
+	Version       int                    `json:"version,omitempty"`
+	ModifiedDate  string                 `json:"modified_date,omitempty"`
+	NextExecution string                 `json:"next_execution,omitempty"`
+	LastSuccess   map[string]interface{} `json:"last_success,omitempty"`
+	LastFailure   map[string]interface{} `json:"last_failure,omitempty"`
+}
+
+// Document is a single document as returned by the get API
+type Document struct {
+	Index       string                 `json:"_index"`
+	ID          string                 `json:"_id"`
+	Version     int64                  `json:"_version,omitempty"`
+	SeqNo       int64                  `json:"_seq_no"`
+	PrimaryTerm int64                  `json:"_primary_term"`
+	Routing     string                 `json:"_routing,omitempty"`
+	Found       bool                   `json:"found"`
+	Source      map[string]interface{} `json:"_source,omitempty"`
+}
+
+// DocumentOptions holds the query parameters shared by the document APIs
+type DocumentOptions struct {
+	Routing         string
+	Refresh         string
+	Pipeline        string
+	OpType          string
+	RetryOnConflict int
+	SourceIncludes  string
+	SourceExcludes  string
+}
+
+// DocumentWriteResult is the response of the index, update and delete APIs
+type DocumentWriteResult struct {
+	Index       string     `json:"_index"`
+	ID          string     `json:"_id"`
+	Version     int64      `json:"_version"`
+	Result      string     `json:"result"`
+	SeqNo       int64      `json:"_seq_no"`
+	PrimaryTerm int64      `json:"_primary_term"`
+	Shards      ShardsInfo `json:"_shards"`
+}
+
+// BulkResponse is the response of the _bulk API
+type BulkResponse struct {
+	Took   int64      `json:"took"`
+	Errors bool       `json:"errors"`
+	Items  []BulkItem `json:"items"`
+}
+
+// BulkItem is the result of one action in a bulk request
+type BulkItem struct {
+	Action string                 `json:"action"`
+	Index  string                 `json:"_index"`
+	ID     string                 `json:"_id"`
+	Status int                    `json:"status"`
+	Result string                 `json:"result,omitempty"`
+	Error  map[string]interface{} `json:"error,omitempty"`
+}
+
+// SearchOptions holds query parameters for the search API
+type SearchOptions struct {
+	Scroll  string
+	Routing string
+}
+
+// PointInTime is an open point in time. OpenSearch and Elasticsearch use different APIs to manage it.
+type PointInTime struct {
+	ID         string `json:"id"`
+	OpenSearch bool   `json:"opensearch,omitempty"`
+}
+
+// SearchResponse is the response of the search and scroll APIs
+type SearchResponse struct {
+	Took         int64                  `json:"took"`
+	TimedOut     bool                   `json:"timed_out"`
+	Shards       ShardsInfo             `json:"_shards"`
+	Hits         SearchHits             `json:"hits"`
+	Aggregations map[string]interface{} `json:"aggregations,omitempty"`
+	PitID        string                 `json:"pit_id,omitempty"`
+	ScrollID     string                 `json:"_scroll_id,omitempty"`
+}
+
+type SearchHits struct {
+	Total    SearchTotal `json:"total"`
+	MaxScore *float64    `json:"max_score"`
+	Hits     []SearchHit `json:"hits"`
+}
+
+// SearchTotal is hits.total, which older clusters report as a plain number
+type SearchTotal struct {
+	Value    int64  `json:"value"`
+	Relation string `json:"relation"`
+}
+
+func (t *SearchTotal) UnmarshalJSON(data []byte) error {
+	var n int64
+	if err := json.Unmarshal(data, &n); err == nil {
+		t.Value, t.Relation = n, "eq"
+		return nil
+	}
+	type plain SearchTotal
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+// SearchHit keeps _source as raw JSON so documents are passed through unchanged
+type SearchHit struct {
+	Index     string                 `json:"_index"`
+	ID        string                 `json:"_id"`
+	Score     *float64               `json:"_score"`
+	Routing   string                 `json:"_routing,omitempty"`
+	Source    json.RawMessage        `json:"_source,omitempty"`
+	Fields    map[string]interface{} `json:"fields,omitempty"`
+	Highlight map[string][]string    `json:"highlight,omitempty"`
+	Sort      []interface{}          `json:"sort,omitempty"`
+}
+
+// SQLColumn describes a column of an SQL or PPL result
+type SQLColumn struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// SQLResponse is a page of SQL or PPL results, normalized from the Elasticsearch and
+// OpenSearch formats. Cursor is set when more pages are available.
+type SQLResponse struct {
+	Columns    []SQLColumn     `json:"columns"`
+	Rows       [][]interface{} `json:"rows"`
+	Cursor     string          `json:"cursor,omitempty"`
+	OpenSearch bool            `json:"-"`
+}
+
+// AnalyzeToken is a token produced by the analyze API
+type AnalyzeToken struct {
+	Token          string `json:"token"`
+	StartOffset    int    `json:"start_offset"`
+	EndOffset      int    `json:"end_offset"`
+	Type           string `json:"type"`
+	Position       int    `json:"position"`
+	PositionLength int    `json:"positionLength,omitempty"`
+}
+
+// AnalyzeResponse holds the tokens of an analyze request, or with explain the output of
+// every analysis stage in Detail
+type AnalyzeResponse struct {
+	Tokens []AnalyzeToken `json:"tokens,omitempty"`
+	Detail *AnalyzeDetail `json:"detail,omitempty"`
+}
+
+type AnalyzeDetail struct {
+	CustomAnalyzer bool                `json:"custom_analyzer"`
+	Analyzer       *AnalyzeStage       `json:"analyzer,omitempty"`
+	CharFilters    []AnalyzeCharFilter `json:"charfilters,omitempty"`
+	Tokenizer      *AnalyzeStage       `json:"tokenizer,omitempty"`
+	TokenFilters   []AnalyzeStage      `json:"tokenfilters,omitempty"`
+}
+
+type AnalyzeStage struct {
+	Name   string         `json:"name"`
+	Tokens []AnalyzeToken `json:"tokens"`
+}
+
+type AnalyzeCharFilter struct {
+	Name         string   `json:"name"`
+	FilteredText []string `json:"filtered_text"`
+}
+
+// FieldCapsResponse is the _field_caps response: field name to type to capabilities
+type FieldCapsResponse struct {
+	Indices []string                              `json:"indices"`
+	Fields  map[string]map[string]FieldCapability `json:"fields"`
+}
+
+// FieldCapability describes one mapping type of a field. Indices is only set when the
+// field has different types across indices.
+type FieldCapability struct {
+	Type                   string   `json:"type"`
+	MetadataField          bool     `json:"metadata_field"`
+	Searchable             bool     `json:"searchable"`
+	Aggregatable           bool     `json:"aggregatable"`
+	Indices                []string `json:"indices,omitempty"`
+	NonSearchableIndices   []string `json:"non_searchable_indices,omitempty"`
+	NonAggregatableIndices []string `json:"non_aggregatable_indices,omitempty"`
+}